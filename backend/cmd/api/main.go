@@ -13,20 +13,37 @@ import (
 
 	"youtube-video-summarizer/backend/internal/config"
 	"youtube-video-summarizer/backend/internal/handlers"
+	"youtube-video-summarizer/backend/internal/jobs"
 	"youtube-video-summarizer/backend/internal/middleware"
 	"youtube-video-summarizer/backend/internal/repository"
+	"youtube-video-summarizer/backend/internal/services/bulkimport"
+	"youtube-video-summarizer/backend/internal/services/broadcast"
+	"youtube-video-summarizer/backend/internal/services/channel"
+	"youtube-video-summarizer/backend/internal/services/comment"
 	"youtube-video-summarizer/backend/internal/services/cost"
 	"youtube-video-summarizer/backend/internal/services/embedding"
 	kafkaservice "youtube-video-summarizer/backend/internal/services/kafka"
+	"youtube-video-summarizer/backend/internal/services/playlist"
 	"youtube-video-summarizer/backend/internal/services/provider"
+	"youtube-video-summarizer/backend/internal/services/revision"
+	"youtube-video-summarizer/backend/internal/services/search"
 	"youtube-video-summarizer/backend/internal/services/similarity"
 	settingsservice "youtube-video-summarizer/backend/internal/services/settings"
+	"youtube-video-summarizer/backend/internal/services/source"
+	"youtube-video-summarizer/backend/internal/services/stats"
 	"youtube-video-summarizer/backend/internal/services/summary"
 	"youtube-video-summarizer/backend/internal/services/transcript"
+	"youtube-video-summarizer/backend/internal/services/translation"
+	"youtube-video-summarizer/backend/internal/services/upload"
 	"youtube-video-summarizer/backend/internal/services/video"
 	"youtube-video-summarizer/backend/internal/workers"
+	"youtube-video-summarizer/backend/pkg/artifact"
+	"youtube-video-summarizer/backend/pkg/audio"
+	"youtube-video-summarizer/backend/pkg/downloader"
 	"youtube-video-summarizer/backend/pkg/errors"
 	"youtube-video-summarizer/backend/pkg/kafka"
+	"youtube-video-summarizer/backend/pkg/podcast"
+	"youtube-video-summarizer/backend/pkg/whisper"
 	"youtube-video-summarizer/backend/pkg/youtube"
 
 	"github.com/gin-gonic/gin"
@@ -75,9 +92,93 @@ func main() {
 	similarityRepo := repository.NewSimilarityRepository(db)
 	costRepo := repository.NewCostRepository(db)
 	settingsRepo := repository.NewSettingsRepository(db)
+	playlistRepo := repository.NewPlaylistRepository(db)
+	channelSubscriptionRepo := repository.NewChannelSubscriptionRepository(db)
+	importJobRepo := repository.NewImportJobRepository(db)
+	uploadRepo := repository.NewUploadRepository(db)
+	videoStatsRepo := repository.NewVideoStatsRepository(db)
+	chapterRepo := repository.NewChapterRepository(db)
+	commentRepo := repository.NewCommentRepository(db)
+	searchRepo := repository.NewSearchRepository(db)
+	revisionRepo := repository.NewTranscriptRevisionRepository(db)
 
 	// Initialize YouTube client
-	youtubeClient := youtube.NewClient(cfg.YouTube.APIKey)
+	youtubeClient := youtube.NewClient(
+		cfg.YouTube.APIKey,
+		youtube.WithDailyQuota(cfg.YouTube.DailyQuota),
+		youtube.WithCacheTTL(time.Duration(cfg.YouTube.CacheTTLMinutes)*time.Minute),
+	)
+
+	// Initialize yt-dlp (audio, caption and chapter downloads)
+	ytDlp := downloader.NewYtDlp(downloader.Config{
+		BinaryPath:      cfg.YtDlp.BinaryPath,
+		Proxy:           cfg.YtDlp.Proxy,
+		CookiesFile:     cfg.YtDlp.CookiesFile,
+		Retries:         cfg.YtDlp.Retries,
+		RateLimit:       cfg.YtDlp.RateLimit,
+		SleepRequests:   time.Duration(cfg.YtDlp.SleepRequestsSeconds) * time.Second,
+		MetadataTimeout: time.Duration(cfg.YtDlp.MetadataTimeoutSeconds) * time.Second,
+		DownloadTimeout: time.Duration(cfg.YtDlp.DownloadTimeoutMinutes) * time.Minute,
+	})
+
+	// Initialize the download workspace (isolated, shared and size-capped audio downloads)
+	downloadWorkspace, err := downloader.NewWorkspace(
+		cfg.Downloads.Dir,
+		cfg.Downloads.MaxConcurrent,
+		int64(cfg.Downloads.DiskQuotaMB)*1024*1024,
+		time.Duration(cfg.YtDlp.DownloadTimeoutMinutes)*time.Minute,
+	)
+	if err != nil {
+		logger.Fatal("Failed to initialize download workspace", zap.Error(err))
+	}
+
+	// Initialize the artifact store (downloaded audio kept for reuse across stages)
+	var audioArtifacts artifact.Store
+	switch cfg.Artifacts.Store {
+	case "local":
+		localStore, err := artifact.NewLocalStore(cfg.Artifacts.Dir)
+		if err != nil {
+			logger.Fatal("Failed to initialize artifact store", zap.Error(err))
+		}
+		audioArtifacts = localStore
+	case "s3":
+		s3Store, err := artifact.NewS3Store(artifact.S3Config{
+			Endpoint:  cfg.Artifacts.S3Endpoint,
+			Bucket:    cfg.Artifacts.S3Bucket,
+			Region:    cfg.Artifacts.S3Region,
+			AccessKey: cfg.Artifacts.S3AccessKey,
+			SecretKey: cfg.Artifacts.S3SecretKey,
+			PathStyle: cfg.Artifacts.S3PathStyle,
+		})
+		if err != nil {
+			logger.Fatal("Failed to initialize artifact store", zap.Error(err))
+		}
+		audioArtifacts = s3Store
+	}
+
+	// Initialize audio chunking (audio over a provider's upload limit is split at pauses)
+	var audioChunker *whisper.Chunker
+	if cfg.Chunking.Enabled {
+		audioChunker = whisper.NewChunker(
+			audio.NewSplitter(audio.Config{
+				FFmpegPath:       cfg.Chunking.FFmpegPath,
+				SilenceThreshold: cfg.Chunking.SilenceThreshold,
+				MinSilence:       time.Duration(cfg.Chunking.MinSilenceMS) * time.Millisecond,
+			}),
+			whisper.ChunkerConfig{
+				MaxChunkBytes: int64(cfg.Chunking.MaxChunkMB) * 1024 * 1024,
+				Parallelism:   cfg.Chunking.Parallelism,
+			},
+		)
+	}
+
+	// Initialize media sources (where metadata, captions and audio come from)
+	podcastSource := source.NewPodcastSource(podcast.NewClient(), logger)
+	mediaSources := source.NewRegistry(
+		source.NewYouTubeSource(youtubeClient, ytDlp, logger),
+		source.NewUploadSource(),
+		podcastSource,
+	)
 
 	// Initialize services
 	costService := cost.NewService(costRepo, logger)
@@ -86,7 +187,7 @@ func main() {
 	// Initialize provider factory (manages providers based on settings)
 	providerFactory := provider.NewProviderFactory(settingsService, cfg, logger)
 	
-	videoService := video.NewService(videoRepo, youtubeClient, logger)
+	videoService := video.NewService(videoRepo, chapterRepo, mediaSources, logger)
 	summaryService := summary.NewService(summaryRepo, providerFactory, costService, settingsService, logger, cfg)
 	
 	// Initialize transcript service
@@ -94,7 +195,13 @@ func main() {
 		transcriptRepo,
 		videoRepo,
 		providerFactory,
-		youtubeClient,
+		mediaSources,
+		ytDlp,
+		downloadWorkspace,
+		audioArtifacts,
+		audioChunker,
+		cfg.Captions.MinQuality,
+		cfg.Whisper.Diarize,
 		costService,
 		logger,
 	)
@@ -103,7 +210,7 @@ func main() {
 	embeddingService := embedding.NewService(embeddingRepo, providerFactory, costService, transcriptService, logger)
 	
 	// Initialize similarity service
-	similarityService := similarity.NewService(similarityRepo, embeddingRepo, videoRepo, youtubeClient, logger)
+	similarityService := similarity.NewService(similarityRepo, embeddingRepo, videoRepo, mediaSources, logger)
 
 	// Initialize Kafka producer if enabled
 	var kafkaProducer *kafka.Producer
@@ -120,6 +227,58 @@ func main() {
 		logger.Info("Kafka is disabled, using direct processing")
 	}
 
+	// Initialize analysis dispatcher (Kafka events with direct-processing fallback)
+	analysisJob := jobs.NewAnalysisJob(videoService, transcriptService, embeddingService, similarityService, logger)
+	analysisDispatcher := jobs.NewDispatcher(analysisJob, videoEventService, logger)
+
+	// Initialize playlist service
+	playlistService := playlist.NewService(playlistRepo, videoService, analysisDispatcher, youtubeClient, logger)
+
+	// Initialize bulk import service
+	importService := bulkimport.NewService(importJobRepo, videoRepo, videoService, analysisDispatcher, youtubeClient, logger)
+	if err := importService.Resume(context.Background()); err != nil {
+		logger.Warn("Failed to resume import jobs", zap.Error(err))
+	}
+
+	// Initialize file upload service
+	uploadService := upload.NewService(uploadRepo, videoRepo, analysisDispatcher, cfg.Upload.Dir, cfg.Upload.MaxSizeMB, logger)
+
+	// Initialize video stats service (periodic metadata refresh)
+	statsService := stats.NewService(
+		videoRepo,
+		videoStatsRepo,
+		youtubeClient,
+		time.Duration(cfg.Refresh.IntervalHours)*time.Hour,
+		cfg.Refresh.BatchSize,
+		logger,
+	)
+
+	// Initialize broadcast service (deferred analysis of premieres and live streams)
+	broadcastService := broadcast.NewService(videoRepo, youtubeClient, analysisDispatcher, logger)
+
+	// Initialize comment service (audience-reaction summaries)
+	commentService := comment.NewService(videoRepo, commentRepo, youtubeClient, providerFactory, costService, logger)
+
+	// Initialize transcript translation service (subtitles in other languages)
+	translationService := translation.NewService(transcriptRepo, providerFactory, costService, logger)
+
+	// Initialize transcript revision service (editor corrections with history)
+	revisionService := revision.NewService(transcriptRepo, revisionRepo, logger)
+
+	// Initialize transcript search service
+	searchService := search.NewService(searchRepo, logger)
+
+	// Initialize channel subscription service
+	channelService := channel.NewService(
+		channelSubscriptionRepo,
+		videoService,
+		analysisDispatcher,
+		transcriptService,
+		summaryService,
+		youtubeClient,
+		logger,
+	)
+
 	// Start Kafka workers if enabled
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -134,7 +293,13 @@ func main() {
 			transcriptRepo,
 			videoRepo,
 			providerFactory,
-			youtubeClient,
+			mediaSources,
+			ytDlp,
+			downloadWorkspace,
+			audioArtifacts,
+			audioChunker,
+			cfg.Captions.MinQuality,
+			cfg.Whisper.Diarize,
 			costService,
 			videoEventService,
 			logger,
@@ -181,6 +346,53 @@ func main() {
 		)
 	}
 
+	// Start channel poller if enabled
+	if cfg.Channels.PollEnabled && cfg.YouTube.APIKey != "" {
+		channelPoller := jobs.NewChannelPoller(
+			channelService,
+			time.Duration(cfg.Channels.PollIntervalMinutes)*time.Minute,
+			logger,
+		)
+		go channelPoller.Start(ctx)
+	} else {
+		logger.Info("Channel poller is disabled")
+	}
+
+	// Start metadata refresher if enabled
+	if cfg.Refresh.Enabled && cfg.YouTube.APIKey != "" {
+		go jobs.NewMetadataRefresher(statsService, logger).Start(ctx)
+	} else {
+		logger.Info("Metadata refresher is disabled")
+	}
+
+	// Start broadcast scheduler if enabled
+	if cfg.Broadcast.CheckEnabled && cfg.YouTube.APIKey != "" {
+		broadcastScheduler := jobs.NewBroadcastScheduler(
+			broadcastService,
+			time.Duration(cfg.Broadcast.CheckIntervalMinutes)*time.Minute,
+			logger,
+		)
+		go broadcastScheduler.Start(ctx)
+	} else {
+		logger.Info("Broadcast scheduler is disabled")
+	}
+
+	// Start artifact pruner when audio is being stored
+	if audioArtifacts != nil {
+		artifactPruner := jobs.NewArtifactPruner(
+			audioArtifacts,
+			artifact.Retention{
+				MaxAge:       time.Duration(cfg.Artifacts.RetentionHours) * time.Hour,
+				MaxTotalSize: int64(cfg.Artifacts.MaxSizeMB) * 1024 * 1024,
+			},
+			time.Duration(cfg.Artifacts.PruneIntervalHours)*time.Hour,
+			logger,
+		)
+		go artifactPruner.Start(ctx)
+	} else {
+		logger.Info("Artifact pruner is disabled")
+	}
+
 	// Initialize router
 	router := gin.New()
 
@@ -213,6 +425,17 @@ func main() {
 			videoEventService,
 			logger,
 		)
+		handlers.RegisterPlaylistRoutes(api, playlistService, logger)
+		handlers.RegisterChannelRoutes(api, channelService, logger)
+		handlers.RegisterImportRoutes(api, importService, logger)
+		handlers.RegisterUploadRoutes(api, uploadService, logger)
+		handlers.RegisterStatsRoutes(api, statsService, logger)
+		handlers.RegisterCommentRoutes(api, commentService, logger)
+		handlers.RegisterTranslationRoutes(api, translationService, logger)
+		handlers.RegisterRevisionRoutes(api, revisionService, logger)
+		handlers.RegisterSearchRoutes(api, searchService, logger)
+		handlers.RegisterSourceRoutes(api, mediaSources, podcastSource, logger)
+		handlers.RegisterQuotaRoutes(api, youtubeClient, logger)
 		handlers.RegisterSettingsRoutes(api, settingsService, cfg, logger)
 		handlers.RegisterCostRoutes(api, costService, logger)
 	}
@@ -244,6 +467,8 @@ func main() {
 
 	// Cancel worker contexts
 	cancel()
+	playlistService.Stop()
+	importService.Stop()
 
 	// Shutdown HTTP server
 	if err := srv.Shutdown(shutdownCtx); err != nil {
@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/services/channel"
+	"youtube-video-summarizer/backend/pkg/errors"
+)
+
+func RegisterChannelRoutes(router *gin.RouterGroup, channelService ChannelService, logger *zap.Logger) {
+	handler := &ChannelHandler{
+		channelService: channelService,
+		logger:         logger,
+	}
+
+	channels := router.Group("/channels")
+	{
+		channels.POST("", handler.CreateSubscription)
+		channels.GET("", handler.ListSubscriptions)
+		channels.GET("/:id", handler.GetSubscription)
+		channels.PATCH("/:id", handler.UpdateSubscription)
+		channels.DELETE("/:id", handler.DeleteSubscription)
+		channels.POST("/:id/check", handler.CheckSubscription)
+	}
+}
+
+type ChannelHandler struct {
+	channelService ChannelService
+	logger         *zap.Logger
+}
+
+func (h *ChannelHandler) CreateSubscription(c *gin.Context) {
+	var req struct {
+		URL       string `json:"url"`
+		ChannelID string `json:"channel_id"`
+		channel.Filters
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid request body",
+		))
+		return
+	}
+
+	// Support both url and channel_id fields
+	channelURL := req.URL
+	if channelURL == "" {
+		channelURL = req.ChannelID
+	}
+	if channelURL == "" {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeMissingParameter,
+			"url or channel_id is required",
+		))
+		return
+	}
+
+	subscription, err := h.channelService.Subscribe(c.Request.Context(), channelURL, req.Filters)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, subscription)
+}
+
+func (h *ChannelHandler) ListSubscriptions(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	subscriptions, total, err := h.channelService.List(c.Request.Context(), limit, offset)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"channels": subscriptions,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+	})
+}
+
+func (h *ChannelHandler) GetSubscription(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	subscription, err := h.channelService.GetByID(c.Request.Context(), id)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+func (h *ChannelHandler) UpdateSubscription(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	var req channel.SubscriptionUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid request body",
+		))
+		return
+	}
+
+	subscription, err := h.channelService.Update(c.Request.Context(), id, req)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+func (h *ChannelHandler) DeleteSubscription(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.channelService.Delete(c.Request.Context(), id); err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Channel subscription deleted"})
+}
+
+func (h *ChannelHandler) CheckSubscription(c *gin.Context) {
+	id, ok := h.parseID(c)
+	if !ok {
+		return
+	}
+
+	subscription, err := h.channelService.GetByID(c.Request.Context(), id)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	ingested, err := h.channelService.CheckSubscription(c.Request.Context(), subscription)
+	if err != nil {
+		errors.HandleError(c, errors.Wrap(err, errors.ErrorCodeYouTubeAPI, errors.SubCodeYouTubeAPIFailed, "Failed to check channel uploads"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"channel":  subscription,
+		"ingested": ingested,
+	})
+}
+
+func (h *ChannelHandler) parseID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid channel subscription ID format",
+		))
+		return uuid.Nil, false
+	}
+	return id, true
+}
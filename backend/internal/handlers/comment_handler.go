@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/pkg/errors"
+)
+
+func RegisterCommentRoutes(router *gin.RouterGroup, commentService CommentService, logger *zap.Logger) {
+	handler := &CommentHandler{
+		commentService: commentService,
+		logger:         logger,
+	}
+
+	router.GET("/videos/:id/comments", handler.ListComments)
+	router.GET("/videos/:id/comments/summary", handler.GetSummary)
+}
+
+type CommentHandler struct {
+	commentService CommentService
+	logger         *zap.Logger
+}
+
+// ListComments returns the most liked comments (limit, default 50, max 200)
+func (h *CommentHandler) ListComments(c *gin.Context) {
+	videoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid video ID format",
+		))
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if limit <= 0 || limit > 200 {
+		limit = 200
+	}
+
+	comments, err := h.commentService.ListComments(c.Request.Context(), videoID, limit)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"comments": comments})
+}
+
+// GetSummary returns the audience-reaction summary of a video. refresh=true re-fetches the
+// comments and generates a new summary.
+func (h *CommentHandler) GetSummary(c *gin.Context) {
+	videoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid video ID format",
+		))
+		return
+	}
+
+	language := c.DefaultQuery("language", "auto")
+	refresh := c.Query("refresh") == "true"
+
+	summary, err := h.commentService.GetSummary(c.Request.Context(), videoID, language, refresh)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/pkg/errors"
+)
+
+// maxImportBodySize limits the size of an uploaded import list (1MB)
+const maxImportBodySize = 1 << 20
+
+func RegisterImportRoutes(router *gin.RouterGroup, importService ImportService, logger *zap.Logger) {
+	handler := &ImportHandler{
+		importService: importService,
+		logger:        logger,
+	}
+
+	imports := router.Group("/imports")
+	{
+		imports.POST("", handler.CreateImport)
+		imports.GET("", handler.ListImports)
+		imports.GET("/:id", handler.GetImport)
+	}
+}
+
+type ImportHandler struct {
+	importService ImportService
+	logger        *zap.Logger
+}
+
+// CreateImport accepts a newline- or CSV-formatted list as a raw text/csv body, a multipart "file"
+// upload, or JSON ({"urls": [...]} or {"content": "..."}), and starts the import in the background.
+func (h *ImportHandler) CreateImport(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBodySize)
+
+	content, err := h.readImportList(c)
+	if err != nil {
+		errors.AbortWithError(c, errors.NewWithError(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid request body",
+			err,
+		))
+		return
+	}
+
+	job, err := h.importService.CreateJob(c.Request.Context(), content)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	if job.Status != "completed" {
+		h.importService.StartProcess(job.ID)
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"import_job_id":     job.ID,
+		"status":            job.Status,
+		"total":             job.Total,
+		"invalid":           job.Invalid,
+		"skipped_duplicate": job.SkippedDuplicate,
+	})
+}
+
+func (h *ImportHandler) ListImports(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	jobs, total, err := h.importService.List(c.Request.Context(), limit, offset)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"imports": jobs,
+		"total":   total,
+		"limit":   limit,
+		"offset":  offset,
+	})
+}
+
+func (h *ImportHandler) GetImport(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid import job ID format",
+		))
+		return
+	}
+
+	job, err := h.importService.GetByID(c.Request.Context(), id)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+func (h *ImportHandler) readImportList(c *gin.Context) (string, error) {
+	contentType := c.ContentType()
+
+	switch {
+	case contentType == "application/json":
+		var req struct {
+			URLs    []string `json:"urls"`
+			Content string   `json:"content"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return "", err
+		}
+		if len(req.URLs) > 0 {
+			return strings.Join(req.URLs, "\n"), nil
+		}
+		return req.Content, nil
+
+	case strings.HasPrefix(contentType, "multipart/"):
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			return "", err
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			return "", err
+		}
+		defer file.Close()
+		data, err := io.ReadAll(file)
+		return string(data), err
+
+	default:
+		data, err := io.ReadAll(c.Request.Body)
+		return string(data), err
+	}
+}
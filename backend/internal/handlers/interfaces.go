@@ -2,21 +2,31 @@ package handlers
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/google/uuid"
 	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/internal/services/channel"
 	"youtube-video-summarizer/backend/internal/services/embedding"
+	"youtube-video-summarizer/backend/internal/services/revision"
+	"youtube-video-summarizer/backend/internal/services/source"
+	"youtube-video-summarizer/backend/internal/services/stats"
 	"youtube-video-summarizer/backend/internal/services/transcript"
+	"youtube-video-summarizer/backend/pkg/podcast"
+	"youtube-video-summarizer/backend/pkg/youtube"
 )
 
 // Service interfaces for dependency injection and testing
 
 type VideoService interface {
 	CreateFromURL(ctx context.Context, url string) (*models.Video, error)
+	CreateFromSource(ctx context.Context, sourceType string, ref source.Reference) (*models.Video, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Video, error)
 	List(ctx context.Context, limit, offset int) ([]*models.Video, int, error)
 	UpdateStatus(ctx context.Context, id uuid.UUID, status string) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	GetChapters(ctx context.Context, id uuid.UUID) ([]*models.Chapter, error)
 }
 
 // AvailableLanguage is an alias for transcript.AvailableLanguage
@@ -25,19 +35,25 @@ type AvailableLanguage = transcript.AvailableLanguage
 type TranscriptService interface {
 	GetOrCreateTranscript(ctx context.Context, videoID uuid.UUID, languageCode ...string) (*models.Transcript, error)
 	GetByVideoID(ctx context.Context, videoID uuid.UUID) (*models.Transcript, error)
-	DownloadAudio(youtubeID string) (string, error)
+	ListByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.Transcript, error)
+	Get(ctx context.Context, videoID, transcriptID uuid.UUID) (*models.Transcript, error)
+	GetStored(ctx context.Context, videoID uuid.UUID, language string) (*models.Transcript, error)
+	SetPrimary(ctx context.Context, videoID, transcriptID uuid.UUID) (*models.Transcript, error)
+	NameSpeakers(ctx context.Context, videoID, transcriptID uuid.UUID, names map[string]string) (*models.Transcript, error)
+	GetAudio(ctx context.Context, video *models.Video) (string, func(), error)
 	ListAvailableLanguages(ctx context.Context, youtubeID string) ([]transcript.AvailableLanguage, error)
 }
 
 type SummaryService interface {
-	GenerateSummary(ctx context.Context, videoID uuid.UUID, transcript string, summaryType string, language string) (*models.Summary, error)
+	GenerateSummary(ctx context.Context, videoID uuid.UUID, transcript *models.Transcript, summaryType string, language string) (*models.Summary, error)
 	GenerateSummaryFromAudio(ctx context.Context, videoID uuid.UUID, audioPath string, summaryType string, language string) (*models.Summary, error)
+	GenerateChapteredSummary(ctx context.Context, videoID uuid.UUID, transcript *models.Transcript, chapters []*models.Chapter, language string) (*models.Summary, error)
 	TranslateSummary(ctx context.Context, videoID uuid.UUID, targetLanguage string) (*models.Summary, error)
 	GetByVideoID(ctx context.Context, videoID uuid.UUID) (*models.Summary, error)
 }
 
 type EmbeddingService interface {
-	GenerateVideoEmbeddings(ctx context.Context, video *models.Video, transcript string) (*embedding.VideoEmbeddings, error)
+	GenerateVideoEmbeddings(ctx context.Context, video *models.Video, transcript *models.Transcript) (*embedding.VideoEmbeddings, error)
 }
 
 type CostService interface {
@@ -64,6 +80,75 @@ type CostBreakdownItem struct {
 	OutputTokens int
 }
 
+type PlaylistService interface {
+	Import(ctx context.Context, playlistURL string) (*models.Playlist, error)
+	StartIngest(id uuid.UUID)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Playlist, error)
+	List(ctx context.Context, limit, offset int) ([]*models.Playlist, int, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type ChannelService interface {
+	Subscribe(ctx context.Context, channelURL string, filters channel.Filters) (*models.ChannelSubscription, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ChannelSubscription, error)
+	List(ctx context.Context, limit, offset int) ([]*models.ChannelSubscription, int, error)
+	Update(ctx context.Context, id uuid.UUID, update channel.SubscriptionUpdate) (*models.ChannelSubscription, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	CheckSubscription(ctx context.Context, subscription *models.ChannelSubscription) (int, error)
+}
+
+type ImportService interface {
+	CreateJob(ctx context.Context, content string) (*models.ImportJob, error)
+	StartProcess(id uuid.UUID)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ImportJob, error)
+	List(ctx context.Context, limit, offset int) ([]*models.ImportJob, int, error)
+}
+
+type UploadService interface {
+	CreateFromFile(ctx context.Context, filename, title string, r io.Reader) (*models.Video, error)
+	StartUpload(ctx context.Context, filename, title string, size int64) (*models.Upload, error)
+	WriteChunk(ctx context.Context, id uuid.UUID, offset int64, r io.Reader) (*models.Upload, error)
+	GetUpload(ctx context.Context, id uuid.UUID) (*models.Upload, error)
+	GetMediaPath(ctx context.Context, videoID uuid.UUID) (string, error)
+	MaxSize() int64
+}
+
+type StatsService interface {
+	GetStats(ctx context.Context, videoID uuid.UUID, since time.Time) (*stats.Stats, error)
+	Refresh(ctx context.Context, videoID uuid.UUID) (*models.Video, error)
+}
+
+type SearchService interface {
+	SearchTranscripts(ctx context.Context, query models.TranscriptSearchQuery) ([]*models.TranscriptSearchResult, int, error)
+}
+
+type TranslationService interface {
+	TranslateTranscript(ctx context.Context, videoID, transcriptID uuid.UUID, language string) (*models.Transcript, error)
+}
+
+type RevisionService interface {
+	EditSegments(ctx context.Context, videoID, transcriptID uuid.UUID, edit revision.Edit) (*models.Transcript, *models.TranscriptRevision, error)
+	ListRevisions(ctx context.Context, videoID, transcriptID uuid.UUID) ([]*models.TranscriptRevision, error)
+	GetRevision(ctx context.Context, videoID, transcriptID uuid.UUID, number int) (*models.TranscriptRevision, error)
+}
+
+type CommentService interface {
+	ListComments(ctx context.Context, videoID uuid.UUID, limit int) ([]*models.Comment, error)
+	GetSummary(ctx context.Context, videoID uuid.UUID, language string, refresh bool) (*models.CommentSummary, error)
+}
+
+type YouTubeQuota interface {
+	QuotaUsage() youtube.QuotaUsage
+}
+
+type SourceRegistry interface {
+	Types() []string
+}
+
+type PodcastFeedService interface {
+	GetFeed(ctx context.Context, feedURL string) (*podcast.Feed, error)
+}
+
 type SimilarityService interface {
 	FindSimilarVideos(ctx context.Context, videoID uuid.UUID, limit int, minThreshold float64) ([]models.SimilarVideo, error)
 }
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/pkg/errors"
+)
+
+func RegisterPlaylistRoutes(router *gin.RouterGroup, playlistService PlaylistService, logger *zap.Logger) {
+	handler := &PlaylistHandler{
+		playlistService: playlistService,
+		logger:          logger,
+	}
+
+	playlists := router.Group("/playlists")
+	{
+		playlists.POST("", handler.CreatePlaylist)
+		playlists.GET("", handler.ListPlaylists)
+		playlists.GET("/:id", handler.GetPlaylist)
+		playlists.DELETE("/:id", handler.DeletePlaylist)
+	}
+}
+
+type PlaylistHandler struct {
+	playlistService PlaylistService
+	logger          *zap.Logger
+}
+
+func (h *PlaylistHandler) CreatePlaylist(c *gin.Context) {
+	var req struct {
+		URL        string `json:"url"`
+		PlaylistID string `json:"playlist_id"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid request body",
+		))
+		return
+	}
+
+	// Support both url and playlist_id fields
+	playlistURL := req.URL
+	if playlistURL == "" {
+		playlistURL = req.PlaylistID
+	}
+	if playlistURL == "" {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeMissingParameter,
+			"url or playlist_id is required",
+		))
+		return
+	}
+
+	playlist, err := h.playlistService.Import(c.Request.Context(), playlistURL)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	// Create videos and queue their analysis in the background; progress is visible via GET /playlists/:id
+	h.playlistService.StartIngest(playlist.ID)
+
+	h.logger.Info("Playlist import started",
+		zap.String("playlist_id", playlist.ID.String()),
+		zap.Int("items", len(playlist.Items)),
+	)
+	c.JSON(http.StatusAccepted, playlist)
+}
+
+func (h *PlaylistHandler) ListPlaylists(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	playlists, total, err := h.playlistService.List(c.Request.Context(), limit, offset)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"playlists": playlists,
+		"total":     total,
+		"limit":     limit,
+		"offset":    offset,
+	})
+}
+
+func (h *PlaylistHandler) GetPlaylist(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid playlist ID format",
+		))
+		return
+	}
+
+	playlist, err := h.playlistService.GetByID(c.Request.Context(), id)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	// Summarize per-item progress for the client; created items whose analysis finished count as completed
+	progress := map[string]int{"pending": 0, "created": 0, "failed": 0, "completed": 0}
+	for _, item := range playlist.Items {
+		status := item.Status
+		if status == "created" && item.Video != nil && item.Video.Status == "completed" {
+			status = "completed"
+		}
+		progress[status]++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"playlist": playlist,
+		"progress": progress,
+	})
+}
+
+func (h *PlaylistHandler) DeletePlaylist(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid playlist ID format",
+		))
+		return
+	}
+
+	if err := h.playlistService.Delete(c.Request.Context(), id); err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Playlist deleted"})
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func RegisterQuotaRoutes(router *gin.RouterGroup, youtubeQuota YouTubeQuota, logger *zap.Logger) {
+	handler := &QuotaHandler{
+		youtubeQuota: youtubeQuota,
+		logger:       logger,
+	}
+
+	router.GET("/quota/youtube", handler.GetYouTubeQuota)
+}
+
+type QuotaHandler struct {
+	youtubeQuota YouTubeQuota
+	logger       *zap.Logger
+}
+
+// GetYouTubeQuota reports today's YouTube Data API quota usage per endpoint, including cache hits
+func (h *QuotaHandler) GetYouTubeQuota(c *gin.Context) {
+	c.JSON(http.StatusOK, h.youtubeQuota.QuotaUsage())
+}
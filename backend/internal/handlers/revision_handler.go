@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/services/revision"
+	"youtube-video-summarizer/backend/pkg/errors"
+)
+
+func RegisterRevisionRoutes(router *gin.RouterGroup, revisionService RevisionService, logger *zap.Logger) {
+	handler := &RevisionHandler{
+		revisionService: revisionService,
+		logger:          logger,
+	}
+
+	router.PATCH("/videos/:id/transcripts/:transcriptId/segments", handler.EditSegments)
+	router.GET("/videos/:id/transcripts/:transcriptId/revisions", handler.ListRevisions)
+	router.GET("/videos/:id/transcripts/:transcriptId/revisions/:number", handler.GetRevision)
+}
+
+type RevisionHandler struct {
+	revisionService RevisionService
+	logger          *zap.Logger
+}
+
+// EditSegments corrects the text of transcript segments, recording the edit as a revision
+func (h *RevisionHandler) EditSegments(c *gin.Context) {
+	videoID, transcriptID, ok := parseTranscriptPath(c)
+	if !ok {
+		return
+	}
+
+	var req revision.Edit
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid request body",
+		))
+		return
+	}
+
+	transcript, rev, err := h.revisionService.EditSegments(c.Request.Context(), videoID, transcriptID, req)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"transcript": transcript, "revision": rev})
+}
+
+// ListRevisions returns the edit history of a transcript, newest first
+func (h *RevisionHandler) ListRevisions(c *gin.Context) {
+	videoID, transcriptID, ok := parseTranscriptPath(c)
+	if !ok {
+		return
+	}
+
+	revisions, err := h.revisionService.ListRevisions(c.Request.Context(), videoID, transcriptID)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"revisions": revisions})
+}
+
+// GetRevision returns one revision of a transcript with the word diff of each edited segment
+func (h *RevisionHandler) GetRevision(c *gin.Context) {
+	videoID, transcriptID, ok := parseTranscriptPath(c)
+	if !ok {
+		return
+	}
+	number, err := strconv.Atoi(c.Param("number"))
+	if err != nil || number < 1 {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid revision number",
+		))
+		return
+	}
+
+	rev, err := h.revisionService.GetRevision(c.Request.Context(), videoID, transcriptID, number)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rev)
+}
+
+// parseTranscriptPath reads the video and transcript IDs of a transcript route, aborting
+// the request when either is malformed
+func parseTranscriptPath(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	videoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid video ID format",
+		))
+		return uuid.Nil, uuid.Nil, false
+	}
+	transcriptID, err := uuid.Parse(c.Param("transcriptId"))
+	if err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid transcript ID format",
+		))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return videoID, transcriptID, true
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/internal/services/revision"
+	"youtube-video-summarizer/backend/pkg/errors"
+)
+
+type MockRevisionService struct {
+	mock.Mock
+}
+
+func (m *MockRevisionService) EditSegments(ctx context.Context, videoID, transcriptID uuid.UUID, edit revision.Edit) (*models.Transcript, *models.TranscriptRevision, error) {
+	args := m.Called(ctx, videoID, transcriptID, edit)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*models.Transcript), args.Get(1).(*models.TranscriptRevision), args.Error(2)
+}
+
+func (m *MockRevisionService) ListRevisions(ctx context.Context, videoID, transcriptID uuid.UUID) ([]*models.TranscriptRevision, error) {
+	args := m.Called(ctx, videoID, transcriptID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.TranscriptRevision), args.Error(1)
+}
+
+func (m *MockRevisionService) GetRevision(ctx context.Context, videoID, transcriptID uuid.UUID, number int) (*models.TranscriptRevision, error) {
+	args := m.Called(ctx, videoID, transcriptID, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TranscriptRevision), args.Error(1)
+}
+
+func TestRevisionHandler_EditSegments(t *testing.T) {
+	mockService := new(MockRevisionService)
+	router := setupRouter()
+	api := router.Group("/api/v1")
+	// The revision routes share their prefix with the video routes
+	RegisterVideoRoutes(api, nil, nil, nil, nil, nil, nil, zap.NewNop())
+	RegisterRevisionRoutes(api, mockService, zap.NewNop())
+
+	videoID, transcriptID := uuid.New(), uuid.New()
+	transcript := &models.Transcript{ID: transcriptID, VideoID: videoID, Content: "Today we deploy to Kubernetes."}
+	rev := &models.TranscriptRevision{ID: uuid.New(), TranscriptID: transcriptID, Number: 1, Author: "Dana"}
+	mockService.On("EditSegments", mock.Anything, videoID, transcriptID, mock.MatchedBy(func(edit revision.Edit) bool {
+		return edit.Author == "Dana" && len(edit.Segments) == 1 && edit.Segments[0].Position == 1 &&
+			edit.BaseRevision != nil && *edit.BaseRevision == 0
+	})).Return(transcript, rev, nil)
+
+	body := `{"author": "Dana", "base_revision": 0, "segments": [{"position": 1, "text": "Today we deploy to Kubernetes."}]}`
+	req := httptest.NewRequest("PATCH", "/api/v1/videos/"+videoID.String()+"/transcripts/"+transcriptID.String()+"/segments", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Transcript models.Transcript         `json:"transcript"`
+		Revision   models.TranscriptRevision `json:"revision"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, transcript.Content, resp.Transcript.Content)
+	assert.Equal(t, 1, resp.Revision.Number)
+	mockService.AssertExpectations(t)
+}
+
+func TestRevisionHandler_GetRevision(t *testing.T) {
+	mockService := new(MockRevisionService)
+	router := setupRouter()
+	router.Use(errors.ErrorHandlerMiddleware(zap.NewNop()))
+	RegisterRevisionRoutes(router.Group(""), mockService, zap.NewNop())
+
+	videoID, transcriptID := uuid.New(), uuid.New()
+	rev := &models.TranscriptRevision{
+		Number: 2,
+		Author: "Dana",
+		Changes: models.SegmentChanges{{
+			Position: 4,
+			Before:   "cooper netties",
+			After:    "Kubernetes",
+			Diff:     []models.DiffPart{{Op: models.DiffDelete, Text: "cooper netties"}, {Op: models.DiffInsert, Text: "Kubernetes"}},
+		}},
+	}
+	mockService.On("GetRevision", mock.Anything, videoID, transcriptID, 2).Return(rev, nil)
+	mockService.On("GetRevision", mock.Anything, videoID, transcriptID, 3).
+		Return(nil, errors.ErrTranscriptRevisionNotFound(transcriptID.String(), 3))
+
+	path := "/videos/" + videoID.String() + "/transcripts/" + transcriptID.String() + "/revisions/"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", path+"2", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp models.TranscriptRevision
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, rev.Changes, resp.Changes)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", path+"3", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", path+"latest", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
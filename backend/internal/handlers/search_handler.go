@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/pkg/errors"
+)
+
+func RegisterSearchRoutes(router *gin.RouterGroup, searchService SearchService, logger *zap.Logger) {
+	handler := &SearchHandler{
+		searchService: searchService,
+		logger:        logger,
+	}
+
+	router.GET("/search/transcripts", handler.SearchTranscripts)
+}
+
+type SearchHandler struct {
+	searchService SearchService
+	logger        *zap.Logger
+}
+
+// SearchTranscripts finds videos whose transcripts contain q, optionally filtered by
+// channel_id, language and publication date (published_after inclusive, published_before
+// exclusive; dates or RFC 3339 times)
+func (h *SearchHandler) SearchTranscripts(c *gin.Context) {
+	query := models.TranscriptSearchQuery{
+		Query:     c.Query("q"),
+		ChannelID: c.Query("channel_id"),
+		Language:  c.Query("language"),
+	}
+	query.Limit, _ = strconv.Atoi(c.DefaultQuery("limit", "20"))
+	query.Offset, _ = strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if query.Limit <= 0 || query.Limit > 100 {
+		query.Limit = 100
+	}
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+
+	var err error
+	if query.PublishedAfter, err = parseOptionalTime(c.Query("published_after")); err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidFormat,
+			"published_after must be a date (YYYY-MM-DD) or an RFC 3339 time",
+		))
+		return
+	}
+	if query.PublishedBefore, err = parseOptionalTime(c.Query("published_before")); err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidFormat,
+			"published_before must be a date (YYYY-MM-DD) or an RFC 3339 time",
+		))
+		return
+	}
+
+	results, total, err := h.searchService.SearchTranscripts(c.Request.Context(), query)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results": results,
+		"total":   total,
+		"limit":   query.Limit,
+		"offset":  query.Offset,
+	})
+}
+
+// parseOptionalTime parses an optional date or RFC 3339 time query parameter, nil when absent
+func parseOptionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		t, err = time.Parse(time.RFC3339, value)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+type MockSearchService struct {
+	mock.Mock
+}
+
+func (m *MockSearchService) SearchTranscripts(ctx context.Context, query models.TranscriptSearchQuery) ([]*models.TranscriptSearchResult, int, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*models.TranscriptSearchResult), args.Int(1), args.Error(2)
+}
+
+func TestSearchHandler_SearchTranscripts(t *testing.T) {
+	mockService := new(MockSearchService)
+	handler := &SearchHandler{searchService: mockService, logger: zap.NewNop()}
+
+	videoID := uuid.New()
+	results := []*models.TranscriptSearchResult{{
+		Video:    &models.Video{ID: videoID, Title: "Designing APIs"},
+		HitCount: 4,
+		Hits:     []models.TranscriptSearchHit{{Start: 312.4, End: 318, Snippet: "then the <mark>rate</mark> <mark>limiter</mark> kicks in"}},
+	}}
+	after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockService.On("SearchTranscripts", mock.Anything, mock.MatchedBy(func(q models.TranscriptSearchQuery) bool {
+		return q.Query == "rate limiter" && q.ChannelID == "UC123" && q.Language == "en" &&
+			q.PublishedAfter != nil && q.PublishedAfter.Equal(after) && q.PublishedBefore == nil &&
+			q.Limit == 10 && q.Offset == 0
+	})).Return(results, 1, nil)
+
+	router := setupRouter()
+	router.GET("/search/transcripts", handler.SearchTranscripts)
+
+	req := httptest.NewRequest("GET", "/search/transcripts?q=rate+limiter&channel_id=UC123&language=en&published_after=2024-01-01&limit=10", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Results []models.TranscriptSearchResult `json:"results"`
+		Total   int                             `json:"total"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, 1, body.Total)
+	require.Len(t, body.Results, 1)
+	assert.Equal(t, videoID, body.Results[0].Video.ID)
+	assert.Equal(t, 312.4, body.Results[0].Hits[0].Start)
+
+	req = httptest.NewRequest("GET", "/search/transcripts?q=rate&published_before=last+week", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/pkg/errors"
+)
+
+func RegisterSourceRoutes(router *gin.RouterGroup, sources SourceRegistry, podcastFeeds PodcastFeedService, logger *zap.Logger) {
+	handler := &SourceHandler{
+		sources:      sources,
+		podcastFeeds: podcastFeeds,
+		logger:       logger,
+	}
+
+	sourcesGroup := router.Group("/sources")
+	{
+		sourcesGroup.GET("", handler.ListSources)
+		sourcesGroup.GET("/podcast/episodes", handler.ListPodcastEpisodes)
+	}
+}
+
+type SourceHandler struct {
+	sources      SourceRegistry
+	podcastFeeds PodcastFeedService
+	logger       *zap.Logger
+}
+
+func (h *SourceHandler) ListSources(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"sources": h.sources.Types(),
+	})
+}
+
+// ListPodcastEpisodes reads a podcast feed so an episode can be picked and added with
+// POST /videos {"source": "podcast", "url": <feed_url>, "episode_id": <guid>}
+func (h *SourceHandler) ListPodcastEpisodes(c *gin.Context) {
+	feedURL := strings.TrimSpace(c.Query("feed_url"))
+	if feedURL == "" {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeMissingParameter,
+			"feed_url is required",
+		))
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+
+	feed, err := h.podcastFeeds.GetFeed(c.Request.Context(), feedURL)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	episodes := make([]gin.H, 0, limit)
+	for i, ep := range feed.Episodes {
+		if i >= limit {
+			break
+		}
+		episodes = append(episodes, gin.H{
+			"episode_id":     ep.GUID,
+			"title":          ep.Title,
+			"description":    ep.Description,
+			"published_at":   ep.PublishedAt,
+			"duration":       ep.Duration,
+			"audio_url":      ep.AudioURL,
+			"thumbnail_url":  ep.ImageURL,
+			"has_transcript": len(ep.Transcripts) > 0,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"feed_url":  feed.URL,
+		"title":     feed.Title,
+		"author":    feed.Author,
+		"image_url": feed.ImageURL,
+		"episodes":  episodes,
+		"total":     len(feed.Episodes),
+	})
+}
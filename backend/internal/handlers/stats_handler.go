@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/pkg/errors"
+)
+
+func RegisterStatsRoutes(router *gin.RouterGroup, statsService StatsService, logger *zap.Logger) {
+	handler := &StatsHandler{
+		statsService: statsService,
+		logger:       logger,
+	}
+
+	router.GET("/videos/:id/stats", handler.GetStats)
+	router.POST("/videos/:id/stats/refresh", handler.RefreshStats)
+}
+
+type StatsHandler struct {
+	statsService StatsService
+	logger       *zap.Logger
+}
+
+// GetStats returns the view/like count history (last "days" days, default 30, max 365)
+// together with recorded title, description and availability changes
+func (h *StatsHandler) GetStats(c *gin.Context) {
+	videoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid video ID format",
+		))
+		return
+	}
+
+	days, _ := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if days <= 0 || days > 365 {
+		days = 365
+	}
+	since := time.Now().AddDate(0, 0, -days)
+
+	stats, err := h.statsService.GetStats(c.Request.Context(), videoID, since)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// RefreshStats re-fetches a YouTube video's metadata now instead of waiting for the refresh job
+func (h *StatsHandler) RefreshStats(c *gin.Context) {
+	videoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid video ID format",
+		))
+		return
+	}
+
+	video, err := h.statsService.Refresh(c.Request.Context(), videoID)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, video)
+}
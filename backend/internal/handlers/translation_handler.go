@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/pkg/errors"
+)
+
+func RegisterTranslationRoutes(router *gin.RouterGroup, translationService TranslationService, logger *zap.Logger) {
+	handler := &TranslationHandler{
+		translationService: translationService,
+		logger:             logger,
+	}
+
+	router.POST("/videos/:id/transcript/translate", handler.TranslateTranscript)
+}
+
+type TranslationHandler struct {
+	translationService TranslationService
+	logger             *zap.Logger
+}
+
+// TranslateTranscript translates the primary transcript of a video, or transcript_id, into
+// language and stores it as a transcript of its own
+func (h *TranslationHandler) TranslateTranscript(c *gin.Context) {
+	videoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid video ID format",
+		))
+		return
+	}
+
+	var req struct {
+		Language     string    `json:"language"`
+		TranscriptID uuid.UUID `json:"transcript_id"` // primary transcript when omitted
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid request body",
+		))
+		return
+	}
+
+	// Long transcripts take one LLM request per batch of segments
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Minute)
+	defer cancel()
+
+	transcript, err := h.translationService.TranslateTranscript(ctx, videoID, req.TranscriptID, req.Language)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transcript)
+}
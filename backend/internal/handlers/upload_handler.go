@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/pkg/errors"
+)
+
+// multipartOverhead is the room left for multipart headers and form fields on top of the file itself
+const multipartOverhead = 1 << 20
+
+func RegisterUploadRoutes(router *gin.RouterGroup, uploadService UploadService, logger *zap.Logger) {
+	handler := &UploadHandler{
+		uploadService: uploadService,
+		logger:        logger,
+	}
+
+	router.POST("/videos/upload", handler.UploadVideo)
+	router.GET("/videos/:id/media", handler.GetMedia)
+
+	uploads := router.Group("/uploads")
+	{
+		uploads.POST("", handler.StartUpload)
+		uploads.GET("/:id", handler.GetUpload)
+		uploads.PATCH("/:id", handler.WriteChunk)
+	}
+}
+
+type UploadHandler struct {
+	uploadService UploadService
+	logger        *zap.Logger
+}
+
+// UploadVideo accepts a whole audio/video file as multipart "file" (plus optional "title")
+func (h *UploadHandler) UploadVideo(c *gin.Context) {
+	// Refuse oversized bodies while reading them, before the multipart form is spooled to disk
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.uploadService.MaxSize()+multipartOverhead)
+
+	fileHeader, err := c.FormFile("file")
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		errors.AbortWithError(c, errors.NewWithDetail(
+			errors.ErrorCodeTooLarge,
+			errors.SubCodeInvalidInput,
+			"Upload too large",
+			fmt.Sprintf("Request body exceeds maximum allowed size of %d bytes", h.uploadService.MaxSize()),
+		))
+		return
+	}
+	if err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeMissingParameter,
+			"file is required",
+		))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		errors.AbortWithError(c, errors.NewWithError(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Failed to read uploaded file",
+			err,
+		))
+		return
+	}
+	defer file.Close()
+
+	video, err := h.uploadService.CreateFromFile(c.Request.Context(), fileHeader.Filename, c.PostForm("title"), file)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, video)
+}
+
+// StartUpload opens a resumable upload session; chunks are then sent with PATCH /uploads/:id
+func (h *UploadHandler) StartUpload(c *gin.Context) {
+	var req struct {
+		Filename string `json:"filename" binding:"required"`
+		Size     int64  `json:"size" binding:"required"`
+		Title    string `json:"title"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"filename and size are required",
+		))
+		return
+	}
+
+	upload, err := h.uploadService.StartUpload(c.Request.Context(), req.Filename, req.Title, req.Size)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.Header("Location", "/api/v1/uploads/"+upload.ID.String())
+	c.Header("Upload-Offset", "0")
+	c.JSON(http.StatusCreated, upload)
+}
+
+// WriteChunk appends the raw request body at the offset given in the Upload-Offset header. On a
+// mismatch (409) the client should GET the upload and resume from its current offset.
+func (h *UploadHandler) WriteChunk(c *gin.Context) {
+	id, ok := h.parseUploadID(c)
+	if !ok {
+		return
+	}
+
+	offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Upload-Offset header must be a non-negative integer",
+		))
+		return
+	}
+
+	upload, err := h.uploadService.WriteChunk(c.Request.Context(), id, offset, c.Request.Body)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.Header("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	c.JSON(http.StatusOK, upload)
+}
+
+func (h *UploadHandler) GetUpload(c *gin.Context) {
+	id, ok := h.parseUploadID(c)
+	if !ok {
+		return
+	}
+
+	upload, err := h.uploadService.GetUpload(c.Request.Context(), id)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.Header("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	c.JSON(http.StatusOK, upload)
+}
+
+// GetMedia serves the stored file of an uploaded video for playback
+func (h *UploadHandler) GetMedia(c *gin.Context) {
+	videoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid video ID format",
+		))
+		return
+	}
+
+	path, err := h.uploadService.GetMediaPath(c.Request.Context(), videoID)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.File(path)
+}
+
+func (h *UploadHandler) parseUploadID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid upload ID format",
+		))
+		return uuid.Nil, false
+	}
+	return id, true
+}
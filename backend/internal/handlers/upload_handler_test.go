@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+type MockUploadService struct {
+	mock.Mock
+}
+
+func (m *MockUploadService) CreateFromFile(ctx context.Context, filename, title string, r io.Reader) (*models.Video, error) {
+	args := m.Called(ctx, filename, title, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Video), args.Error(1)
+}
+
+func (m *MockUploadService) StartUpload(ctx context.Context, filename, title string, size int64) (*models.Upload, error) {
+	args := m.Called(ctx, filename, title, size)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Upload), args.Error(1)
+}
+
+func (m *MockUploadService) WriteChunk(ctx context.Context, id uuid.UUID, offset int64, r io.Reader) (*models.Upload, error) {
+	args := m.Called(ctx, id, offset, r)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Upload), args.Error(1)
+}
+
+func (m *MockUploadService) GetUpload(ctx context.Context, id uuid.UUID) (*models.Upload, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Upload), args.Error(1)
+}
+
+func (m *MockUploadService) GetMediaPath(ctx context.Context, videoID uuid.UUID) (string, error) {
+	args := m.Called(ctx, videoID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockUploadService) MaxSize() int64 {
+	return int64(m.Called().Int(0))
+}
+
+func TestUploadHandler_UploadVideo_TooLarge(t *testing.T) {
+	mockService := new(MockUploadService)
+	router := setupRouter()
+	RegisterUploadRoutes(router.Group(""), mockService, zap.NewNop())
+
+	mockService.On("MaxSize").Return(1024)
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", "talk.mp3")
+	require.NoError(t, err)
+	_, err = part.Write(make([]byte, 1024+multipartOverhead))
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+
+	req := httptest.NewRequest("POST", "/videos/upload", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	mockService.AssertNotCalled(t, "CreateFromFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
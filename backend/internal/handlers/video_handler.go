@@ -3,7 +3,6 @@ package handlers
 import (
 	"context"
 	"net/http"
-	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -13,6 +12,8 @@ import (
 	"go.uber.org/zap"
 	"youtube-video-summarizer/backend/internal/models"
 	kafkaservice "youtube-video-summarizer/backend/internal/services/kafka"
+	"youtube-video-summarizer/backend/internal/services/source"
+	"youtube-video-summarizer/backend/internal/services/transcript"
 	"youtube-video-summarizer/backend/pkg/errors"
 )
 
@@ -45,6 +46,11 @@ func RegisterVideoRoutes(
 		videos.POST("/:id/analyze", handler.AnalyzeVideo)
 		videos.GET("/:id/transcript", handler.GetTranscript)
 	videos.GET("/:id/transcript/languages", handler.GetAvailableLanguages)
+		videos.GET("/:id/transcript/export", handler.ExportTranscript)
+		videos.GET("/:id/transcripts", handler.ListTranscripts)
+		videos.PUT("/:id/transcripts/:transcriptId/primary", handler.SetPrimaryTranscript)
+		videos.PUT("/:id/transcripts/:transcriptId/speakers", handler.NameSpeakers)
+		videos.GET("/:id/chapters", handler.GetChapters)
 		videos.GET("/:id/summary", handler.GetSummary)
 		videos.POST("/:id/summarize", handler.SummarizeVideo)
 		videos.POST("/:id/summary/translate", handler.TranslateSummary)
@@ -66,6 +72,8 @@ func (h *VideoHandler) CreateVideo(c *gin.Context) {
 	var req struct {
 		URL      string `json:"url"`
 		YouTubeID string `json:"youtube_id"`
+		Source    string `json:"source"`     // youtube (default) or podcast
+		EpisodeID string `json:"episode_id"` // podcast episode GUID; latest episode when empty
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -91,12 +99,28 @@ func (h *VideoHandler) CreateVideo(c *gin.Context) {
 		return
 	}
 
-	video, err := h.videoService.CreateFromURL(c.Request.Context(), videoURL)
+	var video *models.Video
+	var err error
+	if req.Source == "" || req.Source == models.SourceTypeYouTube {
+		video, err = h.videoService.CreateFromURL(c.Request.Context(), videoURL)
+	} else {
+		video, err = h.videoService.CreateFromSource(c.Request.Context(), req.Source, source.Reference{
+			URL:       videoURL,
+			EpisodeID: req.EpisodeID,
+		})
+	}
 	if err != nil {
 		errors.HandleError(c, err)
 		return
 	}
 
+	// Premieres and live streams are analyzed by the broadcast scheduler once they end
+	if video.Status == models.VideoStatusScheduled {
+		h.logger.Info("Video is a scheduled broadcast, deferring analysis", zap.String("video_id", video.ID.String()))
+		c.JSON(http.StatusOK, video)
+		return
+	}
+
 	// Publish video.created event to Kafka
 	if h.videoEventService != nil {
 		if err := h.videoEventService.PublishVideoCreated(c.Request.Context(), video); err != nil {
@@ -205,12 +229,6 @@ func (h *VideoHandler) AnalyzeVideo(c *gin.Context) {
 		return
 	}
 
-	// Update status to processing
-	if err := h.videoService.UpdateStatus(c.Request.Context(), id, "processing"); err != nil {
-		errors.HandleError(c, err)
-		return
-	}
-
 	// Get video to get YouTube ID
 	video, err := h.videoService.GetByID(c.Request.Context(), id)
 	if err != nil {
@@ -221,6 +239,16 @@ func (h *VideoHandler) AnalyzeVideo(c *gin.Context) {
 		}
 		return
 	}
+	if video.Status == models.VideoStatusScheduled {
+		errors.AbortWithError(c, errors.ErrVideoScheduled(id.String()))
+		return
+	}
+
+	// Update status to processing
+	if err := h.videoService.UpdateStatus(c.Request.Context(), id, "processing"); err != nil {
+		errors.HandleError(c, err)
+		return
+	}
 
 	// Publish transcript request event
 	if h.videoEventService != nil {
@@ -271,7 +299,7 @@ func (h *VideoHandler) performAnalysisDirect(ctx context.Context, videoID uuid.U
 	}
 
 	// 3. Generate embeddings
-	_, err = h.embeddingService.GenerateVideoEmbeddings(ctx, video, transcript.Content)
+	_, err = h.embeddingService.GenerateVideoEmbeddings(ctx, video, transcript)
 	if err != nil {
 		h.logger.Error("Failed to generate embeddings", zap.String("video_id", videoID.String()), zap.Error(err))
 		h.videoService.UpdateStatus(ctx, videoID, "error")
@@ -313,13 +341,15 @@ func (h *VideoHandler) GetTranscript(c *gin.Context) {
 
 	// Get language parameter from query string
 	languageCode := c.Query("language")
+	// Word timings make up most of the payload; clients not highlighting words can skip them
+	includeWords := c.Query("words") != "false"
 
 	// First try to get existing transcript
 	transcript, err := h.transcriptService.GetByVideoID(c.Request.Context(), id)
 	if err == nil && transcript != nil {
 		// If language is specified and matches existing, return it
 		if languageCode == "" || transcript.Language == languageCode {
-			c.JSON(http.StatusOK, transcript)
+			c.JSON(http.StatusOK, transcriptResponse(transcript, includeWords))
 			return
 		}
 		// Language doesn't match, will create new one below
@@ -344,7 +374,110 @@ func (h *VideoHandler) GetTranscript(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, transcript)
+	c.JSON(http.StatusOK, transcriptResponse(transcript, includeWords))
+}
+
+// transcriptResponse returns the transcript as sent to clients, without word timings unless
+// includeWords
+func transcriptResponse(t *models.Transcript, includeWords bool) *models.Transcript {
+	if includeWords {
+		return t
+	}
+	stripped := *t
+	stripped.Segments = make(models.TranscriptSegments, len(t.Segments))
+	for i, seg := range t.Segments {
+		seg.Words = nil
+		stripped.Segments[i] = seg
+	}
+	return &stripped
+}
+
+// ExportTranscript renders a stored transcript as SRT, WebVTT, plain text or JSON for download.
+// transcript_id picks a transcript, such as a translation, over the language lookup. Nothing is
+// fetched or transcribed here: a transcript that does not exist yet is not found.
+func (h *VideoHandler) ExportTranscript(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid video ID format",
+		))
+		return
+	}
+
+	format, ok := transcript.ParseExportFormat(c.DefaultQuery("format", string(transcript.FormatSRT)))
+	if !ok {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidFormat,
+			"Export format must be one of srt, vtt, txt, json",
+		))
+		return
+	}
+	opts := transcript.ExportOptions{Format: format}
+	if opts.LineLength, err = parseOptionalInt(c.Query("line_length")); err != nil || opts.LineLength < 0 {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"line_length must be a non-negative number of characters",
+		))
+		return
+	}
+	if opts.MergeShorterThan, err = transcript.ParseSeconds(c.Query("merge_under")); err != nil {
+		errors.AbortWithError(c, errors.New(errors.ErrorCodeBadRequest, errors.SubCodeInvalidInput, err.Error()))
+		return
+	}
+	if opts.From, err = transcript.ParseSeconds(c.Query("from")); err != nil {
+		errors.AbortWithError(c, errors.New(errors.ErrorCodeBadRequest, errors.SubCodeInvalidInput, err.Error()))
+		return
+	}
+	if opts.To, err = transcript.ParseSeconds(c.Query("to")); err != nil {
+		errors.AbortWithError(c, errors.New(errors.ErrorCodeBadRequest, errors.SubCodeInvalidInput, err.Error()))
+		return
+	}
+
+	ctx := c.Request.Context()
+	var t *models.Transcript
+	if transcriptID := c.Query("transcript_id"); transcriptID != "" {
+		tid, parseErr := uuid.Parse(transcriptID)
+		if parseErr != nil {
+			errors.AbortWithError(c, errors.New(
+				errors.ErrorCodeBadRequest,
+				errors.SubCodeInvalidInput,
+				"Invalid transcript ID format",
+			))
+			return
+		}
+		t, err = h.transcriptService.Get(ctx, id, tid)
+	} else {
+		t, err = h.transcriptService.GetStored(ctx, id, c.Query("language"))
+	}
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	data, err := transcript.Export(t, opts)
+	if err != nil {
+		errors.AbortWithError(c, errors.New(errors.ErrorCodeBadRequest, errors.SubCodeInvalidInput, err.Error()))
+		return
+	}
+
+	filename := id.String()
+	if t.Language != "" {
+		filename += "." + t.Language
+	}
+	c.Header("Content-Disposition", `attachment; filename="`+filename+"."+string(format)+`"`)
+	c.Data(http.StatusOK, format.ContentType(), data)
+}
+
+// parseOptionalInt parses an optional integer query parameter, 0 when absent
+func parseOptionalInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
 }
 
 func (h *VideoHandler) GetAvailableLanguages(c *gin.Context) {
@@ -442,7 +575,7 @@ func (h *VideoHandler) GetSummary(c *gin.Context) {
 		summary, err = h.summaryService.GenerateSummary(
 			ctx,
 			id,
-			transcript.Content,
+			transcript,
 			"short", // default summary type
 			language,
 		)
@@ -456,7 +589,7 @@ func (h *VideoHandler) GetSummary(c *gin.Context) {
 
 	// No transcript available, try audio analysis
 	// Download audio and generate summary from audio
-	audioPath, err := h.transcriptService.DownloadAudio(video.YouTubeID)
+	audioPath, cleanupAudio, err := h.transcriptService.GetAudio(c.Request.Context(), video)
 	if err != nil {
 		// If audio download fails, try to create transcript anyway
 		transcript, err := h.transcriptService.GetOrCreateTranscript(ctx, id)
@@ -467,7 +600,7 @@ func (h *VideoHandler) GetSummary(c *gin.Context) {
 		summary, err = h.summaryService.GenerateSummary(
 			ctx,
 			id,
-			transcript.Content,
+			transcript,
 			"short",
 			language,
 		)
@@ -480,11 +613,7 @@ func (h *VideoHandler) GetSummary(c *gin.Context) {
 	}
 
 	// Clean up audio file after use
-	defer func() {
-		if err := os.Remove(audioPath); err != nil {
-			h.logger.Warn("Failed to cleanup audio file", zap.String("path", audioPath), zap.Error(err))
-		}
-	}()
+	defer cleanupAudio()
 
 	// Generate summary from audio using audio analysis provider
 	summary, err = h.summaryService.GenerateSummaryFromAudio(
@@ -514,7 +643,7 @@ func (h *VideoHandler) SummarizeVideo(c *gin.Context) {
 	}
 
 	var req struct {
-		Type      string `json:"type"`       // short, detailed, bullet_points
+		Type      string `json:"type"`       // short, detailed, bullet_points, chapters
 		FromAudio bool   `json:"from_audio"` // if true, generate summary from audio instead of transcript
 		Language  string `json:"language"`   // language code for summary (e.g., "en", "tr", "auto")
 	}
@@ -539,20 +668,45 @@ func (h *VideoHandler) SummarizeVideo(c *gin.Context) {
 
 	var summary *models.Summary
 
-	if req.FromAudio {
+	if req.Type == models.SummaryTypeChapters {
+		// Chapters are summarized from the timed transcript segments, never from raw audio
+		chapters, err := h.videoService.GetChapters(ctx, id)
+		if err != nil {
+			errors.HandleError(c, err)
+			return
+		}
+		if len(chapters) == 0 {
+			errors.AbortWithError(c, errors.ErrSummaryNoChapters(id.String()))
+			return
+		}
+
+		transcript, err := h.transcriptService.GetOrCreateTranscript(ctx, id)
+		if err != nil {
+			errors.HandleError(c, err)
+			return
+		}
+
+		summary, err = h.summaryService.GenerateChapteredSummary(
+			ctx,
+			id,
+			transcript,
+			chapters,
+			req.Language,
+		)
+		if err != nil {
+			errors.HandleError(c, err)
+			return
+		}
+	} else if req.FromAudio {
 		// Generate summary from audio using settings' whisper provider
-		audioPath, err := h.transcriptService.DownloadAudio(video.YouTubeID)
+		audioPath, cleanupAudio, err := h.transcriptService.GetAudio(c.Request.Context(), video)
 		if err != nil {
 			errors.HandleError(c, errors.Wrap(err, errors.ErrorCodeYouTubeDownload, errors.SubCodeYouTubeDownloadFailed, "Failed to download audio"))
 			return
 		}
 
 		// Clean up audio file after use
-		defer func() {
-			if err := os.Remove(audioPath); err != nil {
-				h.logger.Warn("Failed to cleanup audio file", zap.String("path", audioPath), zap.Error(err))
-			}
-		}()
+		defer cleanupAudio()
 
 		// Generate summary from audio using audio analysis provider
 		summary, err = h.summaryService.GenerateSummaryFromAudio(
@@ -579,7 +733,7 @@ func (h *VideoHandler) SummarizeVideo(c *gin.Context) {
 		summary, err = h.summaryService.GenerateSummary(
 			ctx,
 			id,
-			transcript.Content,
+			transcript,
 			req.Type,
 			req.Language,
 		)
@@ -595,6 +749,119 @@ func (h *VideoHandler) SummarizeVideo(c *gin.Context) {
 	c.JSON(http.StatusOK, summary)
 }
 
+// ListTranscripts returns every stored transcript of a video, the primary one first
+func (h *VideoHandler) ListTranscripts(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid video ID format",
+		))
+		return
+	}
+
+	transcripts, err := h.transcriptService.ListByVideoID(c.Request.Context(), id)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"transcripts": transcripts})
+}
+
+// SetPrimaryTranscript chooses the transcript later summaries and embeddings are built from
+func (h *VideoHandler) SetPrimaryTranscript(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid video ID format",
+		))
+		return
+	}
+	transcriptID, err := uuid.Parse(c.Param("transcriptId"))
+	if err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid transcript ID format",
+		))
+		return
+	}
+
+	transcript, err := h.transcriptService.SetPrimary(c.Request.Context(), id, transcriptID)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transcript)
+}
+
+// NameSpeakers names the speakers of a diarized transcript
+func (h *VideoHandler) NameSpeakers(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid video ID format",
+		))
+		return
+	}
+	transcriptID, err := uuid.Parse(c.Param("transcriptId"))
+	if err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid transcript ID format",
+		))
+		return
+	}
+
+	var req struct {
+		Speakers map[string]string `json:"speakers" binding:"required"` // display names by label, e.g. SPEAKER_00
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid request body",
+		))
+		return
+	}
+
+	transcript, err := h.transcriptService.NameSpeakers(c.Request.Context(), id, transcriptID, req.Speakers)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transcript)
+}
+
+func (h *VideoHandler) GetChapters(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errors.AbortWithError(c, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Invalid video ID format",
+		))
+		return
+	}
+
+	chapters, err := h.videoService.GetChapters(c.Request.Context(), id)
+	if err != nil {
+		errors.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"chapters": chapters})
+}
+
 func (h *VideoHandler) TranslateSummary(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
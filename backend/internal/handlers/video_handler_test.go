@@ -13,9 +13,12 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"youtube-video-summarizer/backend/internal/models"
 	"youtube-video-summarizer/backend/internal/services/embedding"
+	"youtube-video-summarizer/backend/internal/services/source"
+	"youtube-video-summarizer/backend/pkg/errors"
 )
 
 // MockVideoService implements VideoService interface
@@ -31,6 +34,14 @@ func (m *MockVideoService) CreateFromURL(ctx context.Context, url string) (*mode
 	return args.Get(0).(*models.Video), args.Error(1)
 }
 
+func (m *MockVideoService) CreateFromSource(ctx context.Context, sourceType string, ref source.Reference) (*models.Video, error) {
+	args := m.Called(ctx, sourceType, ref)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Video), args.Error(1)
+}
+
 func (m *MockVideoService) GetByID(ctx context.Context, id uuid.UUID) (*models.Video, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -57,6 +68,14 @@ func (m *MockVideoService) Delete(ctx context.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
+func (m *MockVideoService) GetChapters(ctx context.Context, id uuid.UUID) ([]*models.Chapter, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Chapter), args.Error(1)
+}
+
 func setupVideoRouter() *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	return gin.New()
@@ -192,6 +211,209 @@ func TestVideoHandler_GetTranscript(t *testing.T) {
 	mockTranscriptService.AssertExpectations(t)
 }
 
+func TestVideoHandler_GetTranscript_WithoutWords(t *testing.T) {
+	mockTranscriptService := new(MockTranscriptService)
+	handler := &VideoHandler{transcriptService: mockTranscriptService, logger: zap.NewNop()}
+
+	videoID := uuid.New()
+	transcript := &models.Transcript{
+		ID:       uuid.New(),
+		VideoID:  videoID,
+		Language: "en",
+		Source:   "whisper",
+		Segments: models.TranscriptSegments{{
+			Start: 0, End: 1.2, Text: "Hello world",
+			Words: []models.TranscriptWord{{Start: 0, End: 0.5, Text: "Hello"}, {Start: 0.6, End: 1.2, Text: "world"}},
+		}},
+	}
+	mockTranscriptService.On("GetByVideoID", mock.Anything, videoID).Return(transcript, nil)
+
+	router := setupVideoRouter()
+	router.GET("/videos/:id/transcript", handler.GetTranscript)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/videos/"+videoID.String()+"/transcript", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"words":[{"start":0,"end":0.5,"text":"Hello"}`)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/videos/"+videoID.String()+"/transcript?words=false", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), `"words"`)
+	assert.Contains(t, w.Body.String(), "Hello world")
+	assert.Len(t, transcript.Segments[0].Words, 2, "the stored transcript is left untouched")
+}
+
+func TestVideoHandler_ListTranscripts(t *testing.T) {
+	mockTranscriptService := new(MockTranscriptService)
+	handler := &VideoHandler{transcriptService: mockTranscriptService, logger: zap.NewNop()}
+
+	videoID := uuid.New()
+	transcripts := []*models.Transcript{
+		{ID: uuid.New(), VideoID: videoID, Language: "en", Source: "youtube", IsPrimary: true},
+		{ID: uuid.New(), VideoID: videoID, Language: "es", Source: "youtube"},
+	}
+	mockTranscriptService.On("ListByVideoID", mock.Anything, videoID).Return(transcripts, nil)
+
+	router := setupVideoRouter()
+	router.GET("/videos/:id/transcripts", handler.ListTranscripts)
+
+	req := httptest.NewRequest("GET", "/videos/"+videoID.String()+"/transcripts", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Transcripts []models.Transcript `json:"transcripts"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body.Transcripts, 2)
+	assert.True(t, body.Transcripts[0].IsPrimary)
+	assert.Equal(t, "es", body.Transcripts[1].Language)
+}
+
+func TestVideoHandler_SetPrimaryTranscript(t *testing.T) {
+	mockTranscriptService := new(MockTranscriptService)
+	handler := &VideoHandler{transcriptService: mockTranscriptService, logger: zap.NewNop()}
+
+	videoID, transcriptID, missingID := uuid.New(), uuid.New(), uuid.New()
+	mockTranscriptService.On("SetPrimary", mock.Anything, videoID, transcriptID).
+		Return(&models.Transcript{ID: transcriptID, VideoID: videoID, Language: "es", IsPrimary: true}, nil)
+	mockTranscriptService.On("SetPrimary", mock.Anything, videoID, missingID).
+		Return(nil, errors.ErrTranscriptNotFound(videoID.String()))
+
+	router := setupVideoRouter()
+	router.Use(errors.ErrorHandlerMiddleware(zap.NewNop()))
+	router.PUT("/videos/:id/transcripts/:transcriptId/primary", handler.SetPrimaryTranscript)
+
+	req := httptest.NewRequest("PUT", "/videos/"+videoID.String()+"/transcripts/"+transcriptID.String()+"/primary", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"is_primary":true`)
+
+	req = httptest.NewRequest("PUT", "/videos/"+videoID.String()+"/transcripts/"+missingID.String()+"/primary", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	req = httptest.NewRequest("PUT", "/videos/"+videoID.String()+"/transcripts/not-a-uuid/primary", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockTranscriptService.AssertExpectations(t)
+}
+
+func TestVideoHandler_ExportTranscript(t *testing.T) {
+	mockTranscriptService := new(MockTranscriptService)
+	handler := &VideoHandler{transcriptService: mockTranscriptService, logger: zap.NewNop()}
+
+	videoID := uuid.New()
+	transcript := &models.Transcript{
+		ID:       uuid.New(),
+		VideoID:  videoID,
+		Language: "de",
+		Source:   "youtube",
+		Segments: models.TranscriptSegments{
+			{Start: 0, End: 2.5, Text: "Hallo zusammen"},
+			{Start: 2.5, End: 5, Text: "und willkommen"},
+		},
+	}
+	mockTranscriptService.On("GetStored", mock.Anything, videoID, "de").Return(transcript, nil)
+	mockTranscriptService.On("GetStored", mock.Anything, videoID, "fr").Return(nil, errors.ErrTranscriptNotFound(videoID.String()))
+
+	router := setupVideoRouter()
+	router.Use(errors.ErrorHandlerMiddleware(zap.NewNop()))
+	router.GET("/videos/:id/transcript/export", handler.ExportTranscript)
+
+	req := httptest.NewRequest("GET", "/videos/"+videoID.String()+"/transcript/export?format=vtt&language=de&to=2.5", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/vtt; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), videoID.String()+".de.vtt")
+	assert.Equal(t, "WEBVTT\nKind: captions\nLanguage: de\n\n00:00:00.000 --> 00:00:02.500\nHallo zusammen\n", w.Body.String())
+
+	for _, query := range []string{"format=docx", "format=srt&line_length=-1", "format=srt&from=abc"} {
+		req = httptest.NewRequest("GET", "/videos/"+videoID.String()+"/transcript/export?"+query, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+
+	// A language without a stored transcript is not transcribed for the export
+	req = httptest.NewRequest("GET", "/videos/"+videoID.String()+"/transcript/export?language=fr", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockTranscriptService.AssertNotCalled(t, "GetOrCreateTranscript", mock.Anything, mock.Anything, mock.Anything)
+	mockTranscriptService.AssertExpectations(t)
+}
+
+func TestVideoHandler_ExportTranscript_ByID(t *testing.T) {
+	mockTranscriptService := new(MockTranscriptService)
+	handler := &VideoHandler{transcriptService: mockTranscriptService, logger: zap.NewNop()}
+
+	videoID := uuid.New()
+	translation := &models.Transcript{
+		ID:       uuid.New(),
+		VideoID:  videoID,
+		Language: "es",
+		Source:   "translated",
+		Segments: models.TranscriptSegments{{Start: 1.5, End: 4, Text: "Hola a todos"}},
+	}
+	mockTranscriptService.On("Get", mock.Anything, videoID, translation.ID).Return(translation, nil)
+
+	router := setupVideoRouter()
+	router.GET("/videos/:id/transcript/export", handler.ExportTranscript)
+
+	req := httptest.NewRequest("GET", "/videos/"+videoID.String()+"/transcript/export?transcript_id="+translation.ID.String(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Disposition"), videoID.String()+".es.srt")
+	assert.Equal(t, "1\n00:00:01,500 --> 00:00:04,000\nHola a todos\n", w.Body.String())
+
+	req = httptest.NewRequest("GET", "/videos/"+videoID.String()+"/transcript/export?transcript_id=latest", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockTranscriptService.AssertExpectations(t)
+}
+
+func TestVideoHandler_NameSpeakers(t *testing.T) {
+	mockTranscriptService := new(MockTranscriptService)
+	handler := &VideoHandler{transcriptService: mockTranscriptService, logger: zap.NewNop()}
+
+	videoID, transcriptID := uuid.New(), uuid.New()
+	names := map[string]string{"SPEAKER_00": "Alice"}
+	mockTranscriptService.On("NameSpeakers", mock.Anything, videoID, transcriptID, names).
+		Return(&models.Transcript{ID: transcriptID, VideoID: videoID, Speakers: models.SpeakerNames(names)}, nil)
+
+	router := setupVideoRouter()
+	router.PUT("/videos/:id/transcripts/:transcriptId/speakers", handler.NameSpeakers)
+
+	url := "/videos/" + videoID.String() + "/transcripts/" + transcriptID.String() + "/speakers"
+	req := httptest.NewRequest("PUT", url, bytes.NewBufferString(`{"speakers": {"SPEAKER_00": "Alice"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var body models.Transcript
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "Alice", body.Speakers["SPEAKER_00"])
+
+	req = httptest.NewRequest("PUT", url, bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockTranscriptService.AssertExpectations(t)
+}
+
 func TestVideoHandler_SummarizeVideo(t *testing.T) {
 	mockVideoService := new(MockVideoService)
 	mockTranscriptService := new(MockTranscriptService)
@@ -234,7 +456,7 @@ func TestVideoHandler_SummarizeVideo(t *testing.T) {
 	mockTranscriptService.On("GetOrCreateTranscript", mock.Anything, videoID, mock.MatchedBy(func(langCodes []string) bool {
 		return len(langCodes) == 0
 	})).Return(expectedTranscript, nil)
-	mockSummaryService.On("GenerateSummary", mock.Anything, videoID, expectedTranscript.Content, "short", mock.Anything).Return(expectedSummary, nil)
+	mockSummaryService.On("GenerateSummary", mock.Anything, videoID, expectedTranscript, "short", mock.Anything).Return(expectedSummary, nil)
 	mockVideoService.On("UpdateStatus", mock.Anything, videoID, "completed").Return(nil)
 
 	router := setupVideoRouter()
@@ -254,6 +476,78 @@ func TestVideoHandler_SummarizeVideo(t *testing.T) {
 	mockVideoService.AssertExpectations(t)
 }
 
+func TestVideoHandler_SummarizeVideo_Chapters(t *testing.T) {
+	mockVideoService := new(MockVideoService)
+	mockTranscriptService := new(MockTranscriptService)
+	mockSummaryService := new(MockSummaryService)
+
+	handler := &VideoHandler{
+		videoService:      mockVideoService,
+		transcriptService: mockTranscriptService,
+		summaryService:    mockSummaryService,
+		logger:            zap.NewNop(),
+	}
+
+	videoID := uuid.New()
+	chapters := []*models.Chapter{
+		{VideoID: videoID, Title: "Intro", StartTime: 0, EndTime: 60},
+		{VideoID: videoID, Title: "Main", StartTime: 60, EndTime: 300},
+	}
+	transcript := &models.Transcript{
+		VideoID:  videoID,
+		Content:  "Hello. Main part.",
+		Segments: models.TranscriptSegments{{Start: 0, End: 5, Text: "Hello."}, {Start: 70, End: 75, Text: "Main part."}},
+	}
+
+	mockVideoService.On("GetByID", mock.Anything, videoID).Return(&models.Video{ID: videoID}, nil)
+	mockVideoService.On("GetChapters", mock.Anything, videoID).Return(chapters, nil)
+	mockTranscriptService.On("GetOrCreateTranscript", mock.Anything, videoID, mock.Anything).Return(transcript, nil)
+	mockSummaryService.On("GenerateChapteredSummary", mock.Anything, videoID, transcript, chapters, "auto").
+		Return(&models.Summary{VideoID: videoID, SummaryType: models.SummaryTypeChapters}, nil)
+	mockVideoService.On("UpdateStatus", mock.Anything, videoID, "completed").Return(nil)
+
+	router := setupVideoRouter()
+	router.POST("/videos/:id/summarize", handler.SummarizeVideo)
+
+	req := httptest.NewRequest("POST", "/videos/"+videoID.String()+"/summarize", bytes.NewBufferString(`{"type":"chapters"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSummaryService.AssertExpectations(t)
+	mockSummaryService.AssertNotCalled(t, "GenerateSummary", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestVideoHandler_SummarizeVideo_NoChapters(t *testing.T) {
+	mockVideoService := new(MockVideoService)
+	mockTranscriptService := new(MockTranscriptService)
+	mockSummaryService := new(MockSummaryService)
+
+	handler := &VideoHandler{
+		videoService:      mockVideoService,
+		transcriptService: mockTranscriptService,
+		summaryService:    mockSummaryService,
+		logger:            zap.NewNop(),
+	}
+
+	videoID := uuid.New()
+	mockVideoService.On("GetByID", mock.Anything, videoID).Return(&models.Video{ID: videoID}, nil)
+	mockVideoService.On("GetChapters", mock.Anything, videoID).Return([]*models.Chapter{}, nil)
+
+	router := setupVideoRouter()
+	router.POST("/videos/:id/summarize", handler.SummarizeVideo)
+
+	req := httptest.NewRequest("POST", "/videos/"+videoID.String()+"/summarize", bytes.NewBufferString(`{"type":"chapters"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "SUMMARY_NO_CHAPTERS")
+	mockTranscriptService.AssertNotCalled(t, "GetOrCreateTranscript", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestVideoHandler_GetSimilarVideos(t *testing.T) {
 	mockVideoService := new(MockVideoService)
 	mockTranscriptService := new(MockTranscriptService)
@@ -325,9 +619,49 @@ func (m *MockTranscriptService) GetByVideoID(ctx context.Context, videoID uuid.U
 	return args.Get(0).(*models.Transcript), args.Error(1)
 }
 
-func (m *MockTranscriptService) DownloadAudio(youtubeID string) (string, error) {
-	args := m.Called(youtubeID)
-	return args.String(0), args.Error(1)
+func (m *MockTranscriptService) ListByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.Transcript, error) {
+	args := m.Called(ctx, videoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Transcript), args.Error(1)
+}
+
+func (m *MockTranscriptService) Get(ctx context.Context, videoID, transcriptID uuid.UUID) (*models.Transcript, error) {
+	args := m.Called(ctx, videoID, transcriptID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Transcript), args.Error(1)
+}
+
+func (m *MockTranscriptService) GetStored(ctx context.Context, videoID uuid.UUID, language string) (*models.Transcript, error) {
+	args := m.Called(ctx, videoID, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Transcript), args.Error(1)
+}
+
+func (m *MockTranscriptService) SetPrimary(ctx context.Context, videoID, transcriptID uuid.UUID) (*models.Transcript, error) {
+	args := m.Called(ctx, videoID, transcriptID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Transcript), args.Error(1)
+}
+
+func (m *MockTranscriptService) NameSpeakers(ctx context.Context, videoID, transcriptID uuid.UUID, names map[string]string) (*models.Transcript, error) {
+	args := m.Called(ctx, videoID, transcriptID, names)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Transcript), args.Error(1)
+}
+
+func (m *MockTranscriptService) GetAudio(ctx context.Context, video *models.Video) (string, func(), error) {
+	args := m.Called(ctx, video)
+	return args.String(0), func() {}, args.Error(1)
 }
 
 func (m *MockTranscriptService) ListAvailableLanguages(ctx context.Context, youtubeID string) ([]AvailableLanguage, error) {
@@ -343,7 +677,7 @@ type MockSummaryService struct {
 	mock.Mock
 }
 
-func (m *MockSummaryService) GenerateSummary(ctx context.Context, videoID uuid.UUID, transcript string, summaryType string, language string) (*models.Summary, error) {
+func (m *MockSummaryService) GenerateSummary(ctx context.Context, videoID uuid.UUID, transcript *models.Transcript, summaryType string, language string) (*models.Summary, error) {
 	args := m.Called(ctx, videoID, transcript, summaryType, language)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -359,6 +693,14 @@ func (m *MockSummaryService) GenerateSummaryFromAudio(ctx context.Context, video
 	return args.Get(0).(*models.Summary), args.Error(1)
 }
 
+func (m *MockSummaryService) GenerateChapteredSummary(ctx context.Context, videoID uuid.UUID, transcript *models.Transcript, chapters []*models.Chapter, language string) (*models.Summary, error) {
+	args := m.Called(ctx, videoID, transcript, chapters, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Summary), args.Error(1)
+}
+
 func (m *MockSummaryService) GetByVideoID(ctx context.Context, videoID uuid.UUID) (*models.Summary, error) {
 	args := m.Called(ctx, videoID)
 	if args.Get(0) == nil {
@@ -380,7 +722,7 @@ type MockEmbeddingService struct {
 	mock.Mock
 }
 
-func (m *MockEmbeddingService) GenerateVideoEmbeddings(ctx context.Context, video *models.Video, transcript string) (*embedding.VideoEmbeddings, error) {
+func (m *MockEmbeddingService) GenerateVideoEmbeddings(ctx context.Context, video *models.Video, transcript *models.Transcript) (*embedding.VideoEmbeddings, error) {
 	args := m.Called(ctx, video, transcript)
 	if args.Get(0) == nil {
 		if len(args) > 1 {
@@ -61,7 +61,7 @@ func (j *AnalysisJob) ProcessVideo(ctx context.Context, videoID uuid.UUID) error
 	}
 
 	// 3. Generate embeddings
-	_, err = j.embeddingService.GenerateVideoEmbeddings(ctx, video, transcript.Content)
+	_, err = j.embeddingService.GenerateVideoEmbeddings(ctx, video, transcript)
 	if err != nil {
 		j.logger.Error("Failed to generate embeddings", zap.Error(err))
 		j.videoService.UpdateStatus(ctx, videoID, "error")
@@ -0,0 +1,72 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/pkg/artifact"
+)
+
+// defaultArtifactPruneInterval is used when no positive interval is configured
+const defaultArtifactPruneInterval = 6 * time.Hour
+
+// ArtifactPruner applies the retention policy to the stored audio
+type ArtifactPruner struct {
+	store     artifact.Store
+	retention artifact.Retention
+	interval  time.Duration
+	logger    *zap.Logger
+}
+
+func NewArtifactPruner(
+	store artifact.Store,
+	retention artifact.Retention,
+	interval time.Duration,
+	logger *zap.Logger,
+) *ArtifactPruner {
+	if interval <= 0 {
+		interval = defaultArtifactPruneInterval
+	}
+	return &ArtifactPruner{
+		store:     store,
+		retention: retention,
+		interval:  interval,
+		logger:    logger,
+	}
+}
+
+// Start prunes immediately and then on every tick until ctx is cancelled
+func (p *ArtifactPruner) Start(ctx context.Context) {
+	p.logger.Info("Artifact pruner started",
+		zap.Duration("interval", p.interval),
+		zap.Duration("max_age", p.retention.MaxAge),
+		zap.Int64("max_total_bytes", p.retention.MaxTotalSize))
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.prune(ctx)
+
+		select {
+		case <-ctx.Done():
+			p.logger.Info("Artifact pruner stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *ArtifactPruner) prune(ctx context.Context) {
+	deleted, freed, err := artifact.Prune(ctx, p.store, p.retention, time.Now())
+	if err != nil {
+		p.logger.Error("Failed to prune stored audio", zap.Int("deleted", deleted), zap.Error(err))
+		return
+	}
+	if deleted > 0 {
+		p.logger.Info("Stored audio pruned",
+			zap.Int("deleted", deleted),
+			zap.Int64("freed_bytes", freed))
+	}
+}
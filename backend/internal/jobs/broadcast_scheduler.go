@@ -0,0 +1,53 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/services/broadcast"
+)
+
+// defaultBroadcastCheckInterval is used when no positive interval is configured
+const defaultBroadcastCheckInterval = 10 * time.Minute
+
+// BroadcastScheduler starts the deferred analysis of premieres and live streams once they end
+type BroadcastScheduler struct {
+	broadcastService *broadcast.Service
+	interval         time.Duration
+	logger           *zap.Logger
+}
+
+func NewBroadcastScheduler(
+	broadcastService *broadcast.Service,
+	interval time.Duration,
+	logger *zap.Logger,
+) *BroadcastScheduler {
+	if interval <= 0 {
+		interval = defaultBroadcastCheckInterval
+	}
+	return &BroadcastScheduler{
+		broadcastService: broadcastService,
+		interval:         interval,
+		logger:           logger,
+	}
+}
+
+// Start checks scheduled videos immediately and then on every tick until ctx is cancelled
+func (s *BroadcastScheduler) Start(ctx context.Context) {
+	s.logger.Info("Broadcast scheduler started", zap.Duration("interval", s.interval))
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.broadcastService.CheckScheduled(ctx)
+
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Broadcast scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/services/channel"
+)
+
+// defaultChannelPollInterval is used when no positive interval is configured
+const defaultChannelPollInterval = 15 * time.Minute
+
+// ChannelPoller periodically checks subscribed channels for new uploads
+type ChannelPoller struct {
+	channelService *channel.Service
+	interval       time.Duration
+	logger         *zap.Logger
+}
+
+func NewChannelPoller(
+	channelService *channel.Service,
+	interval time.Duration,
+	logger *zap.Logger,
+) *ChannelPoller {
+	if interval <= 0 {
+		interval = defaultChannelPollInterval
+	}
+	return &ChannelPoller{
+		channelService: channelService,
+		interval:       interval,
+		logger:         logger,
+	}
+}
+
+// Start runs a check immediately and then on every tick until ctx is cancelled
+func (p *ChannelPoller) Start(ctx context.Context) {
+	p.logger.Info("Channel poller started", zap.Duration("interval", p.interval))
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.channelService.CheckAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			p.logger.Info("Channel poller stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package jobs
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/models"
+	kafkaservice "youtube-video-summarizer/backend/internal/services/kafka"
+)
+
+// Dispatcher queues video analysis the same way VideoHandler.CreateVideo does:
+// through Kafka events when enabled, falling back to direct processing otherwise
+type Dispatcher struct {
+	analysisJob       *AnalysisJob
+	videoEventService *kafkaservice.VideoEventService
+	logger            *zap.Logger
+}
+
+func NewDispatcher(
+	analysisJob *AnalysisJob,
+	videoEventService *kafkaservice.VideoEventService,
+	logger *zap.Logger,
+) *Dispatcher {
+	return &Dispatcher{
+		analysisJob:       analysisJob,
+		videoEventService: videoEventService,
+		logger:            logger,
+	}
+}
+
+// Enqueue publishes video.created and transcript.requested events for a new video,
+// or starts direct analysis in the background if Kafka is disabled or unavailable.
+// Scheduled broadcasts are skipped; the broadcast scheduler enqueues them once they end.
+func (d *Dispatcher) Enqueue(ctx context.Context, video *models.Video) {
+	if video.Status == models.VideoStatusScheduled {
+		d.logger.Info("Deferring analysis of scheduled broadcast", zap.String("video_id", video.ID.String()))
+		return
+	}
+
+	if d.videoEventService == nil {
+		go d.processDirect(video)
+		return
+	}
+
+	if err := d.videoEventService.PublishVideoCreated(ctx, video); err != nil {
+		d.logger.Warn("Failed to publish video.created event, continuing with direct processing",
+			zap.String("video_id", video.ID.String()),
+			zap.Error(err),
+		)
+		go d.processDirect(video)
+		return
+	}
+
+	if err := d.videoEventService.PublishTranscriptRequested(ctx, video.ID, video.YouTubeID, 1); err != nil {
+		d.logger.Warn("Failed to publish transcript.requested event",
+			zap.String("video_id", video.ID.String()),
+			zap.Error(err),
+		)
+	}
+}
+
+func (d *Dispatcher) processDirect(video *models.Video) {
+	if err := d.analysisJob.ProcessVideo(context.Background(), video.ID); err != nil {
+		d.logger.Error("Direct video analysis failed",
+			zap.String("video_id", video.ID.String()),
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,44 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/services/stats"
+)
+
+// metadataRefreshTick is how often due videos are looked up; each run refreshes all of them
+const metadataRefreshTick = 15 * time.Minute
+
+// MetadataRefresher periodically re-fetches view/like counts, titles and descriptions of YouTube videos
+type MetadataRefresher struct {
+	statsService *stats.Service
+	logger       *zap.Logger
+}
+
+func NewMetadataRefresher(statsService *stats.Service, logger *zap.Logger) *MetadataRefresher {
+	return &MetadataRefresher{
+		statsService: statsService,
+		logger:       logger,
+	}
+}
+
+// Start refreshes due videos immediately and then on every tick until ctx is cancelled
+func (r *MetadataRefresher) Start(ctx context.Context) {
+	r.logger.Info("Metadata refresher started", zap.Duration("tick", metadataRefreshTick))
+
+	ticker := time.NewTicker(metadataRefreshTick)
+	defer ticker.Stop()
+
+	for {
+		r.statsService.RefreshDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			r.logger.Info("Metadata refresher stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
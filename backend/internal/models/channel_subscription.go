@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChannelSubscription is a followed YouTube channel whose new uploads are ingested automatically
+type ChannelSubscription struct {
+	ID                uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ChannelID         string    `gorm:"type:varchar(255);uniqueIndex;not null" json:"channel_id"`
+	ChannelName       string    `gorm:"type:varchar(255)" json:"channel_name"`
+	ThumbnailURL      string    `gorm:"type:text" json:"thumbnail_url"`
+	UploadsPlaylistID string    `gorm:"type:varchar(255);not null" json:"uploads_playlist_id"`
+	Enabled           bool      `gorm:"not null;index" json:"enabled"`
+
+	// Filters applied to new uploads
+	MinDuration       int    `gorm:"default:0" json:"min_duration"`               // seconds, 0 = no minimum
+	TitleKeyword      string `gorm:"type:varchar(255)" json:"title_keyword"`      // case-insensitive substring match
+	AutoSummarizeType string `gorm:"type:varchar(50)" json:"auto_summarize_type"` // short, detailed, bullet_points; empty = none
+
+	// Polling state
+	LastPublishedAt *time.Time `json:"last_published_at"` // newest upload already considered
+	LastCheckedAt   *time.Time `json:"last_checked_at"`
+	LastError       string     `gorm:"type:text" json:"last_error,omitempty"`
+	VideosIngested  int        `gorm:"default:0" json:"videos_ingested"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// The oldest upload not ingested yet because it failed, and how many checks it failed in
+	FailingVideoID string `gorm:"type:varchar(255)" json:"-"`
+	FailedAttempts int    `gorm:"default:0" json:"-"`
+}
+
+func (ChannelSubscription) TableName() string {
+	return "channel_subscriptions"
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Chapter sources
+const (
+	ChapterSourceDescription = "description" // timestamp list in the video description
+	ChapterSourceYtDlp       = "yt-dlp"      // chapter metadata reported by yt-dlp
+)
+
+// Chapter is a titled section of a video
+type Chapter struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	VideoID   uuid.UUID `gorm:"type:uuid;not null;index:idx_chapters_video_position" json:"video_id"`
+	Position  int       `gorm:"not null;index:idx_chapters_video_position" json:"position"`
+	Title     string    `gorm:"type:text;not null" json:"title"`
+	StartTime float64   `gorm:"not null" json:"start_time"`              // seconds
+	EndTime   float64   `gorm:"not null" json:"end_time"`                // seconds
+	Source    string    `gorm:"type:varchar(20);not null" json:"source"` // description, yt-dlp
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	Video     Video     `gorm:"foreignKey:VideoID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (Chapter) TableName() string {
+	return "chapters"
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Comment is a top-level YouTube comment, ingested from the commentThreads API
+type Comment struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	VideoID     uuid.UUID `gorm:"type:uuid;not null;index" json:"video_id"`
+	CommentID   string    `gorm:"type:varchar(255);not null;uniqueIndex" json:"comment_id"` // YouTube comment ID
+	AuthorName  string    `gorm:"type:varchar(255)" json:"author_name"`
+	Text        string    `gorm:"type:text;not null" json:"text"`
+	LikeCount   int64     `gorm:"default:0" json:"like_count"`
+	ReplyCount  int       `gorm:"default:0" json:"reply_count"`
+	PublishedAt time.Time `json:"published_at"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	Video       Video     `gorm:"foreignKey:VideoID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (Comment) TableName() string {
+	return "comments"
+}
+
+// CommentTheme is a cluster of comments making the same point
+type CommentTheme struct {
+	Summary      string `json:"summary"`
+	CommentCount int    `json:"comment_count"`
+	Example      string `json:"example,omitempty"` // a representative comment
+}
+
+// CommentThemes is a custom type for JSONB serialization
+type CommentThemes []CommentTheme
+
+// Value implements driver.Valuer interface for JSONB
+func (ct CommentThemes) Value() (driver.Value, error) {
+	if len(ct) == 0 {
+		return "[]", nil
+	}
+	return json.Marshal(ct)
+}
+
+// Scan implements sql.Scanner interface for JSONB
+func (ct *CommentThemes) Scan(value interface{}) error {
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	}
+	if len(bytes) == 0 {
+		*ct = CommentThemes{}
+		return nil
+	}
+	return json.Unmarshal(bytes, ct)
+}
+
+// CommentSummary is the audience reaction to a video, generated from its top comments
+type CommentSummary struct {
+	ID           uuid.UUID     `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	VideoID      uuid.UUID     `gorm:"type:uuid;not null;index" json:"video_id"`
+	Overview     string        `gorm:"type:text" json:"overview"`
+	Questions    CommentThemes `gorm:"type:jsonb" json:"questions"`
+	Corrections  CommentThemes `gorm:"type:jsonb" json:"corrections"`
+	Praise       CommentThemes `gorm:"type:jsonb" json:"praise"`
+	Complaints   CommentThemes `gorm:"type:jsonb" json:"complaints"`
+	CommentCount int           `gorm:"not null" json:"comment_count"` // comments the summary is based on
+	Language     string        `gorm:"type:varchar(10);not null;default:'auto';index" json:"language"` // requested output language, "auto" for the comments' own
+	ModelUsed    string        `gorm:"type:varchar(100);not null" json:"model_used"`
+	CreatedAt    time.Time     `gorm:"autoCreateTime" json:"created_at"`
+	Video        Video         `gorm:"foreignKey:VideoID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (CommentSummary) TableName() string {
+	return "comment_summaries"
+}
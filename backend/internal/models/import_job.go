@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImportJob is a bulk import of video URLs/IDs with per-item results
+type ImportJob struct {
+	ID               uuid.UUID       `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Status           string          `gorm:"type:varchar(50);default:'processing';index" json:"status"` // processing, completed
+	Total            int             `gorm:"default:0" json:"total"`
+	Created          int             `gorm:"default:0" json:"created"`
+	SkippedDuplicate int             `gorm:"default:0" json:"skipped_duplicate"`
+	Invalid          int             `gorm:"default:0" json:"invalid"`
+	Failed           int             `gorm:"default:0" json:"failed"`
+	CreatedAt        time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+	CompletedAt      *time.Time      `json:"completed_at,omitempty"`
+	Items            []ImportJobItem `gorm:"foreignKey:ImportJobID;constraint:OnDelete:CASCADE" json:"items,omitempty"`
+}
+
+func (ImportJob) TableName() string {
+	return "import_jobs"
+}
+
+// ImportJobItem is a single line of a bulk import
+type ImportJobItem struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ImportJobID uuid.UUID  `gorm:"type:uuid;not null;index" json:"import_job_id"`
+	Line        int        `gorm:"not null" json:"line"`
+	Input       string     `gorm:"type:text" json:"input"`
+	YouTubeID   string     `gorm:"type:varchar(255);column:youtube_id" json:"youtube_id,omitempty"`
+	VideoID     *uuid.UUID `gorm:"type:uuid" json:"video_id,omitempty"`
+	Status      string     `gorm:"type:varchar(50);default:'pending'" json:"status"` // pending, created, duplicate, invalid, failed
+	Error       string     `gorm:"type:text" json:"error,omitempty"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (ImportJobItem) TableName() string {
+	return "import_job_items"
+}
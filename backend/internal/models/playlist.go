@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Playlist struct {
+	ID                uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	YouTubePlaylistID string         `gorm:"type:varchar(255);uniqueIndex;not null;column:youtube_playlist_id" json:"youtube_playlist_id"`
+	Title             string         `gorm:"type:text;not null" json:"title"`
+	Description       string         `gorm:"type:text" json:"description"`
+	ChannelID         string         `gorm:"type:varchar(255);index" json:"channel_id"`
+	ChannelName       string         `gorm:"type:varchar(255)" json:"channel_name"`
+	ThumbnailURL      string         `gorm:"type:text" json:"thumbnail_url"`
+	ItemCount         int            `gorm:"default:0" json:"item_count"`
+	Status            string         `gorm:"type:varchar(50);default:'importing';index" json:"status"` // importing, completed, error
+	CreatedAt         time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	Items             []PlaylistItem `gorm:"foreignKey:PlaylistID;constraint:OnDelete:CASCADE" json:"items,omitempty"`
+}
+
+func (Playlist) TableName() string {
+	return "playlists"
+}
+
+// PlaylistItem links a playlist position to the video created from it
+type PlaylistItem struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	PlaylistID uuid.UUID  `gorm:"type:uuid;not null;index" json:"playlist_id"`
+	Position   int        `gorm:"not null" json:"position"`
+	YouTubeID  string     `gorm:"type:varchar(255);not null;column:youtube_id" json:"youtube_id"`
+	Title      string     `gorm:"type:text" json:"title"`
+	VideoID    *uuid.UUID `gorm:"type:uuid;index" json:"video_id"`
+	Status     string     `gorm:"type:varchar(50);default:'pending'" json:"status"` // pending, created, failed
+	Error      string     `gorm:"type:text" json:"error,omitempty"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+	Video      *Video     `gorm:"foreignKey:VideoID;constraint:OnDelete:SET NULL" json:"video,omitempty"`
+}
+
+func (PlaylistItem) TableName() string {
+	return "playlist_items"
+}
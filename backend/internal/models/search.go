@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TranscriptSearchSegment is one transcript segment in the full-text search index. The rows
+// of a transcript are rewritten whenever it is saved; the tsv column searched is generated
+// from Text (see runMigrations).
+type TranscriptSearchSegment struct {
+	TranscriptID uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Position     int        `gorm:"primaryKey;autoIncrement:false"` // index of the segment in the transcript
+	VideoID      uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Language     string     `gorm:"type:varchar(10);not null"`
+	Start        float64    `gorm:"column:start_time;not null"`
+	End          float64    `gorm:"column:end_time;not null"`
+	Text         string     `gorm:"type:text;not null"`
+	Transcript   Transcript `gorm:"foreignKey:TranscriptID;constraint:OnDelete:CASCADE"`
+}
+
+func (TranscriptSearchSegment) TableName() string {
+	return "transcript_search_segments"
+}
+
+// TranscriptSearchResult is a video whose transcript matches a search, with its best hits
+type TranscriptSearchResult struct {
+	Video    *Video                `json:"video"`
+	HitCount int                   `json:"hit_count"` // matching segments, of which Hits holds the best
+	Hits     []TranscriptSearchHit `json:"hits"`
+}
+
+// TranscriptSearchHit is a matching transcript segment. Snippet is the HTML-escaped segment
+// text with the matched words wrapped in <mark></mark>.
+type TranscriptSearchHit struct {
+	TranscriptID uuid.UUID `json:"transcript_id"`
+	Language     string    `json:"language"`
+	Start        float64   `json:"start"`
+	End          float64   `json:"end"`
+	Snippet      string    `json:"snippet"`
+}
+
+// TranscriptSearchQuery is a full-text search over transcripts. Without Language only the
+// primary transcript of each video is searched.
+type TranscriptSearchQuery struct {
+	Query           string
+	ChannelID       string
+	Language        string
+	PublishedAfter  *time.Time
+	PublishedBefore *time.Time
+	Limit           int
+	Offset          int
+}
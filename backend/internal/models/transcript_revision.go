@@ -0,0 +1,76 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TranscriptRevision records one edit of a transcript's segments by an editor
+type TranscriptRevision struct {
+	ID           uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	TranscriptID uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex:idx_transcript_revisions_number" json:"transcript_id"`
+	Number       int            `gorm:"not null;uniqueIndex:idx_transcript_revisions_number" json:"number"` // 1 for the first edit of a transcript
+	Author       string         `gorm:"type:varchar(255);not null" json:"author"`
+	Comment      string         `gorm:"type:text" json:"comment,omitempty"`
+	Changes      SegmentChanges `gorm:"type:jsonb;not null" json:"changes"`
+	CreatedAt    time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	Transcript   Transcript     `gorm:"foreignKey:TranscriptID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (TranscriptRevision) TableName() string {
+	return "transcript_revisions"
+}
+
+// SegmentChange is the text of one segment before and after an edit, with the word diff
+// between the two
+type SegmentChange struct {
+	Position int        `json:"position"` // index of the segment in the transcript
+	Start    float64    `json:"start"`
+	End      float64    `json:"end"`
+	Before   string     `json:"before"`
+	After    string     `json:"after"`
+	Diff     []DiffPart `json:"diff"`
+}
+
+// DiffPart is a run of words that is unchanged, inserted or deleted
+type DiffPart struct {
+	Op   string `json:"op"` // equal, insert, delete
+	Text string `json:"text"`
+}
+
+// Diff operations
+const (
+	DiffEqual  = "equal"
+	DiffInsert = "insert"
+	DiffDelete = "delete"
+)
+
+// SegmentChanges is a custom type for JSONB serialization
+type SegmentChanges []SegmentChange
+
+// Value implements driver.Valuer interface for JSONB
+func (sc SegmentChanges) Value() (driver.Value, error) {
+	if len(sc) == 0 {
+		return "[]", nil
+	}
+	return json.Marshal(sc)
+}
+
+// Scan implements sql.Scanner interface for JSONB
+func (sc *SegmentChanges) Scan(value interface{}) error {
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	}
+	if len(bytes) == 0 {
+		*sc = SegmentChanges{}
+		return nil
+	}
+	return json.Unmarshal(bytes, sc)
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Upload tracks a resumable file upload; once all bytes are received a Video is created from it
+type Upload struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Filename  string     `gorm:"type:varchar(255);not null" json:"filename"`
+	Title     string     `gorm:"type:text" json:"title"`
+	Size      int64      `gorm:"not null" json:"size"`    // total size in bytes
+	Offset    int64      `gorm:"default:0" json:"offset"` // bytes received so far
+	Path      string     `gorm:"type:text;not null" json:"-"`
+	Status    string     `gorm:"type:varchar(50);default:'uploading';index" json:"status"` // uploading, completed, failed
+	VideoID   *uuid.UUID `gorm:"type:uuid" json:"video_id,omitempty"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (Upload) TableName() string {
+	return "uploads"
+}
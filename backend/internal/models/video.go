@@ -10,23 +10,44 @@ import (
 	"gorm.io/gorm"
 )
 
+// Video source types
+const (
+	SourceTypeYouTube = "youtube"
+	SourceTypeUpload  = "upload"
+	SourceTypePodcast = "podcast"
+)
+
+// VideoStatusScheduled marks an upcoming premiere or a stream that is still live. It is not
+// analyzed until the broadcast has ended and its recording is available.
+const VideoStatusScheduled = "scheduled"
+
 type Video struct {
 	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	YouTubeID    string    `gorm:"type:varchar(255);uniqueIndex;not null;column:youtube_id" json:"youtube_id"`
+	YouTubeID    string    `gorm:"type:varchar(255);not null;column:youtube_id" json:"youtube_id"` // empty for other sources; unique when set (see runMigrations)
+	SourceType   string    `gorm:"type:varchar(50);default:'youtube';index" json:"source_type"` // youtube, upload, podcast
+	SourceID     string    `gorm:"type:varchar(512)" json:"source_id,omitempty"`                // ID within the source: YouTube video ID, hash of podcast feed URL and episode GUID
+	SourceURL    string    `gorm:"type:text" json:"source_url,omitempty"`                       // remote media, e.g. a podcast enclosure
+	MediaPath    string    `gorm:"type:text" json:"-"`                                          // stored file for uploaded videos
 	Title        string    `gorm:"type:text;not null" json:"title"`
 	Description  string    `gorm:"type:text" json:"description"`
 	ChannelID    string    `gorm:"type:varchar(255);not null;index" json:"channel_id"`
 	ChannelName  string    `gorm:"type:varchar(255);not null" json:"channel_name"`
 	Duration     int       `gorm:"not null" json:"duration"` // seconds
+	StartTime    int       `gorm:"default:0" json:"start_time"` // seconds, from the t= parameter of the submitted URL
 	ViewCount    int64     `gorm:"default:0" json:"view_count"`
 	LikeCount    int64     `gorm:"default:0" json:"like_count"`
 	PublishedAt  time.Time `gorm:"not null" json:"published_at"`
 	ThumbnailURL string    `gorm:"type:text" json:"thumbnail_url"`
 	Tags         pq.StringArray `gorm:"type:text[];default:'{}'" json:"tags"`
 	Category     string    `gorm:"type:varchar(100)" json:"category"`
-	Status       string    `gorm:"type:varchar(50);default:'pending';index" json:"status"` // pending, processing, completed, error
+	Status       string    `gorm:"type:varchar(50);default:'pending';index" json:"status"` // pending, scheduled, processing, completed, error
 	HasTranscript bool     `gorm:"default:false" json:"has_transcript"`
 	HasSummary   bool     `gorm:"default:false" json:"has_summary"`
+	Availability string    `gorm:"type:varchar(20);default:'available'" json:"availability"` // available, private, deleted (YouTube only)
+	StatsUpdatedAt *time.Time `gorm:"index" json:"stats_updated_at,omitempty"` // last metadata refresh
+	StatsAttemptedAt *time.Time `json:"-"` // last refresh attempt, successful or not; failed videos wait an interval before the next try
+	LiveBroadcastContent string `gorm:"type:varchar(20);default:'none'" json:"live_broadcast_content"` // none, upcoming, live (YouTube only)
+	ScheduledStartAt *time.Time `json:"scheduled_start_at,omitempty"` // start of a premiere or stream
 	CreatedAt    time.Time `gorm:"autoCreateTime;index:idx_videos_created_at" json:"created_at"`
 	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
@@ -35,15 +56,24 @@ func (Video) TableName() string {
 	return "videos"
 }
 
+// Transcript is one transcript of a video; a video has at most one per (language, source).
+// The primary transcript is the one analysis (summaries, embeddings) uses by default.
 type Transcript struct {
-	ID        uuid.UUID          `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	VideoID   uuid.UUID          `gorm:"type:uuid;not null;index" json:"video_id"`
-	Language  string             `gorm:"type:varchar(10);not null" json:"language"`
-	Source    string             `gorm:"type:varchar(50);not null" json:"source"` // youtube, whisper
-	Content   string             `gorm:"type:text;not null" json:"content"`
-	Segments  TranscriptSegments `gorm:"type:jsonb" json:"segments"`
-	CreatedAt time.Time          `gorm:"autoCreateTime" json:"created_at"`
-	Video     Video              `gorm:"foreignKey:VideoID;constraint:OnDelete:CASCADE" json:"-"`
+	ID           uuid.UUID          `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	VideoID      uuid.UUID          `gorm:"type:uuid;not null;index" json:"video_id"` // unique with language and source (see runMigrations)
+	Language     string             `gorm:"type:varchar(10);not null" json:"language"`
+	Source       string             `gorm:"type:varchar(50);not null" json:"source"` // youtube, podcast, upload, whisper, translated
+	IsPrimary    bool               `gorm:"default:false" json:"is_primary"`         // one per video (see runMigrations)
+	Content      string             `gorm:"type:text;not null" json:"content"`
+	Segments     TranscriptSegments `gorm:"type:jsonb" json:"segments"`
+	Speakers     SpeakerNames       `gorm:"type:jsonb" json:"speakers,omitempty"` // display names by segment speaker label
+	QualityScore *float64           `json:"quality_score,omitempty"`              // 0-1, set for published captions
+	// TranslatedFrom is the transcript a translation was made from; Stale is set on a
+	// translation when that transcript is edited afterwards
+	TranslatedFrom *uuid.UUID `gorm:"type:uuid;index" json:"translated_from,omitempty"`
+	Stale          bool       `gorm:"default:false" json:"stale"`
+	CreatedAt    time.Time          `gorm:"autoCreateTime" json:"created_at"`
+	Video        Video              `gorm:"foreignKey:VideoID;constraint:OnDelete:CASCADE" json:"-"`
 }
 
 func (Transcript) TableName() string {
@@ -51,6 +81,15 @@ func (Transcript) TableName() string {
 }
 
 type TranscriptSegment struct {
+	Start   float64          `json:"start"`
+	End     float64          `json:"end"`
+	Text    string           `json:"text"`
+	Speaker string           `json:"speaker,omitempty"` // diarization label, e.g. SPEAKER_00
+	Words   []TranscriptWord `json:"words,omitempty"`   // only when the source timed every word
+}
+
+// TranscriptWord is one word of a segment with the time it is spoken
+type TranscriptWord struct {
 	Start float64 `json:"start"`
 	End   float64 `json:"end"`
 	Text  string  `json:"text"`
@@ -92,11 +131,61 @@ func (ts *TranscriptSegments) Scan(value interface{}) error {
 	return json.Unmarshal(bytes, ts)
 }
 
+// SpeakerName returns the display name of a speaker label, the label itself when unnamed
+func (t *Transcript) SpeakerName(label string) string {
+	if name := t.Speakers[label]; name != "" {
+		return name
+	}
+	return label
+}
+
+// HasSpeakers reports whether the transcript's segments are labelled with speakers
+func (t *Transcript) HasSpeakers() bool {
+	for _, seg := range t.Segments {
+		if seg.Speaker != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// SpeakerNames is a custom type for JSONB serialization
+type SpeakerNames map[string]string
+
+// Value implements driver.Valuer interface for JSONB
+func (sn SpeakerNames) Value() (driver.Value, error) {
+	if len(sn) == 0 {
+		return "{}", nil
+	}
+	return json.Marshal(sn)
+}
+
+// Scan implements sql.Scanner interface for JSONB
+func (sn *SpeakerNames) Scan(value interface{}) error {
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	}
+	if len(bytes) == 0 {
+		*sn = SpeakerNames{}
+		return nil
+	}
+	return json.Unmarshal(bytes, sn)
+}
+
+// SummaryTypeChapters summarizes each chapter separately; other types summarize the whole transcript
+const SummaryTypeChapters = "chapters"
+
 type Summary struct {
 	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
 	VideoID     uuid.UUID `gorm:"type:uuid;not null;index" json:"video_id"`
 	ModelUsed   string    `gorm:"type:varchar(100);not null" json:"model_used"`
-	SummaryType string    `gorm:"type:varchar(50);not null" json:"summary_type"` // short, detailed, bullet_points
+	SummaryType string    `gorm:"type:varchar(50);not null" json:"summary_type"` // short, detailed, bullet_points, chapters
+	TranscriptID *uuid.UUID `gorm:"type:uuid;index" json:"transcript_id,omitempty"` // transcript summarized; nil when summarized from audio
+	Stale       bool      `gorm:"default:false" json:"stale"` // the transcript was edited after summarizing
 	Content     string    `gorm:"type:text;not null" json:"content"`
 	KeyPoints   pq.StringArray `gorm:"type:text[];default:'{}'" json:"key_points"`
 	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
@@ -113,6 +202,8 @@ type VideoEmbedding struct {
 	EmbeddingType string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_embeddings_video_type" json:"embedding_type"` // title, description, transcript, combined
 	Embedding     Vector    `gorm:"type:vector(768);not null" json:"embedding"`
 	ModelUsed     string    `gorm:"type:varchar(100);not null" json:"model_used"`
+	TranscriptID  *uuid.UUID `gorm:"type:uuid;index" json:"transcript_id,omitempty"` // transcript embedded; nil when built without one
+	Stale         bool      `gorm:"default:false" json:"stale"` // the transcript was edited after embedding
 	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 	Video         Video     `gorm:"foreignKey:VideoID;constraint:OnDelete:CASCADE" json:"-"`
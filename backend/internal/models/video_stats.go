@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VideoStatsSnapshot is one point of a video's view/like count time series
+type VideoStatsSnapshot struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	VideoID    uuid.UUID `gorm:"type:uuid;not null;index:idx_video_stats_snapshots_video_captured" json:"video_id"`
+	ViewCount  int64     `gorm:"default:0" json:"view_count"`
+	LikeCount  int64     `gorm:"default:0" json:"like_count"`
+	CapturedAt time.Time `gorm:"not null;index:idx_video_stats_snapshots_video_captured" json:"captured_at"`
+	Video      Video     `gorm:"foreignKey:VideoID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (VideoStatsSnapshot) TableName() string {
+	return "video_stats_snapshots"
+}
+
+// VideoMetadataChange records an edit of a video's title or description on YouTube
+type VideoMetadataChange struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	VideoID    uuid.UUID `gorm:"type:uuid;not null;index" json:"video_id"`
+	Field      string    `gorm:"type:varchar(50);not null" json:"field"` // title, description, availability
+	OldValue   string    `gorm:"type:text" json:"old_value"`
+	NewValue   string    `gorm:"type:text" json:"new_value"`
+	DetectedAt time.Time `gorm:"not null" json:"detected_at"`
+	Video      Video     `gorm:"foreignKey:VideoID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (VideoMetadataChange) TableName() string {
+	return "video_metadata_changes"
+}
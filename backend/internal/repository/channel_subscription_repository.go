@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+type ChannelSubscriptionRepository interface {
+	Create(ctx context.Context, subscription *models.ChannelSubscription) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ChannelSubscription, error)
+	GetByChannelID(ctx context.Context, channelID string) (*models.ChannelSubscription, error)
+	List(ctx context.Context, limit, offset int) ([]*models.ChannelSubscription, int, error)
+	ListEnabled(ctx context.Context) ([]*models.ChannelSubscription, error)
+	Update(ctx context.Context, subscription *models.ChannelSubscription) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type channelSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewChannelSubscriptionRepository(db *gorm.DB) ChannelSubscriptionRepository {
+	return &channelSubscriptionRepository{db: db}
+}
+
+func (r *channelSubscriptionRepository) Create(ctx context.Context, subscription *models.ChannelSubscription) error {
+	if subscription.ID == uuid.Nil {
+		subscription.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(subscription).Error
+}
+
+func (r *channelSubscriptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ChannelSubscription, error) {
+	var subscription models.ChannelSubscription
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&subscription).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *channelSubscriptionRepository) GetByChannelID(ctx context.Context, channelID string) (*models.ChannelSubscription, error) {
+	var subscription models.ChannelSubscription
+	err := r.db.WithContext(ctx).Where("channel_id = ?", channelID).First(&subscription).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *channelSubscriptionRepository) List(ctx context.Context, limit, offset int) ([]*models.ChannelSubscription, int, error) {
+	var subscriptions []*models.ChannelSubscription
+	var total int64
+
+	if err := r.db.WithContext(ctx).Model(&models.ChannelSubscription{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := r.db.WithContext(ctx).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return subscriptions, int(total), nil
+}
+
+func (r *channelSubscriptionRepository) ListEnabled(ctx context.Context) ([]*models.ChannelSubscription, error) {
+	var subscriptions []*models.ChannelSubscription
+	err := r.db.WithContext(ctx).
+		Where("enabled = ?", true).
+		Order("last_checked_at ASC NULLS FIRST").
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *channelSubscriptionRepository) Update(ctx context.Context, subscription *models.ChannelSubscription) error {
+	return r.db.WithContext(ctx).Save(subscription).Error
+}
+
+func (r *channelSubscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.ChannelSubscription{}, id).Error
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+type ChapterRepository interface {
+	// ReplaceForVideo swaps the stored chapters of a video for the given ones
+	ReplaceForVideo(ctx context.Context, videoID uuid.UUID, chapters []*models.Chapter) error
+	ListByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.Chapter, error)
+}
+
+type chapterRepository struct {
+	db *gorm.DB
+}
+
+func NewChapterRepository(db *gorm.DB) ChapterRepository {
+	return &chapterRepository{db: db}
+}
+
+func (r *chapterRepository) ReplaceForVideo(ctx context.Context, videoID uuid.UUID, chapters []*models.Chapter) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("video_id = ?", videoID).Delete(&models.Chapter{}).Error; err != nil {
+			return err
+		}
+		if len(chapters) == 0 {
+			return nil
+		}
+		for i, chapter := range chapters {
+			if chapter.ID == uuid.Nil {
+				chapter.ID = uuid.New()
+			}
+			chapter.VideoID = videoID
+			chapter.Position = i
+		}
+		return tx.Create(&chapters).Error
+	})
+}
+
+func (r *chapterRepository) ListByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.Chapter, error) {
+	var chapters []*models.Chapter
+	err := r.db.WithContext(ctx).
+		Where("video_id = ?", videoID).
+		Order("position ASC").
+		Find(&chapters).Error
+	return chapters, err
+}
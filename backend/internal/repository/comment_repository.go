@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+type CommentRepository interface {
+	// ReplaceForVideo swaps the stored comments of a video for a fresh fetch
+	ReplaceForVideo(ctx context.Context, videoID uuid.UUID, comments []*models.Comment) error
+	// ListTop returns the most liked comments of a video
+	ListTop(ctx context.Context, videoID uuid.UUID, limit int) ([]*models.Comment, error)
+	CreateSummary(ctx context.Context, summary *models.CommentSummary) error
+	// GetLatestSummary returns the most recent comment summary of a video
+	GetLatestSummary(ctx context.Context, videoID uuid.UUID, language string) (*models.CommentSummary, error)
+}
+
+type commentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &commentRepository{db: db}
+}
+
+func (r *commentRepository) ReplaceForVideo(ctx context.Context, videoID uuid.UUID, comments []*models.Comment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("video_id = ?", videoID).Delete(&models.Comment{}).Error; err != nil {
+			return err
+		}
+		if len(comments) == 0 {
+			return nil
+		}
+		for _, comment := range comments {
+			if comment.ID == uuid.Nil {
+				comment.ID = uuid.New()
+			}
+			comment.VideoID = videoID
+		}
+		return tx.CreateInBatches(&comments, 100).Error
+	})
+}
+
+func (r *commentRepository) ListTop(ctx context.Context, videoID uuid.UUID, limit int) ([]*models.Comment, error) {
+	var comments []*models.Comment
+	err := r.db.WithContext(ctx).
+		Where("video_id = ?", videoID).
+		Order("like_count DESC, published_at ASC").
+		Limit(limit).
+		Find(&comments).Error
+	return comments, err
+}
+
+func (r *commentRepository) CreateSummary(ctx context.Context, summary *models.CommentSummary) error {
+	if summary.ID == uuid.Nil {
+		summary.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(summary).Error
+}
+
+func (r *commentRepository) GetLatestSummary(ctx context.Context, videoID uuid.UUID, language string) (*models.CommentSummary, error) {
+	var summary models.CommentSummary
+	err := r.db.WithContext(ctx).
+		Where("video_id = ? AND language = ?", videoID, language).
+		Order("created_at DESC").
+		First(&summary).Error
+	if err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
@@ -25,6 +25,8 @@ func NewDatabase(cfg DatabaseConfig, zapLogger *zap.Logger) (*gorm.DB, error) {
 	// Open database connection
 	db, err := gorm.Open(postgres.Open(cfg.URL), &gorm.Config{
 		Logger: gormLogger,
+		// Report unique violations as gorm.ErrDuplicatedKey
+		TranslateError: true,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
@@ -84,6 +86,50 @@ func enableExtensions(db *gorm.DB, zapLogger *zap.Logger) error {
 	return nil
 }
 
+// archiveDuplicateTranscripts creates the unique (video_id, language, source) index of
+// transcripts once. Older rows of the same key are moved to transcript_duplicates first, in the
+// same transaction, so they can still be inspected or restored; nothing is deleted outright.
+func archiveDuplicateTranscripts(db *gorm.DB, zapLogger *zap.Logger) error {
+	var indexed bool
+	err := db.Raw("SELECT EXISTS (SELECT 1 FROM pg_indexes WHERE indexname = 'idx_transcripts_video_language_source')").
+		Scan(&indexed).Error
+	if err != nil || indexed {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var duplicates int64
+		err := tx.Raw(`SELECT COUNT(*) FROM transcripts t WHERE EXISTS (
+				SELECT 1 FROM transcripts newer
+				WHERE t.video_id = newer.video_id AND t.language = newer.language AND t.source = newer.source
+				AND (t.created_at, t.id) < (newer.created_at, newer.id))`).
+			Scan(&duplicates).Error
+		if err != nil {
+			return err
+		}
+
+		if duplicates > 0 {
+			if err := tx.Exec("CREATE TABLE IF NOT EXISTS transcript_duplicates (LIKE transcripts INCLUDING DEFAULTS)").Error; err != nil {
+				return err
+			}
+			moved := tx.Exec(`WITH moved AS (
+					DELETE FROM transcripts t USING transcripts newer
+					WHERE t.video_id = newer.video_id AND t.language = newer.language AND t.source = newer.source
+					AND (t.created_at, t.id) < (newer.created_at, newer.id)
+					RETURNING t.*
+				)
+				INSERT INTO transcript_duplicates SELECT * FROM moved`)
+			if moved.Error != nil {
+				return moved.Error
+			}
+			zapLogger.Warn("Moved duplicate transcripts to transcript_duplicates, keeping the newest of each video, language and source",
+				zap.Int64("transcripts", moved.RowsAffected))
+		}
+
+		return tx.Exec("CREATE UNIQUE INDEX idx_transcripts_video_language_source ON transcripts(video_id, language, source)").Error
+	})
+}
+
 func runMigrations(db *gorm.DB, zapLogger *zap.Logger) error {
 	// AutoMigrate all models
 	err := db.AutoMigrate(
@@ -94,6 +140,19 @@ func runMigrations(db *gorm.DB, zapLogger *zap.Logger) error {
 		&models.VideoSimilarity{},
 		&models.TokenUsage{},
 		&models.Settings{},
+		&models.Playlist{},
+		&models.PlaylistItem{},
+		&models.ChannelSubscription{},
+		&models.ImportJob{},
+		&models.ImportJobItem{},
+		&models.Upload{},
+		&models.VideoStatsSnapshot{},
+		&models.VideoMetadataChange{},
+		&models.Chapter{},
+		&models.Comment{},
+		&models.CommentSummary{},
+		&models.TranscriptSearchSegment{},
+		&models.TranscriptRevision{},
 	)
 	if err != nil {
 		return fmt.Errorf("auto migration failed: %w", err)
@@ -105,6 +164,69 @@ func runMigrations(db *gorm.DB, zapLogger *zap.Logger) error {
 		zapLogger.Warn("Failed to create unique constraint for embeddings", zap.Error(err))
 	}
 
+	// A video has one transcript per (language, source), one of them primary. Earlier versions
+	// added a row per request: keep the newest of each, and make the oldest remaining
+	// transcript of a video (the one its analysis started from) primary.
+	if err := archiveDuplicateTranscripts(db, zapLogger); err != nil {
+		return fmt.Errorf("failed to archive duplicate transcripts: %w", err)
+	}
+	transcriptMigrations := []string{
+		`UPDATE transcripts t SET is_primary = true
+			WHERE t.id = (SELECT id FROM transcripts o WHERE o.video_id = t.video_id ORDER BY created_at, id LIMIT 1)
+			AND NOT EXISTS (SELECT 1 FROM transcripts p WHERE p.video_id = t.video_id AND p.is_primary)`,
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_transcripts_primary ON transcripts(video_id) WHERE is_primary",
+	}
+	for _, stmt := range transcriptMigrations {
+		if err := db.Exec(stmt).Error; err != nil {
+			zapLogger.Warn("Failed to migrate transcript keys", zap.Error(err))
+		}
+	}
+
+	// Transcript search matches the words of each segment as written ('simple' configuration:
+	// no stemming, as transcripts come in any language). Transcripts stored before the index
+	// existed are added to it once.
+	searchMigrations := []string{
+		"ALTER TABLE transcript_search_segments ADD COLUMN IF NOT EXISTS tsv tsvector GENERATED ALWAYS AS (to_tsvector('simple', text)) STORED",
+		"CREATE INDEX IF NOT EXISTS idx_transcript_search_tsv ON transcript_search_segments USING GIN (tsv)",
+		`INSERT INTO transcript_search_segments (transcript_id, position, video_id, language, start_time, end_time, text)
+			SELECT t.id, s.ordinality - 1, t.video_id, t.language,
+				COALESCE((s.value->>'start')::float8, 0), COALESCE((s.value->>'end')::float8, 0), COALESCE(s.value->>'text', '')
+			FROM transcripts t, jsonb_array_elements(CASE jsonb_typeof(t.segments) WHEN 'array' THEN t.segments ELSE '[]' END) WITH ORDINALITY s
+			WHERE NOT EXISTS (SELECT 1 FROM transcript_search_segments x WHERE x.transcript_id = t.id)`,
+	}
+	for _, stmt := range searchMigrations {
+		if err := db.Exec(stmt).Error; err != nil {
+			zapLogger.Warn("Failed to migrate transcript search index", zap.Error(err))
+		}
+	}
+
+	// youtube_id is only unique for YouTube videos; uploaded files leave it empty.
+	// Replace the old full unique index (created by earlier versions) with a partial one.
+	youtubeIDMigrations := []string{
+		`DO $$ BEGIN
+			IF EXISTS (SELECT 1 FROM pg_indexes WHERE indexname = 'idx_videos_youtube_id' AND indexdef LIKE 'CREATE UNIQUE%') THEN
+				DROP INDEX idx_videos_youtube_id;
+			END IF;
+		END $$`,
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_videos_youtube_id_unique ON videos(youtube_id) WHERE youtube_id <> ''",
+		// Videos created before source_id existed are all YouTube videos
+		"UPDATE videos SET source_id = youtube_id WHERE source_id IS NULL OR (source_id = '' AND youtube_id <> '')",
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_videos_source ON videos(source_type, source_id) WHERE source_id <> ''",
+	}
+	for _, stmt := range youtubeIDMigrations {
+		if err := db.Exec(stmt).Error; err != nil {
+			zapLogger.Warn("Failed to migrate video source indexes", zap.Error(err))
+		}
+	}
+
+	// Podcast episodes were keyed by their GUID, which is only unique within a feed. Rekey them
+	// like source.PodcastSource does: SHA-256 of the feed URL (channel_id) and the GUID.
+	podcastMigration := `UPDATE videos SET source_id = encode(sha256(convert_to(channel_id || E'\n' || source_id, 'UTF8')), 'hex')
+		WHERE source_type = 'podcast' AND source_id <> '' AND source_id !~ '^[0-9a-f]{64}$'`
+	if err := db.Exec(podcastMigration).Error; err != nil {
+		zapLogger.Warn("Failed to migrate podcast source IDs", zap.Error(err))
+	}
+
 	// Create custom indexes that GORM might not create
 	indexes := []string{
 		"CREATE INDEX IF NOT EXISTS idx_videos_youtube_id ON videos(youtube_id)",
@@ -122,6 +244,7 @@ func runMigrations(db *gorm.DB, zapLogger *zap.Logger) error {
 		"CREATE INDEX IF NOT EXISTS idx_token_usage_provider ON token_usage(provider)",
 		"CREATE INDEX IF NOT EXISTS idx_token_usage_operation ON token_usage(operation)",
 		"CREATE INDEX IF NOT EXISTS idx_token_usage_created_at ON token_usage(created_at DESC)",
+		"CREATE INDEX IF NOT EXISTS idx_playlist_items_playlist_position ON playlist_items(playlist_id, position)",
 	}
 
 	for _, idx := range indexes {
@@ -36,12 +36,14 @@ func (r *embeddingRepository) Save(ctx context.Context, embedding *models.VideoE
 	
 	// Use raw SQL for ON CONFLICT with pgvector
 	query := `
-		INSERT INTO video_embeddings (id, video_id, embedding_type, embedding, model_used, created_at, updated_at)
-		VALUES ($1, $2, $3, $4::vector, $5, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		INSERT INTO video_embeddings (id, video_id, embedding_type, embedding, model_used, transcript_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4::vector, $5, $6, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
 		ON CONFLICT (video_id, embedding_type)
 		DO UPDATE SET
 			embedding = $4::vector,
 			model_used = $5,
+			transcript_id = $6,
+			stale = false,
 			updated_at = CURRENT_TIMESTAMP
 	`
 	
@@ -50,7 +52,7 @@ func (r *embeddingRepository) Save(ctx context.Context, embedding *models.VideoE
 	
 	return r.db.WithContext(ctx).Exec(query,
 		embedding.ID, embedding.VideoID, embedding.EmbeddingType,
-		vec, embedding.ModelUsed,
+		vec, embedding.ModelUsed, embedding.TranscriptID,
 	).Error
 }
 
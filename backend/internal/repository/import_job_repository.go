@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+type ImportJobRepository interface {
+	Create(ctx context.Context, job *models.ImportJob) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ImportJob, error)
+	List(ctx context.Context, limit, offset int) ([]*models.ImportJob, int, error)
+	ListProcessing(ctx context.Context) ([]*models.ImportJob, error)
+	Update(ctx context.Context, job *models.ImportJob) error
+	UpdateItem(ctx context.Context, item *models.ImportJobItem) error
+}
+
+type importJobRepository struct {
+	db *gorm.DB
+}
+
+func NewImportJobRepository(db *gorm.DB) ImportJobRepository {
+	return &importJobRepository{db: db}
+}
+
+// Create saves the job together with its items
+func (r *importJobRepository) Create(ctx context.Context, job *models.ImportJob) error {
+	if job.ID == uuid.Nil {
+		job.ID = uuid.New()
+	}
+	for i := range job.Items {
+		if job.Items[i].ID == uuid.Nil {
+			job.Items[i].ID = uuid.New()
+		}
+		job.Items[i].ImportJobID = job.ID
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Items").Create(job).Error; err != nil {
+			return err
+		}
+		if len(job.Items) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(job.Items, 500).Error
+	})
+}
+
+func (r *importJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ImportJob, error) {
+	var job models.ImportJob
+	err := r.db.WithContext(ctx).
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("line ASC")
+		}).
+		Where("id = ?", id).
+		First(&job).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *importJobRepository) List(ctx context.Context, limit, offset int) ([]*models.ImportJob, int, error) {
+	var jobs []*models.ImportJob
+	var total int64
+
+	if err := r.db.WithContext(ctx).Model(&models.ImportJob{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := r.db.WithContext(ctx).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&jobs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return jobs, int(total), nil
+}
+
+// ListProcessing returns jobs that still have pending items, oldest first
+func (r *importJobRepository) ListProcessing(ctx context.Context) ([]*models.ImportJob, error) {
+	var jobs []*models.ImportJob
+	err := r.db.WithContext(ctx).
+		Where("status = ?", "processing").
+		Order("created_at ASC").
+		Find(&jobs).Error
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (r *importJobRepository) Update(ctx context.Context, job *models.ImportJob) error {
+	return r.db.WithContext(ctx).Omit("Items").Save(job).Error
+}
+
+func (r *importJobRepository) UpdateItem(ctx context.Context, item *models.ImportJobItem) error {
+	return r.db.WithContext(ctx).Save(item).Error
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+type PlaylistRepository interface {
+	Create(ctx context.Context, playlist *models.Playlist) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Playlist, error)
+	GetByYouTubePlaylistID(ctx context.Context, youtubePlaylistID string) (*models.Playlist, error)
+	List(ctx context.Context, limit, offset int) ([]*models.Playlist, int, error)
+	Update(ctx context.Context, playlist *models.Playlist) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	CreateItems(ctx context.Context, items []*models.PlaylistItem) error
+	UpdateItem(ctx context.Context, item *models.PlaylistItem) error
+}
+
+type playlistRepository struct {
+	db *gorm.DB
+}
+
+func NewPlaylistRepository(db *gorm.DB) PlaylistRepository {
+	return &playlistRepository{db: db}
+}
+
+func (r *playlistRepository) Create(ctx context.Context, playlist *models.Playlist) error {
+	if playlist.ID == uuid.Nil {
+		playlist.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Omit("Items").Create(playlist).Error
+}
+
+func (r *playlistRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Playlist, error) {
+	var playlist models.Playlist
+	err := r.db.WithContext(ctx).
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Preload("Items.Video").
+		Where("id = ?", id).
+		First(&playlist).Error
+	if err != nil {
+		return nil, err
+	}
+	return &playlist, nil
+}
+
+func (r *playlistRepository) GetByYouTubePlaylistID(ctx context.Context, youtubePlaylistID string) (*models.Playlist, error) {
+	var playlist models.Playlist
+	err := r.db.WithContext(ctx).
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Where("youtube_playlist_id = ?", youtubePlaylistID).
+		First(&playlist).Error
+	if err != nil {
+		return nil, err
+	}
+	return &playlist, nil
+}
+
+func (r *playlistRepository) List(ctx context.Context, limit, offset int) ([]*models.Playlist, int, error) {
+	var playlists []*models.Playlist
+	var total int64
+
+	if err := r.db.WithContext(ctx).Model(&models.Playlist{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := r.db.WithContext(ctx).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&playlists).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return playlists, int(total), nil
+}
+
+func (r *playlistRepository) Update(ctx context.Context, playlist *models.Playlist) error {
+	return r.db.WithContext(ctx).Omit("Items").Save(playlist).Error
+}
+
+func (r *playlistRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.Playlist{}, id).Error
+}
+
+func (r *playlistRepository) CreateItems(ctx context.Context, items []*models.PlaylistItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	for _, item := range items {
+		if item.ID == uuid.Nil {
+			item.ID = uuid.New()
+		}
+	}
+	return r.db.WithContext(ctx).Omit("Video").Create(&items).Error
+}
+
+func (r *playlistRepository) UpdateItem(ctx context.Context, item *models.PlaylistItem) error {
+	return r.db.WithContext(ctx).Omit("Video").Save(item).Error
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"html"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+// ts_headline marks matches with these private-use characters rather than <mark> directly, so
+// the segment text can be HTML-escaped before they are replaced by the real tags
+const (
+	snippetStart = "\ue000"
+	snippetStop  = "\ue001"
+)
+
+// snippetOptions configures ts_headline. Segments are short, so most snippets are the whole
+// segment with the matched words marked.
+const snippetOptions = "StartSel=" + snippetStart + ", StopSel=" + snippetStop + ", MinWords=15, MaxWords=35"
+
+// snippetMarks turns the match markers of an escaped snippet into <mark></mark>
+var snippetMarks = strings.NewReplacer(snippetStart, "<mark>", snippetStop, "</mark>")
+
+type SearchRepository interface {
+	// SearchTranscripts returns a page of the videos whose transcripts match, best match first,
+	// each with up to hitsPerVideo of its best hits in time order, and the number of matching
+	// videos
+	SearchTranscripts(ctx context.Context, query models.TranscriptSearchQuery, hitsPerVideo int) ([]*models.TranscriptSearchResult, int, error)
+}
+
+type searchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) SearchRepository {
+	return &searchRepository{db: db}
+}
+
+func (r *searchRepository) SearchTranscripts(ctx context.Context, query models.TranscriptSearchQuery, hitsPerVideo int) ([]*models.TranscriptSearchResult, int, error) {
+	var total int64
+	if err := r.matches(ctx, query).Distinct("s.video_id").Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []*models.TranscriptSearchResult{}, 0, nil
+	}
+
+	var ranked []struct {
+		VideoID  uuid.UUID
+		HitCount int
+	}
+	err := r.matches(ctx, query).
+		Select("s.video_id, COUNT(*) AS hit_count, SUM(ts_rank(s.tsv, websearch_to_tsquery('simple', ?))) AS score", query.Query).
+		Group("s.video_id").
+		Order("score DESC, s.video_id").
+		Limit(query.Limit).
+		Offset(query.Offset).
+		Scan(&ranked).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(ranked) == 0 {
+		return []*models.TranscriptSearchResult{}, int(total), nil
+	}
+
+	videoIDs := make([]uuid.UUID, len(ranked))
+	for i, row := range ranked {
+		videoIDs[i] = row.VideoID
+	}
+
+	best := r.matches(ctx, query).
+		Where("s.video_id IN ?", videoIDs).
+		Select(`s.video_id, s.transcript_id, s.language, s.start_time, s.end_time, s.text,
+			ROW_NUMBER() OVER (PARTITION BY s.video_id ORDER BY ts_rank(s.tsv, websearch_to_tsquery('simple', ?)) DESC, s.start_time) AS n`,
+			query.Query)
+	var hits []struct {
+		VideoID      uuid.UUID
+		TranscriptID uuid.UUID
+		Language     string
+		StartTime    float64
+		EndTime      float64
+		Snippet      string
+	}
+	err = r.db.WithContext(ctx).
+		Table("(?) AS m", best).
+		Select("m.video_id, m.transcript_id, m.language, m.start_time, m.end_time, ts_headline('simple', m.text, websearch_to_tsquery('simple', ?), ?) AS snippet",
+			query.Query, snippetOptions).
+		Where("m.n <= ?", hitsPerVideo).
+		Order("m.start_time").
+		Scan(&hits).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var videos []*models.Video
+	if err := r.db.WithContext(ctx).Where("id IN ?", videoIDs).Find(&videos).Error; err != nil {
+		return nil, 0, err
+	}
+
+	results := make(map[uuid.UUID]*models.TranscriptSearchResult, len(videos))
+	for _, video := range videos {
+		results[video.ID] = &models.TranscriptSearchResult{Video: video, Hits: []models.TranscriptSearchHit{}}
+	}
+	for _, hit := range hits {
+		if result, ok := results[hit.VideoID]; ok {
+			result.Hits = append(result.Hits, models.TranscriptSearchHit{
+				TranscriptID: hit.TranscriptID,
+				Language:     hit.Language,
+				Start:        hit.StartTime,
+				End:          hit.EndTime,
+				Snippet:      markSnippet(hit.Snippet),
+			})
+		}
+	}
+
+	page := make([]*models.TranscriptSearchResult, 0, len(ranked))
+	for _, row := range ranked {
+		if result, ok := results[row.VideoID]; ok {
+			result.HitCount = row.HitCount
+			page = append(page, result)
+		}
+	}
+	return page, int(total), nil
+}
+
+// markSnippet HTML-escapes a ts_headline snippet and marks its matched words with <mark></mark>
+func markSnippet(snippet string) string {
+	return snippetMarks.Replace(html.EscapeString(snippet))
+}
+
+// matches selects the indexed segments matching the query and its filters
+func (r *searchRepository) matches(ctx context.Context, query models.TranscriptSearchQuery) *gorm.DB {
+	db := r.db.WithContext(ctx).
+		Table("transcript_search_segments s").
+		Joins("JOIN transcripts t ON t.id = s.transcript_id").
+		Joins("JOIN videos v ON v.id = s.video_id").
+		Where("s.tsv @@ websearch_to_tsquery('simple', ?)", query.Query)
+	if query.Language != "" {
+		db = db.Where("s.language = ?", query.Language)
+	} else {
+		db = db.Where("t.is_primary")
+	}
+	if query.ChannelID != "" {
+		db = db.Where("v.channel_id = ?", query.ChannelID)
+	}
+	if query.PublishedAfter != nil {
+		db = db.Where("v.published_at >= ?", *query.PublishedAfter)
+	}
+	if query.PublishedBefore != nil {
+		db = db.Where("v.published_at < ?", *query.PublishedBefore)
+	}
+	return db
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+func TestSearchRepository_SearchTranscripts(t *testing.T) {
+	db := setupTestDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+	require.NoError(t, runMigrations(db, zap.NewNop()))
+
+	ctx := context.Background()
+	video := &models.Video{
+		ID:          uuid.New(),
+		YouTubeID:   "search_" + uuid.NewString()[:8],
+		Title:       "Designing APIs",
+		ChannelID:   "search_channel",
+		ChannelName: "Search Channel",
+		PublishedAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Status:      "completed",
+	}
+	require.NoError(t, NewVideoRepository(db).Create(ctx, video))
+	defer db.Delete(video)
+
+	transcripts := NewTranscriptRepository(db)
+	transcript := &models.Transcript{
+		VideoID:  video.ID,
+		Language: "en",
+		Source:   "youtube",
+		Content:  "Welcome back. Today we add a rate limiter. The rate limiter drops requests.",
+		Segments: models.TranscriptSegments{
+			{Start: 0, End: 2, Text: "Welcome back."},
+			{Start: 2, End: 5, Text: "Today we add a rate limiter."},
+			{Start: 5, End: 9, Text: "The rate limiter drops requests."},
+			{Start: 9, End: 12, Text: "Never paste <script>alert(1)</script> into a sanitizer."},
+		},
+	}
+	require.NoError(t, transcripts.Create(ctx, transcript))
+
+	repo := NewSearchRepository(db)
+	results, total, err := repo.SearchTranscripts(ctx, models.TranscriptSearchQuery{Query: `"rate limiter"`, ChannelID: "search_channel", Limit: 10}, 3)
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	assert.Equal(t, video.ID, results[0].Video.ID)
+	assert.Equal(t, 2, results[0].HitCount)
+	require.Len(t, results[0].Hits, 2)
+	assert.Equal(t, 2.0, results[0].Hits[0].Start)
+	assert.Contains(t, results[0].Hits[0].Snippet, "<mark>rate</mark> <mark>limiter</mark>")
+
+	// Segment text is escaped; only the match markers are HTML
+	results, _, err = repo.SearchTranscripts(ctx, models.TranscriptSearchQuery{Query: "sanitizer", ChannelID: "search_channel", Limit: 10}, 3)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Len(t, results[0].Hits, 1)
+	assert.Equal(t, "Never paste &lt;script&gt;alert(1)&lt;/script&gt; into a <mark>sanitizer</mark>.", results[0].Hits[0].Snippet)
+
+	// Saving the transcript again re-indexes its segments
+	transcript.Segments = transcript.Segments[:1]
+	require.NoError(t, transcripts.Update(ctx, transcript))
+	_, total, err = repo.SearchTranscripts(ctx, models.TranscriptSearchQuery{Query: "limiter", ChannelID: "search_channel", Limit: 10}, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+}
+
+func TestMarkSnippet(t *testing.T) {
+	snippet := "<script>alert('x')</script> & " + snippetStart + "rate" + snippetStop + " " + snippetStart + "limiter" + snippetStop
+	assert.Equal(t, "&lt;script&gt;alert(&#39;x&#39;)&lt;/script&gt; &amp; <mark>rate</mark> <mark>limiter</mark>", markSnippet(snippet))
+}
@@ -5,13 +5,26 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"youtube-video-summarizer/backend/internal/models"
 )
 
 type TranscriptRepository interface {
+	// Create stores a new transcript, making it primary when the video has none yet
 	Create(ctx context.Context, transcript *models.Transcript) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Transcript, error)
+	// GetByVideoID returns the primary transcript of a video
 	GetByVideoID(ctx context.Context, videoID uuid.UUID) (*models.Transcript, error)
+	// GetByLanguage returns a transcript of a video in a language, the primary one if it matches
+	GetByLanguage(ctx context.Context, videoID uuid.UUID, language string) (*models.Transcript, error)
+	GetByKey(ctx context.Context, videoID uuid.UUID, language, source string) (*models.Transcript, error)
+	// ListByVideoID returns every transcript of a video, the primary one first
+	ListByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.Transcript, error)
+	// SetPrimary makes a transcript the primary one of its video
+	SetPrimary(ctx context.Context, videoID, id uuid.UUID) error
 	Update(ctx context.Context, transcript *models.Transcript) error
+	// UpdateSpeakers saves the speaker names of a transcript, leaving its other columns as stored
+	UpdateSpeakers(ctx context.Context, id uuid.UUID, speakers models.SpeakerNames) error
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
@@ -27,15 +40,40 @@ func (r *transcriptRepository) Create(ctx context.Context, transcript *models.Tr
 	if transcript.ID == uuid.Nil {
 		transcript.ID = uuid.New()
 	}
-	// GORM will automatically handle JSONB serialization for []TranscriptSegment
-	return r.db.WithContext(ctx).Create(transcript).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the video so concurrent creates for it see each other's primary transcript
+		var video models.Video
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&video, "id = ?", transcript.VideoID).Error; err != nil {
+			return err
+		}
+		var primaries int64
+		if err := tx.Model(&models.Transcript{}).
+			Where("video_id = ? AND is_primary", transcript.VideoID).
+			Count(&primaries).Error; err != nil {
+			return err
+		}
+		transcript.IsPrimary = primaries == 0
+		// GORM will automatically handle JSONB serialization for []TranscriptSegment
+		if err := tx.Create(transcript).Error; err != nil {
+			return err
+		}
+		return indexSegments(tx, transcript)
+	})
+}
+
+func (r *transcriptRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Transcript, error) {
+	var transcript models.Transcript
+	if err := r.db.WithContext(ctx).First(&transcript, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &transcript, nil
 }
 
 func (r *transcriptRepository) GetByVideoID(ctx context.Context, videoID uuid.UUID) (*models.Transcript, error) {
 	var transcript models.Transcript
 	err := r.db.WithContext(ctx).
 		Where("video_id = ?", videoID).
-		Order("created_at DESC").
+		Order("is_primary DESC, created_at ASC").
 		First(&transcript).Error
 	if err != nil {
 		return nil, err
@@ -43,10 +81,98 @@ func (r *transcriptRepository) GetByVideoID(ctx context.Context, videoID uuid.UU
 	return &transcript, nil
 }
 
+func (r *transcriptRepository) GetByLanguage(ctx context.Context, videoID uuid.UUID, language string) (*models.Transcript, error) {
+	var transcript models.Transcript
+	err := r.db.WithContext(ctx).
+		Where("video_id = ? AND language = ?", videoID, language).
+		Order("is_primary DESC, created_at ASC").
+		First(&transcript).Error
+	if err != nil {
+		return nil, err
+	}
+	return &transcript, nil
+}
+
+func (r *transcriptRepository) GetByKey(ctx context.Context, videoID uuid.UUID, language, source string) (*models.Transcript, error) {
+	var transcript models.Transcript
+	err := r.db.WithContext(ctx).
+		Where("video_id = ? AND language = ? AND source = ?", videoID, language, source).
+		First(&transcript).Error
+	if err != nil {
+		return nil, err
+	}
+	return &transcript, nil
+}
+
+func (r *transcriptRepository) ListByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.Transcript, error) {
+	var transcripts []*models.Transcript
+	err := r.db.WithContext(ctx).
+		Where("video_id = ?", videoID).
+		Order("is_primary DESC, created_at ASC").
+		Find(&transcripts).Error
+	return transcripts, err
+}
+
+func (r *transcriptRepository) SetPrimary(ctx context.Context, videoID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Clear the old primary first: at most one may exist per video at any time
+		if err := tx.Model(&models.Transcript{}).
+			Where("video_id = ? AND is_primary AND id <> ?", videoID, id).
+			Update("is_primary", false).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&models.Transcript{}).
+			Where("id = ? AND video_id = ?", id, videoID).
+			Update("is_primary", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
 func (r *transcriptRepository) Update(ctx context.Context, transcript *models.Transcript) error {
-	return r.db.WithContext(ctx).Save(transcript).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(transcript).Error; err != nil {
+			return err
+		}
+		return indexSegments(tx, transcript)
+	})
+}
+
+func (r *transcriptRepository) UpdateSpeakers(ctx context.Context, id uuid.UUID, speakers models.SpeakerNames) error {
+	return r.db.WithContext(ctx).
+		Model(&models.Transcript{}).
+		Where("id = ?", id).
+		Update("speakers", speakers).Error
 }
 
 func (r *transcriptRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.db.WithContext(ctx).Delete(&models.Transcript{}, id).Error
 }
+
+// indexSegments replaces the search index rows of a transcript with its current segments
+func indexSegments(tx *gorm.DB, transcript *models.Transcript) error {
+	if err := tx.Where("transcript_id = ?", transcript.ID).Delete(&models.TranscriptSearchSegment{}).Error; err != nil {
+		return err
+	}
+	if len(transcript.Segments) == 0 {
+		return nil
+	}
+	rows := make([]models.TranscriptSearchSegment, len(transcript.Segments))
+	for i, seg := range transcript.Segments {
+		rows[i] = models.TranscriptSearchSegment{
+			TranscriptID: transcript.ID,
+			Position:     i,
+			VideoID:      transcript.VideoID,
+			Language:     transcript.Language,
+			Start:        seg.Start,
+			End:          seg.End,
+			Text:         seg.Text,
+		}
+	}
+	return tx.Omit(clause.Associations).CreateInBatches(rows, 500).Error
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+func TestTranscriptRepository_Create_OnePrimary(t *testing.T) {
+	db := setupTestDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+	require.NoError(t, runMigrations(db, zap.NewNop()))
+
+	ctx := context.Background()
+	video := &models.Video{
+		ID:          uuid.New(),
+		YouTubeID:   "primary_" + uuid.NewString()[:8],
+		Title:       "Concurrent transcripts",
+		PublishedAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Status:      "processing",
+	}
+	require.NoError(t, NewVideoRepository(db).Create(ctx, video))
+	defer db.Delete(video)
+
+	repo := NewTranscriptRepository(db)
+	languages := []string{"en", "de", "fr", "es"}
+	var wg sync.WaitGroup
+	for _, language := range languages {
+		wg.Add(1)
+		go func(language string) {
+			defer wg.Done()
+			assert.NoError(t, repo.Create(ctx, &models.Transcript{
+				VideoID:  video.ID,
+				Language: language,
+				Source:   "youtube",
+				Content:  "Hello",
+			}))
+		}(language)
+	}
+	wg.Wait()
+
+	transcripts, err := repo.ListByVideoID(ctx, video.ID)
+	require.NoError(t, err)
+	require.Len(t, transcripts, len(languages))
+	primaries := 0
+	for _, transcript := range transcripts {
+		if transcript.IsPrimary {
+			primaries++
+		}
+	}
+	assert.Equal(t, 1, primaries, "concurrent creates make exactly one transcript primary")
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+// translatedSource is the source of transcripts translated from another one
+const translatedSource = "translated"
+
+// ErrRevisionConflict is returned by Create when the transcript gained another revision
+// since the edit was based on it
+var ErrRevisionConflict = errors.New("transcript revision conflict")
+
+type TranscriptRevisionRepository interface {
+	// Create saves the segments and content of an edited transcript together with the revision
+	// recording the edit, and marks the summaries, embeddings and translations built from the
+	// transcript stale. Other columns, such as speaker names, are left as stored and read back
+	// into transcript. revision.Number must follow the transcript's last revision, else nothing
+	// is saved and ErrRevisionConflict is returned.
+	Create(ctx context.Context, transcript *models.Transcript, revision *models.TranscriptRevision) error
+	// ListByTranscriptID returns the revisions of a transcript, newest first
+	ListByTranscriptID(ctx context.Context, transcriptID uuid.UUID) ([]*models.TranscriptRevision, error)
+	Get(ctx context.Context, transcriptID uuid.UUID, number int) (*models.TranscriptRevision, error)
+	// LatestNumber returns the number of the transcript's last revision, 0 when never edited
+	LatestNumber(ctx context.Context, transcriptID uuid.UUID) (int, error)
+}
+
+type transcriptRevisionRepository struct {
+	db *gorm.DB
+}
+
+func NewTranscriptRevisionRepository(db *gorm.DB) TranscriptRevisionRepository {
+	return &transcriptRevisionRepository{db: db}
+}
+
+func (r *transcriptRevisionRepository) Create(ctx context.Context, transcript *models.Transcript, revision *models.TranscriptRevision) error {
+	if revision.ID == uuid.Nil {
+		revision.ID = uuid.New()
+	}
+	revision.TranscriptID = transcript.ID
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the transcript so concurrent edits are checked one after the other
+		var locked models.Transcript
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&locked, "id = ?", transcript.ID).Error; err != nil {
+			return err
+		}
+		last, err := latestNumber(tx, transcript.ID)
+		if err != nil {
+			return err
+		}
+		if revision.Number != last+1 {
+			return ErrRevisionConflict
+		}
+
+		if err := tx.Omit(clause.Associations).Create(revision).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(transcript).Select("segments", "content").Updates(transcript).Error; err != nil {
+			return err
+		}
+		if err := tx.First(transcript, "id = ?", transcript.ID).Error; err != nil {
+			return err
+		}
+		if err := indexSegments(tx, transcript); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.Summary{}).
+			Where("transcript_id = ? AND NOT stale", transcript.ID).
+			Update("stale", true).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.VideoEmbedding{}).
+			Where("transcript_id = ? AND NOT stale", transcript.ID).
+			Update("stale", true).Error; err != nil {
+			return err
+		}
+		// Translations made before their origin was recorded may come from this transcript too
+		return tx.Model(&models.Transcript{}).
+			Where("source = ? AND NOT stale AND id <> ?", translatedSource, transcript.ID).
+			Where("translated_from = ? OR (translated_from IS NULL AND video_id = ?)", transcript.ID, transcript.VideoID).
+			Update("stale", true).Error
+	})
+}
+
+func (r *transcriptRevisionRepository) ListByTranscriptID(ctx context.Context, transcriptID uuid.UUID) ([]*models.TranscriptRevision, error) {
+	var revisions []*models.TranscriptRevision
+	err := r.db.WithContext(ctx).
+		Where("transcript_id = ?", transcriptID).
+		Order("number DESC").
+		Find(&revisions).Error
+	return revisions, err
+}
+
+func (r *transcriptRevisionRepository) Get(ctx context.Context, transcriptID uuid.UUID, number int) (*models.TranscriptRevision, error) {
+	var revision models.TranscriptRevision
+	err := r.db.WithContext(ctx).
+		Where("transcript_id = ? AND number = ?", transcriptID, number).
+		First(&revision).Error
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+func (r *transcriptRevisionRepository) LatestNumber(ctx context.Context, transcriptID uuid.UUID) (int, error) {
+	return latestNumber(r.db.WithContext(ctx), transcriptID)
+}
+
+func latestNumber(db *gorm.DB, transcriptID uuid.UUID) (int, error) {
+	var last int
+	err := db.Model(&models.TranscriptRevision{}).
+		Where("transcript_id = ?", transcriptID).
+		Select("COALESCE(MAX(number), 0)").
+		Scan(&last).Error
+	return last, err
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+func TestTranscriptRevisionRepository_Create(t *testing.T) {
+	db := setupTestDB(t)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+	require.NoError(t, runMigrations(db, zap.NewNop()))
+
+	ctx := context.Background()
+	video := &models.Video{
+		ID:          uuid.New(),
+		YouTubeID:   "revision_" + uuid.NewString()[:8],
+		Title:       "Kubernetes in Production",
+		ChannelID:   "revision_channel",
+		ChannelName: "Revision Channel",
+		PublishedAt: time.Now(),
+		Status:      "completed",
+	}
+	require.NoError(t, NewVideoRepository(db).Create(ctx, video))
+	defer db.Delete(video)
+
+	transcript := &models.Transcript{
+		VideoID:  video.ID,
+		Language: "en",
+		Source:   "youtube",
+		Content:  "Welcome to cooper netties.",
+		Segments: models.TranscriptSegments{{Start: 0, End: 2, Text: "Welcome to cooper netties."}},
+	}
+	require.NoError(t, NewTranscriptRepository(db).Create(ctx, transcript))
+	summary := &models.Summary{VideoID: video.ID, TranscriptID: &transcript.ID, ModelUsed: "test", SummaryType: "short", Content: "About cooper netties."}
+	require.NoError(t, NewSummaryRepository(db).Create(ctx, summary))
+
+	translation := &models.Transcript{
+		VideoID:        video.ID,
+		Language:       "de",
+		Source:         "translated",
+		Content:        "Willkommen bei cooper netties.",
+		TranslatedFrom: &transcript.ID,
+	}
+	require.NoError(t, NewTranscriptRepository(db).Create(ctx, translation))
+	// Speakers named while the edit was being made are kept
+	require.NoError(t, NewTranscriptRepository(db).UpdateSpeakers(ctx, transcript.ID, models.SpeakerNames{"SPEAKER_00": "Host"}))
+
+	repo := NewTranscriptRevisionRepository(db)
+	transcript.Segments[0].Text = "Welcome to Kubernetes."
+	transcript.Content = "Welcome to Kubernetes."
+	revision := &models.TranscriptRevision{
+		Number:  1,
+		Author:  "editor",
+		Changes: models.SegmentChanges{{Position: 0, Before: "Welcome to cooper netties.", After: "Welcome to Kubernetes."}},
+	}
+	require.NoError(t, repo.Create(ctx, transcript, revision))
+
+	stored, err := NewTranscriptRepository(db).GetByID(ctx, transcript.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Welcome to Kubernetes.", stored.Segments[0].Text)
+	var stale bool
+	require.NoError(t, db.Model(&models.Summary{}).Select("stale").Where("id = ?", summary.ID).Scan(&stale).Error)
+	assert.True(t, stale, "summaries of the edited transcript are stale")
+	assert.Equal(t, models.SpeakerNames{"SPEAKER_00": "Host"}, stored.Speakers)
+	assert.Equal(t, models.SpeakerNames{"SPEAKER_00": "Host"}, transcript.Speakers, "the saved transcript is read back")
+	storedTranslation, err := NewTranscriptRepository(db).GetByID(ctx, translation.ID)
+	require.NoError(t, err)
+	assert.True(t, storedTranslation.Stale, "translations of the edited transcript are stale")
+
+	latest, err := repo.LatestNumber(ctx, transcript.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, latest)
+
+	// An edit based on the transcript before revision 1 is refused
+	err = repo.Create(ctx, transcript, &models.TranscriptRevision{Number: 1, Author: "other"})
+	assert.ErrorIs(t, err, ErrRevisionConflict)
+
+	revisions, err := repo.ListByTranscriptID(ctx, transcript.ID)
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	assert.Equal(t, "editor", revisions[0].Author)
+	assert.Equal(t, "Welcome to Kubernetes.", revisions[0].Changes[0].After)
+}
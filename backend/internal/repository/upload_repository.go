@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+type UploadRepository interface {
+	Create(ctx context.Context, upload *models.Upload) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Upload, error)
+	Update(ctx context.Context, upload *models.Upload) error
+}
+
+type uploadRepository struct {
+	db *gorm.DB
+}
+
+func NewUploadRepository(db *gorm.DB) UploadRepository {
+	return &uploadRepository{db: db}
+}
+
+func (r *uploadRepository) Create(ctx context.Context, upload *models.Upload) error {
+	if upload.ID == uuid.Nil {
+		upload.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(upload).Error
+}
+
+func (r *uploadRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Upload, error) {
+	var upload models.Upload
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&upload).Error
+	if err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+func (r *uploadRepository) Update(ctx context.Context, upload *models.Upload) error {
+	return r.db.WithContext(ctx).Save(upload).Error
+}
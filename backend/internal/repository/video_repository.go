@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -12,10 +13,17 @@ type VideoRepository interface {
 	Create(ctx context.Context, video *models.Video) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Video, error)
 	GetByYouTubeID(ctx context.Context, youtubeID string) (*models.Video, error)
+	GetBySourceID(ctx context.Context, sourceType, sourceID string) (*models.Video, error)
 	List(ctx context.Context, limit, offset int) ([]*models.Video, int, error)
 	Update(ctx context.Context, video *models.Video) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	UpdateStatus(ctx context.Context, id uuid.UUID, status string) error
+	// ListScheduledDue returns every scheduled video whose broadcast starts at or before the given
+	// time or whose start is unknown, earliest start first
+	ListScheduledDue(ctx context.Context, before time.Time) ([]*models.Video, error)
+	// UpdateBroadcast saves only the columns a broadcast check refreshes so concurrent edits to
+	// the rest of the video are not overwritten
+	UpdateBroadcast(ctx context.Context, video *models.Video) error
 }
 
 type videoRepository struct {
@@ -30,6 +38,9 @@ func (r *videoRepository) Create(ctx context.Context, video *models.Video) error
 	if video.ID == uuid.Nil {
 		video.ID = uuid.New()
 	}
+	if video.SourceID == "" && video.YouTubeID != "" {
+		video.SourceID = video.YouTubeID
+	}
 	return r.db.WithContext(ctx).Create(video).Error
 }
 
@@ -51,6 +62,17 @@ func (r *videoRepository) GetByYouTubeID(ctx context.Context, youtubeID string)
 	return &video, nil
 }
 
+func (r *videoRepository) GetBySourceID(ctx context.Context, sourceType, sourceID string) (*models.Video, error) {
+	var video models.Video
+	err := r.db.WithContext(ctx).
+		Where("source_type = ? AND source_id = ?", sourceType, sourceID).
+		First(&video).Error
+	if err != nil {
+		return nil, err
+	}
+	return &video, nil
+}
+
 func (r *videoRepository) List(ctx context.Context, limit, offset int) ([]*models.Video, int, error) {
 	var videos []*models.Video
 	var total int64
@@ -88,3 +110,20 @@ func (r *videoRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status
 		Where("id = ?", id).
 		Update("status", status).Error
 }
+
+func (r *videoRepository) ListScheduledDue(ctx context.Context, before time.Time) ([]*models.Video, error) {
+	var videos []*models.Video
+	err := r.db.WithContext(ctx).
+		Where("status = ?", models.VideoStatusScheduled).
+		Where("scheduled_start_at IS NULL OR scheduled_start_at <= ?", before).
+		Order("scheduled_start_at ASC NULLS FIRST, created_at ASC").
+		Find(&videos).Error
+	return videos, err
+}
+
+func (r *videoRepository) UpdateBroadcast(ctx context.Context, video *models.Video) error {
+	return r.db.WithContext(ctx).
+		Model(video).
+		Select("status", "live_broadcast_content", "scheduled_start_at", "title", "description", "duration", "thumbnail_url").
+		Updates(video).Error
+}
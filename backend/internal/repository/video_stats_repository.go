@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+type VideoStatsRepository interface {
+	CreateSnapshot(ctx context.Context, snapshot *models.VideoStatsSnapshot) error
+	ListSnapshots(ctx context.Context, videoID uuid.UUID, since time.Time) ([]*models.VideoStatsSnapshot, error)
+	CreateChange(ctx context.Context, change *models.VideoMetadataChange) error
+	ListChanges(ctx context.Context, videoID uuid.UUID) ([]*models.VideoMetadataChange, error)
+	// ListDueForRefresh returns YouTube videos whose last refresh attempt is older than before,
+	// oldest first. Deleted videos are skipped since they cannot come back.
+	ListDueForRefresh(ctx context.Context, before time.Time, limit int) ([]*models.Video, error)
+	// MarkRefreshAttempted records a failed refresh so the videos make way for others until the next interval
+	MarkRefreshAttempted(ctx context.Context, videoIDs []uuid.UUID, at time.Time) error
+	// UpdateVideoMetadata saves only the refreshed columns so a concurrent status update is not overwritten
+	UpdateVideoMetadata(ctx context.Context, video *models.Video) error
+}
+
+type videoStatsRepository struct {
+	db *gorm.DB
+}
+
+func NewVideoStatsRepository(db *gorm.DB) VideoStatsRepository {
+	return &videoStatsRepository{db: db}
+}
+
+func (r *videoStatsRepository) CreateSnapshot(ctx context.Context, snapshot *models.VideoStatsSnapshot) error {
+	if snapshot.ID == uuid.Nil {
+		snapshot.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(snapshot).Error
+}
+
+func (r *videoStatsRepository) ListSnapshots(ctx context.Context, videoID uuid.UUID, since time.Time) ([]*models.VideoStatsSnapshot, error) {
+	var snapshots []*models.VideoStatsSnapshot
+	err := r.db.WithContext(ctx).
+		Where("video_id = ? AND captured_at >= ?", videoID, since).
+		Order("captured_at ASC").
+		Find(&snapshots).Error
+	return snapshots, err
+}
+
+func (r *videoStatsRepository) CreateChange(ctx context.Context, change *models.VideoMetadataChange) error {
+	if change.ID == uuid.Nil {
+		change.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(change).Error
+}
+
+func (r *videoStatsRepository) ListChanges(ctx context.Context, videoID uuid.UUID) ([]*models.VideoMetadataChange, error) {
+	var changes []*models.VideoMetadataChange
+	err := r.db.WithContext(ctx).
+		Where("video_id = ?", videoID).
+		Order("detected_at DESC").
+		Find(&changes).Error
+	return changes, err
+}
+
+func (r *videoStatsRepository) ListDueForRefresh(ctx context.Context, before time.Time, limit int) ([]*models.Video, error) {
+	var videos []*models.Video
+	err := r.db.WithContext(ctx).
+		Where("source_type = ? AND youtube_id <> ''", models.SourceTypeYouTube).
+		Where("availability IS NULL OR availability <> ?", "deleted").
+		Where("COALESCE(stats_attempted_at, stats_updated_at) IS NULL OR COALESCE(stats_attempted_at, stats_updated_at) < ?", before).
+		Order("COALESCE(stats_attempted_at, stats_updated_at) ASC NULLS FIRST").
+		Limit(limit).
+		Find(&videos).Error
+	return videos, err
+}
+
+func (r *videoStatsRepository) UpdateVideoMetadata(ctx context.Context, video *models.Video) error {
+	return r.db.WithContext(ctx).
+		Model(video).
+		Select("title", "description", "view_count", "like_count", "availability", "stats_updated_at", "stats_attempted_at").
+		Updates(video).Error
+}
+
+func (r *videoStatsRepository) MarkRefreshAttempted(ctx context.Context, videoIDs []uuid.UUID, at time.Time) error {
+	if len(videoIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Model(&models.Video{}).
+		Where("id IN ?", videoIDs).
+		Update("stats_attempted_at", at).Error
+}
@@ -0,0 +1,148 @@
+package broadcast
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/internal/repository"
+	"youtube-video-summarizer/backend/pkg/youtube"
+)
+
+// batchSize matches the 50 IDs a single videos.list request accepts
+const batchSize = 50
+
+// YouTubeClient is the subset of youtube.Client used to follow broadcasts
+type YouTubeClient interface {
+	GetVideosInfo(ctx context.Context, videoIDs []string) (map[string]*youtube.VideoInfo, error)
+}
+
+// AnalysisQueue queues analysis for a video whose broadcast has ended
+type AnalysisQueue interface {
+	Enqueue(ctx context.Context, video *models.Video)
+}
+
+type Service struct {
+	videoRepo     repository.VideoRepository
+	youtubeClient YouTubeClient
+	analysisQueue AnalysisQueue
+	logger        *zap.Logger
+}
+
+func NewService(
+	videoRepo repository.VideoRepository,
+	youtubeClient YouTubeClient,
+	analysisQueue AnalysisQueue,
+	logger *zap.Logger,
+) *Service {
+	return &Service{
+		videoRepo:     videoRepo,
+		youtubeClient: youtubeClient,
+		analysisQueue: analysisQueue,
+		logger:        logger,
+	}
+}
+
+// CheckScheduled looks up the scheduled premieres and live streams that are due to have started
+// and queues the analysis of those that have ended and whose recording is available. Broadcasts
+// further ahead are left alone until their start so they cannot crowd out the due ones.
+func (s *Service) CheckScheduled(ctx context.Context) {
+	videos, err := s.videoRepo.ListScheduledDue(ctx, time.Now())
+	if err != nil {
+		s.logger.Error("Failed to list scheduled videos", zap.Error(err))
+		return
+	}
+
+	queued := 0
+	for start := 0; start < len(videos); start += batchSize {
+		if ctx.Err() != nil {
+			break
+		}
+		end := min(start+batchSize, len(videos))
+		queued += s.checkBatch(ctx, videos[start:end])
+	}
+
+	if queued > 0 {
+		s.logger.Info("Ended broadcasts queued for analysis",
+			zap.Int("scheduled", len(videos)),
+			zap.Int("queued", queued))
+	}
+}
+
+// checkBatch updates one videos.list worth of scheduled videos and returns how many were queued
+func (s *Service) checkBatch(ctx context.Context, videos []*models.Video) int {
+	youtubeIDs := make([]string, 0, len(videos))
+	for _, video := range videos {
+		youtubeIDs = append(youtubeIDs, video.YouTubeID)
+	}
+	// Bypass the cache: a cached response would hide the broadcast ending
+	infos, err := s.youtubeClient.GetVideosInfo(youtube.WithoutCache(ctx), youtubeIDs)
+	if err != nil {
+		s.logger.Warn("Failed to look up scheduled videos", zap.Int("videos", len(videos)), zap.Error(err))
+		return 0
+	}
+
+	queued := 0
+	for _, video := range videos {
+		if ctx.Err() != nil {
+			break
+		}
+		ready, err := s.apply(ctx, video, infos[video.YouTubeID])
+		if err != nil {
+			s.logger.Warn("Failed to update scheduled video",
+				zap.String("video_id", video.ID.String()),
+				zap.Error(err))
+			continue
+		}
+		if ready {
+			s.analysisQueue.Enqueue(ctx, video)
+			queued++
+		}
+	}
+	return queued
+}
+
+// apply records the current broadcast state of a video and reports whether it can be analyzed.
+// A broadcast is ready once YouTube no longer reports it as upcoming or live and the recording
+// has a duration; right after a stream ends the duration is still zero while it is processed.
+func (s *Service) apply(ctx context.Context, video *models.Video, info *youtube.VideoInfo) (bool, error) {
+	if info == nil {
+		// Cancelled premieres and streams that were never kept disappear from the API
+		s.logger.Info("Scheduled broadcast is no longer available", zap.String("video_id", video.ID.String()))
+		video.Status = "error"
+		return false, s.videoRepo.UpdateBroadcast(ctx, video)
+	}
+
+	switch info.LiveBroadcastContent {
+	case youtube.BroadcastUpcoming, youtube.BroadcastLive:
+		if info.LiveBroadcastContent == video.LiveBroadcastContent && sameTime(info.ScheduledStartTime, video.ScheduledStartAt) {
+			return false, nil
+		}
+		video.LiveBroadcastContent = info.LiveBroadcastContent
+		video.ScheduledStartAt = info.ScheduledStartTime
+		return false, s.videoRepo.UpdateBroadcast(ctx, video)
+	}
+
+	if info.Duration == 0 {
+		return false, nil
+	}
+
+	video.LiveBroadcastContent = youtube.BroadcastNone
+	video.Title = info.Title
+	video.Description = info.Description
+	video.Duration = info.Duration
+	video.ThumbnailURL = info.ThumbnailURL
+	video.Status = "pending"
+	if err := s.videoRepo.UpdateBroadcast(ctx, video); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
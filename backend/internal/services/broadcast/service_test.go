@@ -0,0 +1,176 @@
+package broadcast
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/pkg/youtube"
+)
+
+type MockVideoRepository struct {
+	mock.Mock
+}
+
+func (m *MockVideoRepository) Create(ctx context.Context, video *models.Video) error {
+	args := m.Called(ctx, video)
+	return args.Error(0)
+}
+
+func (m *MockVideoRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Video, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Video), args.Error(1)
+}
+
+func (m *MockVideoRepository) GetByYouTubeID(ctx context.Context, youtubeID string) (*models.Video, error) {
+	args := m.Called(ctx, youtubeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Video), args.Error(1)
+}
+
+func (m *MockVideoRepository) GetBySourceID(ctx context.Context, sourceType, sourceID string) (*models.Video, error) {
+	args := m.Called(ctx, sourceType, sourceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Video), args.Error(1)
+}
+
+func (m *MockVideoRepository) List(ctx context.Context, limit, offset int) ([]*models.Video, int, error) {
+	args := m.Called(ctx, limit, offset)
+	return args.Get(0).([]*models.Video), args.Int(1), args.Error(2)
+}
+
+func (m *MockVideoRepository) Update(ctx context.Context, video *models.Video) error {
+	args := m.Called(ctx, video)
+	return args.Error(0)
+}
+
+func (m *MockVideoRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVideoRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func (m *MockVideoRepository) ListScheduledDue(ctx context.Context, before time.Time) ([]*models.Video, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).([]*models.Video), args.Error(1)
+}
+
+func (m *MockVideoRepository) UpdateBroadcast(ctx context.Context, video *models.Video) error {
+	args := m.Called(ctx, video)
+	return args.Error(0)
+}
+
+type MockYouTubeClient struct {
+	mock.Mock
+}
+
+func (m *MockYouTubeClient) GetVideosInfo(ctx context.Context, videoIDs []string) (map[string]*youtube.VideoInfo, error) {
+	args := m.Called(ctx, videoIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]*youtube.VideoInfo), args.Error(1)
+}
+
+type MockAnalysisQueue struct {
+	mock.Mock
+}
+
+func (m *MockAnalysisQueue) Enqueue(ctx context.Context, video *models.Video) {
+	m.Called(ctx, video)
+}
+
+func TestService_CheckScheduled(t *testing.T) {
+	videoRepo := new(MockVideoRepository)
+	youtubeClient := new(MockYouTubeClient)
+	queue := new(MockAnalysisQueue)
+	service := NewService(videoRepo, youtubeClient, queue, zap.NewNop())
+	ctx := context.Background()
+
+	start := time.Date(2024, 6, 1, 18, 0, 0, 0, time.UTC)
+	later := start.Add(2 * time.Hour)
+
+	waiting := &models.Video{ID: uuid.New(), YouTubeID: "upcoming001", Status: models.VideoStatusScheduled, LiveBroadcastContent: "upcoming", ScheduledStartAt: &start}
+	moved := &models.Video{ID: uuid.New(), YouTubeID: "upcoming002", Status: models.VideoStatusScheduled, LiveBroadcastContent: "upcoming", ScheduledStartAt: &start}
+	processing := &models.Video{ID: uuid.New(), YouTubeID: "processing1", Status: models.VideoStatusScheduled, LiveBroadcastContent: "live"}
+	ended := &models.Video{ID: uuid.New(), YouTubeID: "ended000001", Status: models.VideoStatusScheduled, LiveBroadcastContent: "live", Title: "Live now!"}
+	cancelled := &models.Video{ID: uuid.New(), YouTubeID: "cancelled01", Status: models.VideoStatusScheduled, LiveBroadcastContent: "upcoming"}
+
+	videoRepo.On("ListScheduledDue", ctx, mock.AnythingOfType("time.Time")).
+		Return([]*models.Video{waiting, moved, processing, ended, cancelled}, nil)
+	youtubeClient.On("GetVideosInfo", youtube.WithoutCache(ctx), []string{"upcoming001", "upcoming002", "processing1", "ended000001", "cancelled01"}).
+		Return(map[string]*youtube.VideoInfo{
+			"upcoming001": {LiveBroadcastContent: "upcoming", ScheduledStartTime: &start},
+			"upcoming002": {LiveBroadcastContent: "upcoming", ScheduledStartTime: &later},
+			"processing1": {LiveBroadcastContent: "none", Duration: 0},
+			"ended000001": {LiveBroadcastContent: "none", Duration: 5400, Title: "Stream replay"},
+		}, nil)
+	videoRepo.On("UpdateBroadcast", ctx, mock.Anything).Return(nil)
+	queue.On("Enqueue", ctx, ended).Return()
+
+	service.CheckScheduled(ctx)
+
+	assert.Equal(t, later, *moved.ScheduledStartAt, "a rescheduled premiere keeps waiting with the new start")
+	assert.Equal(t, models.VideoStatusScheduled, moved.Status)
+	assert.Equal(t, models.VideoStatusScheduled, processing.Status, "the recording is not available while its duration is unknown")
+
+	assert.Equal(t, "pending", ended.Status)
+	assert.Equal(t, "none", ended.LiveBroadcastContent)
+	assert.Equal(t, 5400, ended.Duration)
+	assert.Equal(t, "Stream replay", ended.Title)
+
+	assert.Equal(t, "error", cancelled.Status)
+
+	videoRepo.AssertNumberOfCalls(t, "UpdateBroadcast", 3)
+	queue.AssertNumberOfCalls(t, "Enqueue", 1)
+	queue.AssertExpectations(t)
+}
+
+func TestService_CheckScheduled_LooksUpEveryDueVideo(t *testing.T) {
+	videoRepo := new(MockVideoRepository)
+	youtubeClient := new(MockYouTubeClient)
+	queue := new(MockAnalysisQueue)
+	service := NewService(videoRepo, youtubeClient, queue, zap.NewNop())
+	ctx := context.Background()
+
+	videos := make([]*models.Video, batchSize+2)
+	infos := make(map[string]*youtube.VideoInfo, len(videos))
+	for i := range videos {
+		youtubeID := fmt.Sprintf("upcoming%03d", i)
+		videos[i] = &models.Video{ID: uuid.New(), YouTubeID: youtubeID, Status: models.VideoStatusScheduled, LiveBroadcastContent: "upcoming"}
+		infos[youtubeID] = &youtube.VideoInfo{LiveBroadcastContent: "upcoming"}
+	}
+	last := videos[len(videos)-1]
+	infos[last.YouTubeID] = &youtube.VideoInfo{LiveBroadcastContent: "none", Duration: 600}
+
+	videoRepo.On("ListScheduledDue", ctx, mock.AnythingOfType("time.Time")).Return(videos, nil)
+	youtubeClient.On("GetVideosInfo", youtube.WithoutCache(ctx), mock.Anything).Return(infos, nil)
+	videoRepo.On("UpdateBroadcast", ctx, last).Return(nil)
+	queue.On("Enqueue", ctx, last).Return()
+
+	service.CheckScheduled(ctx)
+
+	youtubeClient.AssertNumberOfCalls(t, "GetVideosInfo", 2)
+	for _, call := range youtubeClient.Calls {
+		assert.LessOrEqual(t, len(call.Arguments.Get(1).([]string)), batchSize)
+	}
+	assert.Equal(t, "pending", last.Status, "a broadcast past the first batch is still picked up")
+	queue.AssertExpectations(t)
+}
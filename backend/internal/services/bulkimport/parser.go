@@ -0,0 +1,90 @@
+package bulkimport
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// headerNames are first-row column names treated as a CSV header rather than an entry
+var headerNames = map[string]bool{
+	"url":        true,
+	"urls":       true,
+	"link":       true,
+	"video":      true,
+	"video_id":   true,
+	"video_url":  true,
+	"youtube_id": true,
+	"id":         true,
+}
+
+// Entry is a single candidate URL/ID from an import list
+type Entry struct {
+	Line  int
+	Input string
+}
+
+// ParseList reads a newline- or CSV-formatted list. For each record the first non-empty field that
+// looks like a URL or ID is used, so spreadsheets with extra columns (title, notes) import as-is.
+// Blank lines, lines starting with '#' and a header row are ignored.
+func ParseList(content string, isVideoRef func(string) bool) ([]Entry, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var entries []Entry
+	first := true
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		var fields []string
+		for _, field := range record {
+			if field = strings.TrimSpace(field); field != "" {
+				fields = append(fields, field)
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		input := ""
+		for _, field := range fields {
+			if isVideoRef(field) {
+				input = field
+				break
+			}
+		}
+
+		if input == "" {
+			if first && isHeader(fields) {
+				first = false
+				continue
+			}
+			// Keep the raw line so the item reports what was rejected
+			input = strings.Join(fields, ",")
+		}
+
+		first = false
+		entries = append(entries, Entry{Line: line, Input: input})
+	}
+
+	return entries, nil
+}
+
+func isHeader(fields []string) bool {
+	for _, field := range fields {
+		if headerNames[strings.ToLower(field)] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,285 @@
+package bulkimport
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/internal/repository"
+	"youtube-video-summarizer/backend/pkg/errors"
+	"youtube-video-summarizer/backend/pkg/youtube"
+)
+
+// maxImportItems caps how many entries a single import may contain
+const maxImportItems = 1000
+
+// VideoCreator creates videos from a YouTube URL or ID
+type VideoCreator interface {
+	CreateFromURL(ctx context.Context, url string) (*models.Video, error)
+}
+
+// AnalysisQueue queues analysis for a newly created video
+type AnalysisQueue interface {
+	Enqueue(ctx context.Context, video *models.Video)
+}
+
+type Service struct {
+	importJobRepo repository.ImportJobRepository
+	videoRepo     repository.VideoRepository
+	videoCreator  VideoCreator
+	analysisQueue AnalysisQueue
+	youtubeClient *youtube.Client
+	logger        *zap.Logger
+
+	// Background processing: at most one run per job, cancelled by Stop
+	processCtx  context.Context
+	stopProcess context.CancelFunc
+	processMu   sync.Mutex
+	processing  map[uuid.UUID]struct{}
+	processWG   sync.WaitGroup
+}
+
+func NewService(
+	importJobRepo repository.ImportJobRepository,
+	videoRepo repository.VideoRepository,
+	videoCreator VideoCreator,
+	analysisQueue AnalysisQueue,
+	youtubeClient *youtube.Client,
+	logger *zap.Logger,
+) *Service {
+	processCtx, stopProcess := context.WithCancel(context.Background())
+	return &Service{
+		importJobRepo: importJobRepo,
+		videoRepo:     videoRepo,
+		videoCreator:  videoCreator,
+		analysisQueue: analysisQueue,
+		youtubeClient: youtubeClient,
+		logger:        logger,
+		processCtx:    processCtx,
+		stopProcess:   stopProcess,
+		processing:    make(map[uuid.UUID]struct{}),
+	}
+}
+
+// CreateJob parses and validates an import list and saves it as a job. Invalid entries and videos
+// that already exist (or repeat earlier lines) are resolved immediately; the rest stay pending
+// until Process (usually via StartProcess) creates them.
+func (s *Service) CreateJob(ctx context.Context, content string) (*models.ImportJob, error) {
+	entries, err := ParseList(content, s.isVideoRef)
+	if err != nil {
+		return nil, errors.NewWithError(errors.ErrorCodeBadRequest, errors.SubCodeInvalidFormat, "Failed to parse import list", err)
+	}
+	if len(entries) == 0 {
+		return nil, errors.New(errors.ErrorCodeBadRequest, errors.SubCodeMissingParameter, "Import list contains no URLs or video IDs")
+	}
+	if len(entries) > maxImportItems {
+		return nil, errors.NewWithDetail(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Import list is too large",
+			fmt.Sprintf("%d entries exceeds the maximum of %d per import", len(entries), maxImportItems),
+		)
+	}
+
+	job := &models.ImportJob{
+		Status: "processing",
+		Total:  len(entries),
+		Items:  make([]models.ImportJobItem, 0, len(entries)),
+	}
+
+	seen := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		item := models.ImportJobItem{
+			Line:   entry.Line,
+			Input:  entry.Input,
+			Status: "pending",
+		}
+
+		youtubeID, err := s.youtubeClient.ExtractVideoID(entry.Input)
+		switch {
+		case err != nil:
+			item.Status = "invalid"
+			item.Error = "not a valid YouTube URL or video ID"
+			job.Invalid++
+		case seen[youtubeID] != 0:
+			item.YouTubeID = youtubeID
+			item.Status = "duplicate"
+			item.Error = fmt.Sprintf("duplicate of line %d", seen[youtubeID])
+			job.SkippedDuplicate++
+		default:
+			item.YouTubeID = youtubeID
+			seen[youtubeID] = entry.Line
+
+			existing, err := s.videoRepo.GetByYouTubeID(ctx, youtubeID)
+			if err != nil && err != gorm.ErrRecordNotFound {
+				return nil, errors.ErrDatabaseError("get video", err)
+			}
+			if existing != nil {
+				item.VideoID = &existing.ID
+				item.Status = "duplicate"
+				item.Error = "video already exists"
+				job.SkippedDuplicate++
+			}
+		}
+
+		job.Items = append(job.Items, item)
+	}
+
+	if job.Invalid+job.SkippedDuplicate == job.Total {
+		s.complete(job)
+	}
+
+	if err := s.importJobRepo.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to save import job: %w", err)
+	}
+
+	s.logger.Info("Import job created",
+		zap.String("import_job_id", job.ID.String()),
+		zap.Int("total", job.Total),
+		zap.Int("invalid", job.Invalid),
+		zap.Int("skipped_duplicate", job.SkippedDuplicate),
+	)
+
+	return job, nil
+}
+
+// StartProcess runs Process for a job in the background. It does nothing if the job is
+// already being processed.
+func (s *Service) StartProcess(id uuid.UUID) {
+	s.processMu.Lock()
+	if _, running := s.processing[id]; running {
+		s.processMu.Unlock()
+		return
+	}
+	s.processing[id] = struct{}{}
+	s.processWG.Add(1)
+	s.processMu.Unlock()
+
+	go func() {
+		defer s.processWG.Done()
+		defer func() {
+			s.processMu.Lock()
+			delete(s.processing, id)
+			s.processMu.Unlock()
+		}()
+
+		if err := s.Process(s.processCtx, id); err != nil {
+			s.logger.Error("Import job failed", zap.String("import_job_id", id.String()), zap.Error(err))
+		}
+	}()
+}
+
+// Resume restarts processing of jobs left unfinished by a previous run, e.g. because the
+// server was stopped while they were in progress
+func (s *Service) Resume(ctx context.Context) error {
+	jobs, err := s.importJobRepo.ListProcessing(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list unfinished import jobs: %w", err)
+	}
+
+	for _, job := range jobs {
+		s.logger.Info("Resuming import job", zap.String("import_job_id", job.ID.String()))
+		s.StartProcess(job.ID)
+	}
+	return nil
+}
+
+// Stop cancels background processing and waits for it to return. Items that were not
+// reached stay pending and are picked up by Resume on the next start.
+func (s *Service) Stop() {
+	s.stopProcess()
+	s.processWG.Wait()
+}
+
+// Process creates a video for every pending item of the job and queues its analysis
+func (s *Service) Process(ctx context.Context, id uuid.UUID) error {
+	job, err := s.importJobRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get import job: %w", err)
+	}
+
+	for i := range job.Items {
+		item := &job.Items[i]
+		if item.Status != "pending" {
+			continue
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		// Pass the original input so URL details such as the start time are kept
+		video, err := s.videoCreator.CreateFromURL(ctx, item.Input)
+		if err != nil && ctx.Err() != nil {
+			// Interrupted, not failed: leave the item pending
+			return ctx.Err()
+		}
+		if err != nil {
+			item.Status = "failed"
+			item.Error = err.Error()
+			job.Failed++
+		} else {
+			item.VideoID = &video.ID
+			item.Status = "created"
+			job.Created++
+			if video.Status == "pending" {
+				s.analysisQueue.Enqueue(ctx, video)
+			}
+		}
+
+		if err := s.importJobRepo.UpdateItem(ctx, item); err != nil {
+			s.logger.Warn("Failed to update import item",
+				zap.String("item_id", item.ID.String()),
+				zap.Error(err),
+			)
+		}
+		// Persist counts as we go so the status endpoint shows progress
+		if err := s.importJobRepo.Update(ctx, job); err != nil {
+			s.logger.Warn("Failed to update import job", zap.String("import_job_id", id.String()), zap.Error(err))
+		}
+	}
+
+	s.complete(job)
+	if err := s.importJobRepo.Update(ctx, job); err != nil {
+		return fmt.Errorf("failed to update import job: %w", err)
+	}
+
+	s.logger.Info("Import job finished",
+		zap.String("import_job_id", id.String()),
+		zap.Int("created", job.Created),
+		zap.Int("failed", job.Failed),
+	)
+
+	return nil
+}
+
+func (s *Service) GetByID(ctx context.Context, id uuid.UUID) (*models.ImportJob, error) {
+	job, err := s.importJobRepo.GetByID(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrImportJobNotFound(id.String())
+		}
+		return nil, err
+	}
+	return job, nil
+}
+
+func (s *Service) List(ctx context.Context, limit, offset int) ([]*models.ImportJob, int, error) {
+	return s.importJobRepo.List(ctx, limit, offset)
+}
+
+func (s *Service) complete(job *models.ImportJob) {
+	now := time.Now()
+	job.Status = "completed"
+	job.CompletedAt = &now
+}
+
+func (s *Service) isVideoRef(value string) bool {
+	_, err := s.youtubeClient.ExtractVideoID(value)
+	return err == nil
+}
@@ -0,0 +1,310 @@
+package bulkimport
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/pkg/youtube"
+)
+
+type MockImportJobRepository struct {
+	mock.Mock
+}
+
+func (m *MockImportJobRepository) Create(ctx context.Context, job *models.ImportJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockImportJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ImportJob, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ImportJob), args.Error(1)
+}
+
+func (m *MockImportJobRepository) List(ctx context.Context, limit, offset int) ([]*models.ImportJob, int, error) {
+	args := m.Called(ctx, limit, offset)
+	return args.Get(0).([]*models.ImportJob), args.Int(1), args.Error(2)
+}
+
+func (m *MockImportJobRepository) ListProcessing(ctx context.Context) ([]*models.ImportJob, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*models.ImportJob), args.Error(1)
+}
+
+func (m *MockImportJobRepository) Update(ctx context.Context, job *models.ImportJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockImportJobRepository) UpdateItem(ctx context.Context, item *models.ImportJobItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+type MockVideoRepository struct {
+	mock.Mock
+}
+
+func (m *MockVideoRepository) Create(ctx context.Context, video *models.Video) error {
+	args := m.Called(ctx, video)
+	return args.Error(0)
+}
+
+func (m *MockVideoRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Video, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Video), args.Error(1)
+}
+
+func (m *MockVideoRepository) GetByYouTubeID(ctx context.Context, youtubeID string) (*models.Video, error) {
+	args := m.Called(ctx, youtubeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Video), args.Error(1)
+}
+
+func (m *MockVideoRepository) GetBySourceID(ctx context.Context, sourceType, sourceID string) (*models.Video, error) {
+	args := m.Called(ctx, sourceType, sourceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Video), args.Error(1)
+}
+
+func (m *MockVideoRepository) List(ctx context.Context, limit, offset int) ([]*models.Video, int, error) {
+	args := m.Called(ctx, limit, offset)
+	return args.Get(0).([]*models.Video), args.Int(1), args.Error(2)
+}
+
+func (m *MockVideoRepository) Update(ctx context.Context, video *models.Video) error {
+	args := m.Called(ctx, video)
+	return args.Error(0)
+}
+
+func (m *MockVideoRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVideoRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func (m *MockVideoRepository) ListScheduledDue(ctx context.Context, before time.Time) ([]*models.Video, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).([]*models.Video), args.Error(1)
+}
+
+func (m *MockVideoRepository) UpdateBroadcast(ctx context.Context, video *models.Video) error {
+	args := m.Called(ctx, video)
+	return args.Error(0)
+}
+
+type MockVideoCreator struct {
+	mock.Mock
+}
+
+func (m *MockVideoCreator) CreateFromURL(ctx context.Context, url string) (*models.Video, error) {
+	args := m.Called(ctx, url)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Video), args.Error(1)
+}
+
+type MockAnalysisQueue struct {
+	mock.Mock
+}
+
+func (m *MockAnalysisQueue) Enqueue(ctx context.Context, video *models.Video) {
+	m.Called(ctx, video)
+}
+
+func TestParseList(t *testing.T) {
+	isRef := func(s string) bool {
+		_, err := youtube.NewClient("").ExtractVideoID(s)
+		return err == nil
+	}
+
+	t.Run("newline separated", func(t *testing.T) {
+		content := "https://youtu.be/dQw4w9WgXcQ\n\n# comment\ndQw4w9WgXcR\nnot a url\n"
+		entries, err := ParseList(content, isRef)
+		require.NoError(t, err)
+		assert.Equal(t, []Entry{
+			{Line: 1, Input: "https://youtu.be/dQw4w9WgXcQ"},
+			{Line: 4, Input: "dQw4w9WgXcR"},
+			{Line: 5, Input: "not a url"},
+		}, entries)
+	})
+
+	t.Run("csv with header and extra columns", func(t *testing.T) {
+		content := "title,url\n\"Intro, part 1\",https://www.youtube.com/watch?v=dQw4w9WgXcQ\nOutro,\n"
+		entries, err := ParseList(content, isRef)
+		require.NoError(t, err)
+		assert.Equal(t, []Entry{
+			{Line: 2, Input: "https://www.youtube.com/watch?v=dQw4w9WgXcQ"},
+			{Line: 3, Input: "Outro"},
+		}, entries)
+	})
+}
+
+func TestService_CreateJob(t *testing.T) {
+	mockJobRepo := new(MockImportJobRepository)
+	mockVideoRepo := new(MockVideoRepository)
+	service := NewService(mockJobRepo, mockVideoRepo, nil, nil, youtube.NewClient(""), zap.NewNop())
+
+	ctx := context.Background()
+	existing := &models.Video{ID: uuid.New(), YouTubeID: "existing001"}
+
+	mockVideoRepo.On("GetByYouTubeID", ctx, "newvideo001").Return(nil, gorm.ErrRecordNotFound)
+	mockVideoRepo.On("GetByYouTubeID", ctx, "existing001").Return(existing, nil)
+	mockJobRepo.On("Create", ctx, mock.AnythingOfType("*models.ImportJob")).Return(nil)
+
+	content := "newvideo001\nhttps://youtu.be/newvideo001\nexisting001\nhttps://example.com/video\n"
+	job, err := service.CreateJob(ctx, content)
+	require.NoError(t, err)
+
+	assert.Equal(t, "processing", job.Status)
+	assert.Equal(t, 4, job.Total)
+	assert.Equal(t, 2, job.SkippedDuplicate)
+	assert.Equal(t, 1, job.Invalid)
+	require.Len(t, job.Items, 4)
+	assert.Equal(t, "pending", job.Items[0].Status)
+	assert.Equal(t, "duplicate", job.Items[1].Status)
+	assert.Equal(t, "duplicate of line 1", job.Items[1].Error)
+	assert.Equal(t, "duplicate", job.Items[2].Status)
+	assert.Equal(t, existing.ID, *job.Items[2].VideoID)
+	assert.Equal(t, "invalid", job.Items[3].Status)
+
+	mockVideoRepo.AssertExpectations(t)
+	mockJobRepo.AssertExpectations(t)
+}
+
+func TestService_Process(t *testing.T) {
+	mockJobRepo := new(MockImportJobRepository)
+	mockCreator := new(MockVideoCreator)
+	mockQueue := new(MockAnalysisQueue)
+	service := NewService(mockJobRepo, nil, mockCreator, mockQueue, youtube.NewClient(""), zap.NewNop())
+
+	ctx := context.Background()
+	jobID := uuid.New()
+	job := &models.ImportJob{
+		ID:     jobID,
+		Status: "processing",
+		Total:  3,
+		Items: []models.ImportJobItem{
+			{ID: uuid.New(), Line: 1, Input: "https://youtu.be/newvideo001?t=30", YouTubeID: "newvideo001", Status: "pending"},
+			{ID: uuid.New(), Line: 2, Input: "missing0002", YouTubeID: "missing0002", Status: "pending"},
+			{ID: uuid.New(), Line: 3, Input: "bogus", Status: "invalid"},
+		},
+		Invalid: 1,
+	}
+
+	video := &models.Video{ID: uuid.New(), YouTubeID: "newvideo001", Status: "pending"}
+
+	mockJobRepo.On("GetByID", ctx, jobID).Return(job, nil)
+	mockCreator.On("CreateFromURL", ctx, "https://youtu.be/newvideo001?t=30").Return(video, nil)
+	mockCreator.On("CreateFromURL", ctx, "missing0002").Return(nil, fmt.Errorf("video not found"))
+	mockQueue.On("Enqueue", ctx, video).Return().Once()
+	mockJobRepo.On("UpdateItem", ctx, mock.AnythingOfType("*models.ImportJobItem")).Return(nil)
+	mockJobRepo.On("Update", ctx, job).Return(nil)
+
+	err := service.Process(ctx, jobID)
+	require.NoError(t, err)
+
+	assert.Equal(t, "completed", job.Status)
+	assert.NotNil(t, job.CompletedAt)
+	assert.Equal(t, 1, job.Created)
+	assert.Equal(t, 1, job.Failed)
+	assert.Equal(t, "created", job.Items[0].Status)
+	assert.Equal(t, "failed", job.Items[1].Status)
+	assert.Equal(t, "video not found", job.Items[1].Error)
+
+	mockCreator.AssertExpectations(t)
+	mockQueue.AssertExpectations(t)
+}
+
+func TestService_Process_LeavesInterruptedItemsPending(t *testing.T) {
+	mockJobRepo := new(MockImportJobRepository)
+	mockCreator := new(MockVideoCreator)
+	service := NewService(mockJobRepo, nil, mockCreator, new(MockAnalysisQueue), youtube.NewClient(""), zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	jobID := uuid.New()
+	job := &models.ImportJob{
+		ID:     jobID,
+		Status: "processing",
+		Total:  2,
+		Items: []models.ImportJobItem{
+			{ID: uuid.New(), Line: 1, Input: "newvideo001", YouTubeID: "newvideo001", Status: "pending"},
+			{ID: uuid.New(), Line: 2, Input: "newvideo002", YouTubeID: "newvideo002", Status: "pending"},
+		},
+	}
+
+	mockJobRepo.On("GetByID", ctx, jobID).Return(job, nil)
+	// Shutting down while the first video is being created
+	mockCreator.On("CreateFromURL", ctx, "newvideo001").
+		Run(func(mock.Arguments) { cancel() }).
+		Return(nil, context.Canceled)
+
+	err := service.Process(ctx, jobID)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	assert.Equal(t, "processing", job.Status)
+	assert.Equal(t, 0, job.Failed)
+	assert.Equal(t, "pending", job.Items[0].Status)
+	assert.Equal(t, "pending", job.Items[1].Status)
+	mockJobRepo.AssertNotCalled(t, "UpdateItem", mock.Anything, mock.Anything)
+	mockJobRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	mockCreator.AssertNumberOfCalls(t, "CreateFromURL", 1)
+}
+
+func TestService_Resume(t *testing.T) {
+	mockJobRepo := new(MockImportJobRepository)
+	mockCreator := new(MockVideoCreator)
+	service := NewService(mockJobRepo, nil, mockCreator, new(MockAnalysisQueue), youtube.NewClient(""), zap.NewNop())
+
+	jobID := uuid.New()
+	job := &models.ImportJob{
+		ID:     jobID,
+		Status: "processing",
+		Total:  1,
+		Items: []models.ImportJobItem{
+			{ID: uuid.New(), Line: 1, Input: "missing0001", YouTubeID: "missing0001", Status: "pending"},
+		},
+	}
+
+	mockJobRepo.On("ListProcessing", mock.Anything).Return([]*models.ImportJob{job}, nil)
+	mockJobRepo.On("GetByID", mock.Anything, jobID).Return(job, nil)
+	mockCreator.On("CreateFromURL", mock.Anything, "missing0001").Return(nil, fmt.Errorf("video not found"))
+	mockJobRepo.On("UpdateItem", mock.Anything, mock.AnythingOfType("*models.ImportJobItem")).Return(nil)
+	mockJobRepo.On("Update", mock.Anything, job).Return(nil)
+
+	require.NoError(t, service.Resume(context.Background()))
+	require.Eventually(t, func() bool {
+		service.processMu.Lock()
+		defer service.processMu.Unlock()
+		return len(service.processing) == 0
+	}, time.Second, 10*time.Millisecond)
+	service.Stop()
+
+	assert.Equal(t, "completed", job.Status)
+	assert.Equal(t, 1, job.Failed)
+	mockJobRepo.AssertExpectations(t)
+}
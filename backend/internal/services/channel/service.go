@@ -0,0 +1,404 @@
+package channel
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/internal/repository"
+	"youtube-video-summarizer/backend/pkg/errors"
+	"youtube-video-summarizer/backend/pkg/youtube"
+)
+
+// pollBatchSize is how many of the most recent uploads are inspected per check (one playlistItems page)
+const pollBatchSize = 50
+
+// maxUploadAttempts is how many checks an upload may fail in before it is skipped, so that one
+// upload that can never be ingested (private, members-only, removed) does not hold back the rest
+const maxUploadAttempts = 3
+
+// validSummaryTypes are the summary types accepted for auto-summarization
+var validSummaryTypes = map[string]bool{
+	"":              true,
+	"short":         true,
+	"detailed":      true,
+	"bullet_points": true,
+}
+
+// VideoCreator creates (or returns existing) videos from a YouTube URL or ID
+type VideoCreator interface {
+	CreateFromURL(ctx context.Context, url string) (*models.Video, error)
+}
+
+// AnalysisQueue queues analysis for a newly created video
+type AnalysisQueue interface {
+	Enqueue(ctx context.Context, video *models.Video)
+}
+
+// YouTubeClient is the subset of youtube.Client used to resolve channels and list their uploads
+type YouTubeClient interface {
+	ExtractChannelRef(channelURL string) (string, error)
+	GetChannelInfo(ctx context.Context, channelRef string) (*youtube.ChannelInfo, error)
+	GetPlaylistItems(ctx context.Context, playlistID string, maxItems int) ([]youtube.PlaylistItem, error)
+	GetVideoInfo(ctx context.Context, videoID string) (*youtube.VideoInfo, error)
+}
+
+type TranscriptProvider interface {
+	GetOrCreateTranscript(ctx context.Context, videoID uuid.UUID, languageCode ...string) (*models.Transcript, error)
+}
+
+type SummaryGenerator interface {
+	GenerateSummary(ctx context.Context, videoID uuid.UUID, transcript *models.Transcript, summaryType string, language string) (*models.Summary, error)
+}
+
+// Filters control which new uploads of a channel are ingested
+type Filters struct {
+	MinDuration       int    `json:"min_duration"`
+	TitleKeyword      string `json:"title_keyword"`
+	AutoSummarizeType string `json:"auto_summarize_type"`
+}
+
+// SubscriptionUpdate holds the optional fields of a subscription update
+type SubscriptionUpdate struct {
+	Enabled           *bool   `json:"enabled"`
+	MinDuration       *int    `json:"min_duration"`
+	TitleKeyword      *string `json:"title_keyword"`
+	AutoSummarizeType *string `json:"auto_summarize_type"`
+}
+
+type Service struct {
+	subscriptionRepo  repository.ChannelSubscriptionRepository
+	videoCreator      VideoCreator
+	analysisQueue     AnalysisQueue
+	transcriptService TranscriptProvider
+	summaryService    SummaryGenerator
+	youtubeClient     YouTubeClient
+	logger            *zap.Logger
+}
+
+func NewService(
+	subscriptionRepo repository.ChannelSubscriptionRepository,
+	videoCreator VideoCreator,
+	analysisQueue AnalysisQueue,
+	transcriptService TranscriptProvider,
+	summaryService SummaryGenerator,
+	youtubeClient YouTubeClient,
+	logger *zap.Logger,
+) *Service {
+	return &Service{
+		subscriptionRepo:  subscriptionRepo,
+		videoCreator:      videoCreator,
+		analysisQueue:     analysisQueue,
+		transcriptService: transcriptService,
+		summaryService:    summaryService,
+		youtubeClient:     youtubeClient,
+		logger:            logger,
+	}
+}
+
+// Subscribe resolves a channel URL, ID or @handle and starts watching its uploads.
+// Only videos published after the subscription is created are ingested.
+func (s *Service) Subscribe(ctx context.Context, channelURL string, filters Filters) (*models.ChannelSubscription, error) {
+	if err := validateFilters(filters.MinDuration, filters.AutoSummarizeType); err != nil {
+		return nil, err
+	}
+
+	channelRef, err := s.youtubeClient.ExtractChannelRef(channelURL)
+	if err != nil {
+		return nil, errors.ErrChannelInvalidURL(channelURL)
+	}
+
+	info, err := s.youtubeClient.GetChannelInfo(ctx, channelRef)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrorCodeYouTubeAPI, errors.SubCodeYouTubeAPIFailed, "Failed to fetch channel info")
+	}
+
+	existing, err := s.subscriptionRepo.GetByChannelID(ctx, info.ID)
+	if err == nil && existing != nil {
+		return nil, errors.ErrChannelAlreadySubscribed(info.ID)
+	}
+
+	now := time.Now()
+	subscription := &models.ChannelSubscription{
+		ChannelID:         info.ID,
+		ChannelName:       info.Title,
+		ThumbnailURL:      info.ThumbnailURL,
+		UploadsPlaylistID: info.UploadsPlaylistID,
+		Enabled:           true,
+		MinDuration:       filters.MinDuration,
+		TitleKeyword:      strings.TrimSpace(filters.TitleKeyword),
+		AutoSummarizeType: filters.AutoSummarizeType,
+		LastPublishedAt:   &now,
+	}
+
+	if err := s.subscriptionRepo.Create(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to save channel subscription: %w", err)
+	}
+
+	s.logger.Info("Channel subscription created",
+		zap.String("subscription_id", subscription.ID.String()),
+		zap.String("channel_id", subscription.ChannelID),
+	)
+
+	return subscription, nil
+}
+
+func (s *Service) GetByID(ctx context.Context, id uuid.UUID) (*models.ChannelSubscription, error) {
+	subscription, err := s.subscriptionRepo.GetByID(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrChannelSubscriptionNotFound(id.String())
+		}
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (s *Service) List(ctx context.Context, limit, offset int) ([]*models.ChannelSubscription, int, error) {
+	return s.subscriptionRepo.List(ctx, limit, offset)
+}
+
+func (s *Service) Update(ctx context.Context, id uuid.UUID, update SubscriptionUpdate) (*models.ChannelSubscription, error) {
+	subscription, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Enabled != nil {
+		subscription.Enabled = *update.Enabled
+	}
+	if update.MinDuration != nil {
+		subscription.MinDuration = *update.MinDuration
+	}
+	if update.TitleKeyword != nil {
+		subscription.TitleKeyword = strings.TrimSpace(*update.TitleKeyword)
+	}
+	if update.AutoSummarizeType != nil {
+		subscription.AutoSummarizeType = *update.AutoSummarizeType
+	}
+
+	if err := validateFilters(subscription.MinDuration, subscription.AutoSummarizeType); err != nil {
+		return nil, err
+	}
+
+	if err := s.subscriptionRepo.Update(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to update channel subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.subscriptionRepo.Delete(ctx, id)
+}
+
+// CheckAll checks every enabled subscription for new uploads
+func (s *Service) CheckAll(ctx context.Context) {
+	subscriptions, err := s.subscriptionRepo.ListEnabled(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list channel subscriptions", zap.Error(err))
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		if ctx.Err() != nil {
+			return
+		}
+		if _, err := s.CheckSubscription(ctx, subscription); err != nil {
+			s.logger.Warn("Channel check failed",
+				zap.String("channel_id", subscription.ChannelID),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// CheckSubscription fetches the channel's most recent uploads and ingests the ones published since
+// the last check that pass the subscription filters. It returns the number of videos ingested.
+func (s *Service) CheckSubscription(ctx context.Context, subscription *models.ChannelSubscription) (int, error) {
+	now := time.Now()
+	subscription.LastCheckedAt = &now
+
+	items, err := s.youtubeClient.GetPlaylistItems(ctx, subscription.UploadsPlaylistID, pollBatchSize)
+	if err != nil {
+		subscription.LastError = err.Error()
+		s.saveSubscription(ctx, subscription)
+		return 0, fmt.Errorf("failed to fetch uploads: %w", err)
+	}
+
+	ingested, newest := s.ingestUploads(ctx, subscription, items)
+
+	if newest != nil {
+		subscription.LastPublishedAt = newest
+	}
+	subscription.VideosIngested += ingested
+	s.saveSubscription(ctx, subscription)
+
+	if ingested > 0 {
+		s.logger.Info("Channel uploads ingested",
+			zap.String("channel_id", subscription.ChannelID),
+			zap.Int("videos", ingested),
+		)
+	}
+
+	return ingested, nil
+}
+
+// ingestUploads creates and queues unseen uploads, oldest first. It returns the number of videos
+// ingested and the publish time of the newest upload that was ingested or filtered out, which becomes
+// the subscription's new watermark. It stops at the first upload that fails so the next check retries it.
+func (s *Service) ingestUploads(ctx context.Context, subscription *models.ChannelSubscription, items []youtube.PlaylistItem) (int, *time.Time) {
+	var newest *time.Time
+	ingested := 0
+	subscription.LastError = ""
+
+	// Uploads playlists are ordered newest first
+	for i := len(items) - 1; i >= 0; i-- {
+		item := items[i]
+
+		// Scheduled, private and deleted videos have no publish time yet
+		if item.PublishedAt.IsZero() {
+			continue
+		}
+		if subscription.LastPublishedAt != nil && !item.PublishedAt.After(*subscription.LastPublishedAt) {
+			continue
+		}
+
+		created, err := s.ingestUpload(ctx, subscription, item)
+		if err != nil {
+			if subscription.FailingVideoID == item.VideoID {
+				subscription.FailedAttempts++
+			} else {
+				subscription.FailingVideoID = item.VideoID
+				subscription.FailedAttempts = 1
+			}
+			if subscription.FailedAttempts < maxUploadAttempts {
+				subscription.LastError = fmt.Sprintf("%s: %v", item.VideoID, err)
+				// Keep the watermark below the failed upload, even when an upload published at the same time went through
+				if newest != nil && !newest.Before(item.PublishedAt) {
+					before := item.PublishedAt.Add(-time.Nanosecond)
+					newest = &before
+				}
+				break
+			}
+
+			s.logger.Warn("Skipping channel upload that keeps failing",
+				zap.String("channel_id", subscription.ChannelID),
+				zap.String("youtube_id", item.VideoID),
+				zap.Int("attempts", subscription.FailedAttempts),
+				zap.Error(err))
+			subscription.LastError = fmt.Sprintf("%s: skipped after %d failed attempts: %v", item.VideoID, subscription.FailedAttempts, err)
+			created = false
+		}
+		if subscription.FailingVideoID == item.VideoID {
+			subscription.FailingVideoID = ""
+			subscription.FailedAttempts = 0
+		}
+
+		publishedAt := item.PublishedAt
+		newest = &publishedAt
+		if created {
+			ingested++
+		}
+	}
+
+	return ingested, newest
+}
+
+// ingestUpload creates and queues one upload unless the subscription's filters exclude it.
+// It reports whether a video was created.
+func (s *Service) ingestUpload(ctx context.Context, subscription *models.ChannelSubscription, item youtube.PlaylistItem) (bool, error) {
+	if !matchesKeyword(subscription.TitleKeyword, item.Title) {
+		return false, nil
+	}
+
+	if subscription.MinDuration > 0 {
+		info, err := s.youtubeClient.GetVideoInfo(ctx, item.VideoID)
+		if err != nil {
+			s.logger.Warn("Failed to fetch upload info",
+				zap.String("youtube_id", item.VideoID),
+				zap.Error(err),
+			)
+			return false, err
+		}
+		if info.Duration < subscription.MinDuration {
+			return false, nil
+		}
+	}
+
+	video, err := s.videoCreator.CreateFromURL(ctx, item.VideoID)
+	if err != nil {
+		s.logger.Warn("Failed to create video from channel upload",
+			zap.String("channel_id", subscription.ChannelID),
+			zap.String("youtube_id", item.VideoID),
+			zap.Error(err),
+		)
+		return false, err
+	}
+
+	// Only queue videos that haven't been analyzed yet
+	if video.Status == "pending" {
+		s.queue(video, subscription.AutoSummarizeType)
+	}
+	return true, nil
+}
+
+// queue sends a video through the analysis pipeline. With auto-summarize enabled the transcript and
+// summary are produced first, so the analysis reuses the stored transcript instead of fetching it twice.
+func (s *Service) queue(video *models.Video, summaryType string) {
+	if summaryType == "" {
+		s.analysisQueue.Enqueue(context.Background(), video)
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+
+		transcript, err := s.transcriptService.GetOrCreateTranscript(ctx, video.ID)
+		if err != nil {
+			s.logger.Warn("Auto-summarize failed to get transcript",
+				zap.String("video_id", video.ID.String()),
+				zap.Error(err),
+			)
+		} else if _, err := s.summaryService.GenerateSummary(ctx, video.ID, transcript, summaryType, "auto"); err != nil {
+			s.logger.Warn("Auto-summarize failed",
+				zap.String("video_id", video.ID.String()),
+				zap.Error(err),
+			)
+		}
+
+		s.analysisQueue.Enqueue(context.Background(), video)
+	}()
+}
+
+func (s *Service) saveSubscription(ctx context.Context, subscription *models.ChannelSubscription) {
+	if err := s.subscriptionRepo.Update(ctx, subscription); err != nil {
+		s.logger.Warn("Failed to update channel subscription",
+			zap.String("subscription_id", subscription.ID.String()),
+			zap.Error(err),
+		)
+	}
+}
+
+func matchesKeyword(keyword, title string) bool {
+	if keyword == "" {
+		return true
+	}
+	return strings.Contains(strings.ToLower(title), strings.ToLower(keyword))
+}
+
+func validateFilters(minDuration int, summaryType string) error {
+	if minDuration < 0 {
+		return errors.New(errors.ErrorCodeBadRequest, errors.SubCodeInvalidInput, "min_duration must not be negative")
+	}
+	if !validSummaryTypes[summaryType] {
+		return errors.New(errors.ErrorCodeBadRequest, errors.SubCodeSummaryInvalidType, "auto_summarize_type must be one of short, detailed, bullet_points")
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+package channel
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/pkg/youtube"
+)
+
+type MockSubscriptionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSubscriptionRepository) Create(ctx context.Context, subscription *models.ChannelSubscription) error {
+	args := m.Called(ctx, subscription)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ChannelSubscription, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ChannelSubscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) GetByChannelID(ctx context.Context, channelID string) (*models.ChannelSubscription, error) {
+	args := m.Called(ctx, channelID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ChannelSubscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) List(ctx context.Context, limit, offset int) ([]*models.ChannelSubscription, int, error) {
+	args := m.Called(ctx, limit, offset)
+	return args.Get(0).([]*models.ChannelSubscription), args.Int(1), args.Error(2)
+}
+
+func (m *MockSubscriptionRepository) ListEnabled(ctx context.Context) ([]*models.ChannelSubscription, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*models.ChannelSubscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) Update(ctx context.Context, subscription *models.ChannelSubscription) error {
+	args := m.Called(ctx, subscription)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockYouTubeClient struct {
+	mock.Mock
+}
+
+func (m *MockYouTubeClient) ExtractChannelRef(channelURL string) (string, error) {
+	args := m.Called(channelURL)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockYouTubeClient) GetChannelInfo(ctx context.Context, channelRef string) (*youtube.ChannelInfo, error) {
+	args := m.Called(ctx, channelRef)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*youtube.ChannelInfo), args.Error(1)
+}
+
+func (m *MockYouTubeClient) GetPlaylistItems(ctx context.Context, playlistID string, maxItems int) ([]youtube.PlaylistItem, error) {
+	args := m.Called(ctx, playlistID, maxItems)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]youtube.PlaylistItem), args.Error(1)
+}
+
+func (m *MockYouTubeClient) GetVideoInfo(ctx context.Context, videoID string) (*youtube.VideoInfo, error) {
+	args := m.Called(ctx, videoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*youtube.VideoInfo), args.Error(1)
+}
+
+type MockVideoCreator struct {
+	mock.Mock
+}
+
+func (m *MockVideoCreator) CreateFromURL(ctx context.Context, url string) (*models.Video, error) {
+	args := m.Called(ctx, url)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Video), args.Error(1)
+}
+
+type MockAnalysisQueue struct {
+	mock.Mock
+}
+
+func (m *MockAnalysisQueue) Enqueue(ctx context.Context, video *models.Video) {
+	m.Called(ctx, video)
+}
+
+func TestService_CheckSubscription(t *testing.T) {
+	mockRepo := new(MockSubscriptionRepository)
+	mockClient := new(MockYouTubeClient)
+	mockCreator := new(MockVideoCreator)
+	mockQueue := new(MockAnalysisQueue)
+	service := NewService(mockRepo, mockCreator, mockQueue, nil, nil, mockClient, zap.NewNop())
+
+	ctx := context.Background()
+	watermark := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	subscription := &models.ChannelSubscription{
+		ID:                uuid.New(),
+		ChannelID:         "UC_x5XG1OV2P6uZZ5FSM9Ttw",
+		UploadsPlaylistID: "UU_x5XG1OV2P6uZZ5FSM9Ttw",
+		Enabled:           true,
+		MinDuration:       300,
+		TitleKeyword:      "tutorial",
+		LastPublishedAt:   &watermark,
+	}
+
+	// Newest first, as returned for uploads playlists
+	items := []youtube.PlaylistItem{
+		{VideoID: "scheduled01", Title: "Tutorial premiere"},
+		{VideoID: "shortvid002", Title: "Quick tutorial", PublishedAt: watermark.Add(3 * time.Hour)},
+		{VideoID: "keeper00003", Title: "Go Tutorial: generics", PublishedAt: watermark.Add(2 * time.Hour)},
+		{VideoID: "offtopic004", Title: "Channel update", PublishedAt: watermark.Add(1 * time.Hour)},
+		{VideoID: "oldvideo005", Title: "Old tutorial", PublishedAt: watermark.Add(-1 * time.Hour)},
+	}
+
+	newVideo := &models.Video{ID: uuid.New(), YouTubeID: "keeper00003", Status: "pending"}
+
+	mockClient.On("GetPlaylistItems", ctx, "UU_x5XG1OV2P6uZZ5FSM9Ttw", pollBatchSize).Return(items, nil)
+	mockClient.On("GetVideoInfo", ctx, "keeper00003").Return(&youtube.VideoInfo{ID: "keeper00003", Duration: 900}, nil)
+	mockClient.On("GetVideoInfo", ctx, "shortvid002").Return(&youtube.VideoInfo{ID: "shortvid002", Duration: 45}, nil)
+	mockCreator.On("CreateFromURL", ctx, "keeper00003").Return(newVideo, nil).Once()
+	mockQueue.On("Enqueue", mock.Anything, newVideo).Return().Once()
+	mockRepo.On("Update", ctx, subscription).Return(nil)
+
+	ingested, err := service.CheckSubscription(ctx, subscription)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, ingested)
+	assert.Equal(t, 1, subscription.VideosIngested)
+	assert.Equal(t, watermark.Add(3*time.Hour), *subscription.LastPublishedAt)
+	assert.NotNil(t, subscription.LastCheckedAt)
+	assert.Empty(t, subscription.LastError)
+
+	mockClient.AssertExpectations(t)
+	mockCreator.AssertExpectations(t)
+	mockQueue.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_CheckSubscription_RetriesFailedUpload(t *testing.T) {
+	mockRepo := new(MockSubscriptionRepository)
+	mockClient := new(MockYouTubeClient)
+	mockCreator := new(MockVideoCreator)
+	mockQueue := new(MockAnalysisQueue)
+	service := NewService(mockRepo, mockCreator, mockQueue, nil, nil, mockClient, zap.NewNop())
+
+	ctx := context.Background()
+	watermark := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	subscription := &models.ChannelSubscription{
+		ID:                uuid.New(),
+		ChannelID:         "UC_x5XG1OV2P6uZZ5FSM9Ttw",
+		UploadsPlaylistID: "UU_x5XG1OV2P6uZZ5FSM9Ttw",
+		Enabled:           true,
+		LastPublishedAt:   &watermark,
+	}
+	items := []youtube.PlaylistItem{
+		{VideoID: "newest00003", Title: "Part 3", PublishedAt: watermark.Add(3 * time.Hour)},
+		{VideoID: "flaky000002", Title: "Part 2", PublishedAt: watermark.Add(2 * time.Hour)},
+		{VideoID: "first000001", Title: "Part 1", PublishedAt: watermark.Add(1 * time.Hour)},
+	}
+	videos := map[string]*models.Video{}
+	for _, item := range items {
+		videos[item.VideoID] = &models.Video{ID: uuid.New(), YouTubeID: item.VideoID, Status: "pending"}
+	}
+
+	mockClient.On("GetPlaylistItems", ctx, "UU_x5XG1OV2P6uZZ5FSM9Ttw", pollBatchSize).Return(items, nil)
+	mockCreator.On("CreateFromURL", ctx, "first000001").Return(videos["first000001"], nil).Once()
+	mockCreator.On("CreateFromURL", ctx, "flaky000002").Return(nil, fmt.Errorf("connection reset")).Once()
+	mockQueue.On("Enqueue", mock.Anything, mock.Anything).Return()
+	mockRepo.On("Update", ctx, subscription).Return(nil)
+
+	ingested, err := service.CheckSubscription(ctx, subscription)
+	require.NoError(t, err)
+	assert.Equal(t, 1, ingested)
+	assert.Equal(t, watermark.Add(1*time.Hour), *subscription.LastPublishedAt, "the watermark stops before the failed upload")
+	assert.Contains(t, subscription.LastError, "flaky000002")
+
+	// The next check picks up where the failure left off
+	mockCreator.On("CreateFromURL", ctx, "flaky000002").Return(videos["flaky000002"], nil).Once()
+	mockCreator.On("CreateFromURL", ctx, "newest00003").Return(videos["newest00003"], nil).Once()
+
+	ingested, err = service.CheckSubscription(ctx, subscription)
+	require.NoError(t, err)
+	assert.Equal(t, 2, ingested)
+	assert.Equal(t, watermark.Add(3*time.Hour), *subscription.LastPublishedAt)
+	assert.Empty(t, subscription.LastError)
+	mockCreator.AssertExpectations(t)
+}
+
+func TestService_CheckSubscription_SkipsUploadThatKeepsFailing(t *testing.T) {
+	mockRepo := new(MockSubscriptionRepository)
+	mockClient := new(MockYouTubeClient)
+	mockCreator := new(MockVideoCreator)
+	mockQueue := new(MockAnalysisQueue)
+	service := NewService(mockRepo, mockCreator, mockQueue, nil, nil, mockClient, zap.NewNop())
+
+	ctx := context.Background()
+	watermark := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	subscription := &models.ChannelSubscription{
+		ID:                uuid.New(),
+		ChannelID:         "UC_x5XG1OV2P6uZZ5FSM9Ttw",
+		UploadsPlaylistID: "UU_x5XG1OV2P6uZZ5FSM9Ttw",
+		Enabled:           true,
+		LastPublishedAt:   &watermark,
+	}
+	items := []youtube.PlaylistItem{
+		{VideoID: "newest00002", Title: "Part 2", PublishedAt: watermark.Add(2 * time.Hour)},
+		{VideoID: "members0001", Title: "Members only", PublishedAt: watermark.Add(1 * time.Hour)},
+	}
+	newest := &models.Video{ID: uuid.New(), YouTubeID: "newest00002", Status: "pending"}
+
+	mockClient.On("GetPlaylistItems", ctx, "UU_x5XG1OV2P6uZZ5FSM9Ttw", pollBatchSize).Return(items, nil)
+	mockCreator.On("CreateFromURL", ctx, "members0001").Return(nil, fmt.Errorf("video is members-only"))
+	mockCreator.On("CreateFromURL", ctx, "newest00002").Return(newest, nil).Once()
+	mockQueue.On("Enqueue", mock.Anything, mock.Anything).Return()
+	mockRepo.On("Update", ctx, subscription).Return(nil)
+
+	for attempt := 1; attempt < maxUploadAttempts; attempt++ {
+		ingested, err := service.CheckSubscription(ctx, subscription)
+		require.NoError(t, err)
+		assert.Zero(t, ingested)
+		assert.Equal(t, watermark, *subscription.LastPublishedAt, "the failing upload is retried")
+		assert.Equal(t, attempt, subscription.FailedAttempts)
+	}
+
+	ingested, err := service.CheckSubscription(ctx, subscription)
+	require.NoError(t, err)
+	assert.Equal(t, 1, ingested, "the uploads after the skipped one are ingested")
+	assert.Equal(t, watermark.Add(2*time.Hour), *subscription.LastPublishedAt)
+	assert.Contains(t, subscription.LastError, "members0001: skipped after 3 failed attempts")
+	assert.Empty(t, subscription.FailingVideoID)
+	assert.Zero(t, subscription.FailedAttempts)
+	mockCreator.AssertNumberOfCalls(t, "CreateFromURL", maxUploadAttempts+1)
+}
+
+func TestMatchesKeyword(t *testing.T) {
+	assert.True(t, matchesKeyword("", "anything"))
+	assert.True(t, matchesKeyword("tutorial", "Go Tutorial: generics"))
+	assert.False(t, matchesKeyword("tutorial", "Channel update"))
+}
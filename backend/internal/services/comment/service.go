@@ -0,0 +1,288 @@
+package comment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/internal/repository"
+	"youtube-video-summarizer/backend/pkg/errors"
+	"youtube-video-summarizer/backend/pkg/llm"
+	"youtube-video-summarizer/backend/pkg/prompts"
+	"youtube-video-summarizer/backend/pkg/youtube"
+)
+
+const (
+	// maxFetchedComments caps one ingestion at two commentThreads pages (2 quota units)
+	maxFetchedComments = 200
+	// summaryCommentLimit is how many of the most liked comments are sent to the LLM
+	summaryCommentLimit = 100
+	// maxCommentLength truncates long comments in the prompt
+	maxCommentLength = 500
+)
+
+// YouTubeClient is the subset of youtube.Client used to fetch comments
+type YouTubeClient interface {
+	GetTopComments(ctx context.Context, videoID string, maxComments int) ([]youtube.Comment, error)
+}
+
+// LLMProviderFactory returns the LLM configured for an operation
+type LLMProviderFactory interface {
+	GetLLMProvider(ctx context.Context, operation string) (llm.LLMProvider, error)
+}
+
+// UsageRecorder records token usage, implemented by cost.Service
+type UsageRecorder interface {
+	RecordUsage(ctx context.Context, videoID uuid.UUID, operation, provider, model string, inputTokens, outputTokens int) error
+}
+
+type Service struct {
+	videoRepo       repository.VideoRepository
+	commentRepo     repository.CommentRepository
+	youtubeClient   YouTubeClient
+	providerFactory LLMProviderFactory
+	costService     UsageRecorder
+	logger          *zap.Logger
+}
+
+func NewService(
+	videoRepo repository.VideoRepository,
+	commentRepo repository.CommentRepository,
+	youtubeClient YouTubeClient,
+	providerFactory LLMProviderFactory,
+	costService UsageRecorder,
+	logger *zap.Logger,
+) *Service {
+	return &Service{
+		videoRepo:       videoRepo,
+		commentRepo:     commentRepo,
+		youtubeClient:   youtubeClient,
+		providerFactory: providerFactory,
+		costService:     costService,
+		logger:          logger,
+	}
+}
+
+// Ingest fetches the top comments of a YouTube video and replaces the stored ones
+func (s *Service) Ingest(ctx context.Context, videoID uuid.UUID) ([]*models.Comment, error) {
+	video, err := s.getYouTubeVideo(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	return s.ingest(ctx, video)
+}
+
+// ListComments returns the most liked stored comments, ingesting them on first use
+func (s *Service) ListComments(ctx context.Context, videoID uuid.UUID, limit int) ([]*models.Comment, error) {
+	comments, err := s.commentRepo.ListTop(ctx, videoID, limit)
+	if err != nil {
+		return nil, errors.ErrDatabaseError("list comments", err)
+	}
+	if len(comments) > 0 {
+		return comments, nil
+	}
+
+	if _, err := s.Ingest(ctx, videoID); err != nil {
+		return nil, err
+	}
+	comments, err = s.commentRepo.ListTop(ctx, videoID, limit)
+	if err != nil {
+		return nil, errors.ErrDatabaseError("list comments", err)
+	}
+	return comments, nil
+}
+
+// GetSummary returns the latest audience-reaction summary of a video in language. It is generated
+// when none exists yet in that language or when refresh is set, in which case the comments are
+// fetched again too.
+func (s *Service) GetSummary(ctx context.Context, videoID uuid.UUID, language string, refresh bool) (*models.CommentSummary, error) {
+	if language == "" {
+		language = "auto"
+	}
+	if !refresh {
+		summary, err := s.commentRepo.GetLatestSummary(ctx, videoID, language)
+		if err == nil {
+			return summary, nil
+		}
+		if err != gorm.ErrRecordNotFound {
+			return nil, errors.ErrDatabaseError("get comment summary", err)
+		}
+	}
+
+	video, err := s.getYouTubeVideo(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	var comments []*models.Comment
+	if !refresh {
+		comments, err = s.commentRepo.ListTop(ctx, videoID, summaryCommentLimit)
+		if err != nil {
+			return nil, errors.ErrDatabaseError("list comments", err)
+		}
+	}
+	if len(comments) == 0 {
+		if _, err := s.ingest(ctx, video); err != nil {
+			return nil, err
+		}
+		comments, err = s.commentRepo.ListTop(ctx, videoID, summaryCommentLimit)
+		if err != nil {
+			return nil, errors.ErrDatabaseError("list comments", err)
+		}
+	}
+	if len(comments) == 0 {
+		return nil, errors.ErrCommentsNotFound(videoID.String())
+	}
+
+	return s.summarize(ctx, video, comments, language)
+}
+
+func (s *Service) ingest(ctx context.Context, video *models.Video) ([]*models.Comment, error) {
+	fetched, err := s.youtubeClient.GetTopComments(ctx, video.YouTubeID, maxFetchedComments)
+	if err != nil {
+		switch {
+		case errors.Is(err, youtube.ErrCommentsDisabled):
+			return nil, errors.ErrCommentsDisabled(video.ID.String())
+		case errors.Is(err, youtube.ErrQuotaExceeded):
+			return nil, errors.ErrYouTubeQuotaExceeded(err)
+		}
+		return nil, errors.Wrap(err, errors.ErrorCodeYouTubeAPI, errors.SubCodeYouTubeAPIFailed, "Failed to fetch comments")
+	}
+
+	comments := make([]*models.Comment, 0, len(fetched))
+	for _, c := range fetched {
+		comments = append(comments, &models.Comment{
+			CommentID:   c.ID,
+			AuthorName:  c.AuthorName,
+			Text:        c.Text,
+			LikeCount:   c.LikeCount,
+			ReplyCount:  c.ReplyCount,
+			PublishedAt: c.PublishedAt,
+		})
+	}
+
+	if err := s.commentRepo.ReplaceForVideo(ctx, video.ID, comments); err != nil {
+		return nil, errors.ErrDatabaseError("save comments", err)
+	}
+
+	s.logger.Info("Comments ingested",
+		zap.String("video_id", video.ID.String()),
+		zap.Int("comments", len(comments)))
+
+	return comments, nil
+}
+
+// summarize clusters the comments into questions, corrections, praise and complaints
+func (s *Service) summarize(ctx context.Context, video *models.Video, comments []*models.Comment, language string) (*models.CommentSummary, error) {
+	llmProvider, err := s.providerFactory.GetLLMProvider(ctx, "summary")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LLM provider: %w", err)
+	}
+	modelInfo := llmProvider.GetModelInfo()
+
+	prompt := prompts.GetCommentSummaryPrompt(language)
+	prompt = strings.ReplaceAll(prompt, "{{.Title}}", video.Title)
+	prompt = strings.ReplaceAll(prompt, "{{.Comments}}", formatComments(comments))
+
+	resp, err := llmProvider.GenerateCompletion(ctx, llm.CompletionRequest{
+		Prompt:       prompt,
+		SystemPrompt: "You are an expert at analyzing audience feedback. You answer with valid JSON only.",
+		MaxTokens:    2000,
+		Temperature:  0.3,
+		TopP:         0.9,
+	})
+	if err != nil {
+		return nil, errors.NewWithError(errors.ErrorCodeSummaryGeneration, errors.SubCodeSummaryLLMFailed, "Failed to summarize comments", err)
+	}
+
+	if s.costService != nil {
+		s.costService.RecordUsage(ctx, video.ID, "comment_summary", modelInfo.Provider, modelInfo.Name, resp.InputTokens, resp.OutputTokens)
+	}
+
+	summary, err := parseCommentSummary(resp.Content)
+	if err != nil {
+		// Keep the answer rather than fail after paying for it
+		s.logger.Warn("Comment summary is not valid JSON, storing it as overview",
+			zap.String("video_id", video.ID.String()),
+			zap.Error(err))
+		summary = &models.CommentSummary{Overview: strings.TrimSpace(resp.Content)}
+	}
+	summary.VideoID = video.ID
+	summary.Language = language
+	summary.CommentCount = len(comments)
+	summary.ModelUsed = modelInfo.Name
+
+	if err := s.commentRepo.CreateSummary(ctx, summary); err != nil {
+		return nil, errors.ErrDatabaseError("save comment summary", err)
+	}
+
+	s.logger.Info("Comment summary generated",
+		zap.String("video_id", video.ID.String()),
+		zap.Int("comments", len(comments)))
+
+	return summary, nil
+}
+
+func (s *Service) getYouTubeVideo(ctx context.Context, videoID uuid.UUID) (*models.Video, error) {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrVideoNotFound(videoID.String())
+		}
+		return nil, err
+	}
+	if video.SourceType != models.SourceTypeYouTube || video.YouTubeID == "" {
+		return nil, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Comments are only available for YouTube videos",
+		)
+	}
+	return video, nil
+}
+
+// formatComments lists comments one per line as "[likes] text"
+func formatComments(comments []*models.Comment) string {
+	var b strings.Builder
+	for _, c := range comments {
+		text := strings.Join(strings.Fields(c.Text), " ")
+		if runes := []rune(text); len(runes) > maxCommentLength {
+			text = string(runes[:maxCommentLength]) + "..."
+		}
+		fmt.Fprintf(&b, "[%d] %s\n", c.LikeCount, text)
+	}
+	return b.String()
+}
+
+// parseCommentSummary reads the JSON answer, tolerating Markdown fences or text around it
+func parseCommentSummary(content string) (*models.CommentSummary, error) {
+	start := strings.Index(content, "{")
+	end := strings.LastIndex(content, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("no JSON object in response")
+	}
+
+	var result struct {
+		Overview    string               `json:"overview"`
+		Questions   models.CommentThemes `json:"questions"`
+		Corrections models.CommentThemes `json:"corrections"`
+		Praise      models.CommentThemes `json:"praise"`
+		Complaints  models.CommentThemes `json:"complaints"`
+	}
+	if err := json.Unmarshal([]byte(content[start:end+1]), &result); err != nil {
+		return nil, err
+	}
+
+	return &models.CommentSummary{
+		Overview:    result.Overview,
+		Questions:   result.Questions,
+		Corrections: result.Corrections,
+		Praise:      result.Praise,
+		Complaints:  result.Complaints,
+	}, nil
+}
@@ -0,0 +1,271 @@
+package comment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/pkg/errors"
+	"youtube-video-summarizer/backend/pkg/llm"
+	"youtube-video-summarizer/backend/pkg/youtube"
+)
+
+type MockVideoRepository struct {
+	mock.Mock
+}
+
+func (m *MockVideoRepository) Create(ctx context.Context, video *models.Video) error {
+	args := m.Called(ctx, video)
+	return args.Error(0)
+}
+
+func (m *MockVideoRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Video, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Video), args.Error(1)
+}
+
+func (m *MockVideoRepository) GetByYouTubeID(ctx context.Context, youtubeID string) (*models.Video, error) {
+	args := m.Called(ctx, youtubeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Video), args.Error(1)
+}
+
+func (m *MockVideoRepository) GetBySourceID(ctx context.Context, sourceType, sourceID string) (*models.Video, error) {
+	args := m.Called(ctx, sourceType, sourceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Video), args.Error(1)
+}
+
+func (m *MockVideoRepository) List(ctx context.Context, limit, offset int) ([]*models.Video, int, error) {
+	args := m.Called(ctx, limit, offset)
+	return args.Get(0).([]*models.Video), args.Int(1), args.Error(2)
+}
+
+func (m *MockVideoRepository) Update(ctx context.Context, video *models.Video) error {
+	args := m.Called(ctx, video)
+	return args.Error(0)
+}
+
+func (m *MockVideoRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVideoRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func (m *MockVideoRepository) ListScheduledDue(ctx context.Context, before time.Time) ([]*models.Video, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).([]*models.Video), args.Error(1)
+}
+
+func (m *MockVideoRepository) UpdateBroadcast(ctx context.Context, video *models.Video) error {
+	args := m.Called(ctx, video)
+	return args.Error(0)
+}
+
+type MockCommentRepository struct {
+	mock.Mock
+}
+
+func (m *MockCommentRepository) ReplaceForVideo(ctx context.Context, videoID uuid.UUID, comments []*models.Comment) error {
+	args := m.Called(ctx, videoID, comments)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) ListTop(ctx context.Context, videoID uuid.UUID, limit int) ([]*models.Comment, error) {
+	args := m.Called(ctx, videoID, limit)
+	return args.Get(0).([]*models.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) CreateSummary(ctx context.Context, summary *models.CommentSummary) error {
+	args := m.Called(ctx, summary)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) GetLatestSummary(ctx context.Context, videoID uuid.UUID, language string) (*models.CommentSummary, error) {
+	args := m.Called(ctx, videoID, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CommentSummary), args.Error(1)
+}
+
+type MockYouTubeClient struct {
+	mock.Mock
+}
+
+func (m *MockYouTubeClient) GetTopComments(ctx context.Context, videoID string, maxComments int) ([]youtube.Comment, error) {
+	args := m.Called(ctx, videoID, maxComments)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]youtube.Comment), args.Error(1)
+}
+
+type MockUsageRecorder struct {
+	mock.Mock
+}
+
+func (m *MockUsageRecorder) RecordUsage(ctx context.Context, videoID uuid.UUID, operation, provider, model string, inputTokens, outputTokens int) error {
+	args := m.Called(ctx, videoID, operation, provider, model, inputTokens, outputTokens)
+	return args.Error(0)
+}
+
+// fakeLLM answers every completion with a fixed response
+type fakeLLM struct {
+	response string
+	prompts  []string
+}
+
+func (f *fakeLLM) GenerateCompletion(ctx context.Context, req llm.CompletionRequest) (*llm.CompletionResponse, error) {
+	f.prompts = append(f.prompts, req.Prompt)
+	return &llm.CompletionResponse{Content: f.response, InputTokens: 900, OutputTokens: 150}, nil
+}
+
+func (f *fakeLLM) GenerateCompletionStream(ctx context.Context, req llm.CompletionRequest) (<-chan llm.StreamChunk, error) {
+	return nil, nil
+}
+
+func (f *fakeLLM) GenerateEmbedding(ctx context.Context, text string) ([]float32, error) {
+	return nil, nil
+}
+
+func (f *fakeLLM) GenerateBatchEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	return nil, nil
+}
+
+func (f *fakeLLM) GetModelInfo() llm.ModelInfo {
+	return llm.ModelInfo{Name: "gemini-test", Provider: "gemini"}
+}
+
+func (f *fakeLLM) ListAvailableModels() ([]string, error) {
+	return nil, nil
+}
+
+type fakeProviderFactory struct {
+	provider llm.LLMProvider
+}
+
+func (f *fakeProviderFactory) GetLLMProvider(ctx context.Context, operation string) (llm.LLMProvider, error) {
+	return f.provider, nil
+}
+
+func TestService_GetSummary_IngestsAndSummarizes(t *testing.T) {
+	videoRepo := new(MockVideoRepository)
+	commentRepo := new(MockCommentRepository)
+	youtubeClient := new(MockYouTubeClient)
+	costs := new(MockUsageRecorder)
+	model := &fakeLLM{response: "```json\n" + `{
+		"overview": "Viewers liked the build but want wiring details.",
+		"questions": [{"summary": "How is the relay wired?", "comment_count": 2, "example": "How did you wire the relay?"}],
+		"corrections": [{"summary": "The supply is 5V, not 12V", "comment_count": 1}],
+		"praise": [],
+		"complaints": []
+	}` + "\n```"}
+	service := NewService(videoRepo, commentRepo, youtubeClient, &fakeProviderFactory{provider: model}, costs, zap.NewNop())
+	ctx := context.Background()
+
+	video := &models.Video{ID: uuid.New(), YouTubeID: "dQw4w9WgXcQ", SourceType: models.SourceTypeYouTube, Title: "Relay build"}
+	stored := []*models.Comment{
+		{Text: "How did you wire the relay?", LikeCount: 120},
+		{Text: "At 3:10 it should be 5V,\nnot 12V", LikeCount: 8},
+	}
+
+	commentRepo.On("GetLatestSummary", ctx, video.ID, "auto").Return(nil, gorm.ErrRecordNotFound)
+	videoRepo.On("GetByID", ctx, video.ID).Return(video, nil)
+	commentRepo.On("ListTop", ctx, video.ID, summaryCommentLimit).Return([]*models.Comment{}, nil).Once()
+	youtubeClient.On("GetTopComments", ctx, "dQw4w9WgXcQ", maxFetchedComments).Return([]youtube.Comment{
+		{ID: "c1", Text: "How did you wire the relay?", LikeCount: 120},
+		{ID: "c2", Text: "At 3:10 it should be 5V,\nnot 12V", LikeCount: 8},
+	}, nil)
+	commentRepo.On("ReplaceForVideo", ctx, video.ID, mock.MatchedBy(func(c []*models.Comment) bool {
+		return len(c) == 2 && c[0].CommentID == "c1"
+	})).Return(nil)
+	commentRepo.On("ListTop", ctx, video.ID, summaryCommentLimit).Return(stored, nil).Once()
+	costs.On("RecordUsage", ctx, video.ID, "comment_summary", "gemini", "gemini-test", 900, 150).Return(nil)
+	commentRepo.On("CreateSummary", ctx, mock.Anything).Return(nil)
+
+	summary, err := service.GetSummary(ctx, video.ID, "auto", false)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Viewers liked the build but want wiring details.", summary.Overview)
+	require.Len(t, summary.Questions, 1)
+	assert.Equal(t, 2, summary.Questions[0].CommentCount)
+	require.Len(t, summary.Corrections, 1)
+	assert.Empty(t, summary.Praise)
+	assert.Equal(t, 2, summary.CommentCount)
+	assert.Equal(t, "gemini-test", summary.ModelUsed)
+	assert.Equal(t, "auto", summary.Language)
+
+	require.Len(t, model.prompts, 1)
+	assert.Contains(t, model.prompts[0], "[8] At 3:10 it should be 5V, not 12V", "comments are flattened to one line")
+	assert.Contains(t, model.prompts[0], "Relay build")
+	costs.AssertExpectations(t)
+	commentRepo.AssertExpectations(t)
+}
+
+func TestService_GetSummary_ReturnsStored(t *testing.T) {
+	commentRepo := new(MockCommentRepository)
+	service := NewService(nil, commentRepo, nil, nil, nil, zap.NewNop())
+	ctx := context.Background()
+
+	videoID := uuid.New()
+	stored := &models.CommentSummary{VideoID: videoID, Overview: "Mostly positive", Language: "auto"}
+	commentRepo.On("GetLatestSummary", ctx, videoID, "auto").Return(stored, nil)
+
+	summary, err := service.GetSummary(ctx, videoID, "", false)
+	require.NoError(t, err)
+	assert.Same(t, stored, summary)
+
+	// A summary in another language is not served for this one
+	german := &models.CommentSummary{VideoID: videoID, Overview: "Überwiegend positiv", Language: "de"}
+	commentRepo.On("GetLatestSummary", ctx, videoID, "de").Return(german, nil)
+	summary, err = service.GetSummary(ctx, videoID, "de", false)
+	require.NoError(t, err)
+	assert.Same(t, german, summary)
+	commentRepo.AssertExpectations(t)
+}
+
+func TestService_Ingest_CommentsDisabled(t *testing.T) {
+	videoRepo := new(MockVideoRepository)
+	youtubeClient := new(MockYouTubeClient)
+	service := NewService(videoRepo, new(MockCommentRepository), youtubeClient, nil, nil, zap.NewNop())
+	ctx := context.Background()
+
+	video := &models.Video{ID: uuid.New(), YouTubeID: "disabled000", SourceType: models.SourceTypeYouTube}
+	videoRepo.On("GetByID", ctx, video.ID).Return(video, nil)
+	youtubeClient.On("GetTopComments", ctx, "disabled000", maxFetchedComments).Return(nil, youtube.ErrCommentsDisabled)
+
+	_, err := service.Ingest(ctx, video.ID)
+	require.Error(t, err)
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok)
+	assert.Equal(t, errors.SubCodeCommentsDisabled, appErr.SubCode)
+}
+
+func TestParseCommentSummary(t *testing.T) {
+	summary, err := parseCommentSummary(`Here you go: {"overview": "ok", "praise": [{"summary": "Clear audio", "comment_count": 4}]}`)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", summary.Overview)
+	assert.Equal(t, "Clear audio", summary.Praise[0].Summary)
+
+	_, err = parseCommentSummary("Viewers were mostly positive.")
+	assert.Error(t, err)
+}
@@ -45,25 +45,36 @@ type VideoEmbeddings struct {
 	DescriptionEmbedding []float32
 	TranscriptEmbedding []float32
 	CombinedEmbedding   []float32
+	TranscriptID        *uuid.UUID // transcript behind the transcript and combined embeddings
 }
 
-func (s *Service) GenerateVideoEmbeddings(ctx context.Context, video *models.Video, transcript string) (*VideoEmbeddings, error) {
+// GenerateVideoEmbeddings embeds a video's title, description and transcript. Without a
+// transcript (or with an empty one) the video's primary transcript is used.
+func (s *Service) GenerateVideoEmbeddings(ctx context.Context, video *models.Video, source *models.Transcript) (*VideoEmbeddings, error) {
 	// Get LLM provider from settings
 	llmProvider, err := s.providerFactory.GetLLMProvider(ctx, "embedding")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get LLM provider: %w", err)
 	}
 
-	// If transcriptContent is empty, try to fetch it
-	if transcript == "" && s.transcriptService != nil {
+	// If no transcript content was given, try to fetch the primary transcript
+	if (source == nil || source.Content == "") && s.transcriptService != nil {
 		t, err := s.transcriptService.GetByVideoID(ctx, video.ID)
 		if err == nil && t != nil {
-			transcript = t.Content
+			source = t
 			s.logger.Debug("Fetched transcript for embedding generation", zap.String("video_id", video.ID.String()))
 		} else {
 			s.logger.Warn("Transcript not found for embedding generation, proceeding without it", zap.String("video_id", video.ID.String()), zap.Error(err))
 		}
 	}
+	var transcript string
+	var transcriptID *uuid.UUID
+	if source != nil && source.Content != "" {
+		transcript = source.Content
+		if source.ID != uuid.Nil {
+			transcriptID = &source.ID
+		}
+	}
 
 	// Generate title embedding
 	titleEmb, err := llmProvider.GenerateEmbedding(ctx, video.Title)
@@ -96,6 +107,9 @@ func (s *Service) GenerateVideoEmbeddings(ctx context.Context, video *models.Vid
 		TranscriptEmbedding:  transcriptEmb,
 		CombinedEmbedding:    combinedEmb,
 	}
+	if len(transcriptEmb) > 0 {
+		embeddings.TranscriptID = transcriptID
+	}
 
 	// Save embeddings
 	if err := s.saveEmbeddings(ctx, embeddings, video.ID, llmProvider); err != nil {
@@ -282,6 +296,7 @@ func (s *Service) saveEmbeddings(ctx context.Context, embeddings *VideoEmbedding
 			EmbeddingType: "transcript",
 			Embedding:     models.Vector{Data: embeddings.TranscriptEmbedding},
 			ModelUsed:     modelName,
+			TranscriptID:  embeddings.TranscriptID,
 		}); err != nil {
 			return err
 		}
@@ -294,6 +309,7 @@ func (s *Service) saveEmbeddings(ctx context.Context, embeddings *VideoEmbedding
 			EmbeddingType: "combined",
 			Embedding:     models.Vector{Data: embeddings.CombinedEmbedding},
 			ModelUsed:     modelName,
+			TranscriptID:  embeddings.TranscriptID,
 		}); err != nil {
 			return err
 		}
@@ -68,8 +68,8 @@ func (s *VideoEventService) PublishTranscriptRequested(ctx context.Context, vide
 }
 
 // PublishEmbeddingRequested publishes an embedding.requested event
-func (s *VideoEventService) PublishEmbeddingRequested(ctx context.Context, videoID uuid.UUID, youtubeID, transcriptContent string, priority int) error {
-	event := kafka.NewEmbeddingRequestedEvent(videoID.String(), youtubeID, transcriptContent, priority)
+func (s *VideoEventService) PublishEmbeddingRequested(ctx context.Context, videoID uuid.UUID, youtubeID string, transcriptID uuid.UUID, transcriptContent string, priority int) error {
+	event := kafka.NewEmbeddingRequestedEvent(videoID.String(), youtubeID, transcriptID.String(), transcriptContent, priority)
 
 	if err := s.producer.Publish(ctx, kafka.TopicEmbeddingRequested, videoID.String(), event); err != nil {
 		s.logger.Error("Failed to publish embedding.requested event",
@@ -0,0 +1,274 @@
+package playlist
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/internal/repository"
+	"youtube-video-summarizer/backend/pkg/errors"
+	"youtube-video-summarizer/backend/pkg/youtube"
+)
+
+// maxPlaylistItems caps how many videos are imported from a single playlist
+const maxPlaylistItems = 500
+
+// VideoCreator creates (or returns existing) videos from a YouTube URL or ID
+type VideoCreator interface {
+	CreateFromURL(ctx context.Context, url string) (*models.Video, error)
+}
+
+// AnalysisQueue queues analysis for a newly created video
+type AnalysisQueue interface {
+	Enqueue(ctx context.Context, video *models.Video)
+}
+
+type Service struct {
+	playlistRepo  repository.PlaylistRepository
+	videoCreator  VideoCreator
+	analysisQueue AnalysisQueue
+	youtubeClient *youtube.Client
+	logger        *zap.Logger
+
+	// Background ingestion: at most one run per playlist, cancelled by Stop
+	ingestCtx  context.Context
+	stopIngest context.CancelFunc
+	ingestMu   sync.Mutex
+	ingesting  map[uuid.UUID]bool // true when another run was requested while one is in progress
+	ingestWG   sync.WaitGroup
+}
+
+func NewService(
+	playlistRepo repository.PlaylistRepository,
+	videoCreator VideoCreator,
+	analysisQueue AnalysisQueue,
+	youtubeClient *youtube.Client,
+	logger *zap.Logger,
+) *Service {
+	ingestCtx, stopIngest := context.WithCancel(context.Background())
+	return &Service{
+		playlistRepo:  playlistRepo,
+		videoCreator:  videoCreator,
+		analysisQueue: analysisQueue,
+		youtubeClient: youtubeClient,
+		logger:        logger,
+		ingestCtx:     ingestCtx,
+		stopIngest:    stopIngest,
+		ingesting:     make(map[uuid.UUID]bool),
+	}
+}
+
+// Import resolves a playlist URL or ID and persists the playlist with one pending item per video.
+// Importing an already known playlist appends any videos that were added since the last import.
+// Call IngestItems afterwards to create the videos and queue their analysis.
+func (s *Service) Import(ctx context.Context, playlistURL string) (*models.Playlist, error) {
+	playlistID, err := s.youtubeClient.ExtractPlaylistID(playlistURL)
+	if err != nil {
+		return nil, errors.ErrPlaylistInvalidURL(playlistURL)
+	}
+
+	playlist, err := s.playlistRepo.GetByYouTubePlaylistID(ctx, playlistID)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, errors.ErrDatabaseError("get playlist", err)
+	}
+
+	if playlist == nil {
+		info, err := s.youtubeClient.GetPlaylistInfo(ctx, playlistID)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.ErrorCodeYouTubeAPI, errors.SubCodeYouTubeAPIFailed, "Failed to fetch playlist info")
+		}
+
+		playlist = &models.Playlist{
+			YouTubePlaylistID: info.ID,
+			Title:             info.Title,
+			Description:       info.Description,
+			ChannelID:         info.ChannelID,
+			ChannelName:       info.ChannelName,
+			ThumbnailURL:      info.ThumbnailURL,
+			ItemCount:         info.ItemCount,
+			Status:            "importing",
+		}
+		if err := s.playlistRepo.Create(ctx, playlist); err != nil {
+			return nil, fmt.Errorf("failed to save playlist: %w", err)
+		}
+	}
+
+	items, err := s.youtubeClient.GetPlaylistItems(ctx, playlistID, maxPlaylistItems)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.ErrorCodeYouTubeAPI, errors.SubCodeYouTubeAPIFailed, "Failed to fetch playlist items")
+	}
+
+	known := make(map[string]bool, len(playlist.Items))
+	for _, item := range playlist.Items {
+		known[item.YouTubeID] = true
+	}
+
+	var newItems []*models.PlaylistItem
+	for _, item := range items {
+		if known[item.VideoID] {
+			continue
+		}
+		known[item.VideoID] = true
+		newItems = append(newItems, &models.PlaylistItem{
+			PlaylistID: playlist.ID,
+			Position:   item.Position,
+			YouTubeID:  item.VideoID,
+			Title:      item.Title,
+			Status:     "pending",
+		})
+	}
+
+	if err := s.playlistRepo.CreateItems(ctx, newItems); err != nil {
+		return nil, fmt.Errorf("failed to save playlist items: %w", err)
+	}
+
+	if len(newItems) > 0 {
+		playlist.ItemCount = len(known)
+		playlist.Status = "importing"
+		if err := s.playlistRepo.Update(ctx, playlist); err != nil {
+			return nil, fmt.Errorf("failed to update playlist: %w", err)
+		}
+	}
+
+	s.logger.Info("Playlist imported",
+		zap.String("playlist_id", playlist.ID.String()),
+		zap.String("youtube_playlist_id", playlistID),
+		zap.Int("new_items", len(newItems)),
+	)
+
+	return s.playlistRepo.GetByID(ctx, playlist.ID)
+}
+
+// StartIngest runs IngestItems for a playlist in the background. If the playlist is already being
+// ingested, the running ingestion makes one more pass when it finishes, which picks up items
+// appended by a re-import in the meantime.
+func (s *Service) StartIngest(id uuid.UUID) {
+	s.ingestMu.Lock()
+	if _, running := s.ingesting[id]; running {
+		s.ingesting[id] = true
+		s.ingestMu.Unlock()
+		return
+	}
+	s.ingesting[id] = false
+	s.ingestWG.Add(1)
+	s.ingestMu.Unlock()
+
+	go func() {
+		defer s.ingestWG.Done()
+		for {
+			if err := s.IngestItems(s.ingestCtx, id); err != nil {
+				s.logger.Error("Playlist ingestion failed", zap.String("playlist_id", id.String()), zap.Error(err))
+			}
+
+			s.ingestMu.Lock()
+			again := s.ingesting[id] && s.ingestCtx.Err() == nil
+			if !again {
+				delete(s.ingesting, id)
+				s.ingestMu.Unlock()
+				return
+			}
+			s.ingesting[id] = false
+			s.ingestMu.Unlock()
+		}
+	}()
+}
+
+// Stop cancels background ingestions and waits for them to return. Items that were not
+// reached stay pending and are picked up by the next import of the playlist.
+func (s *Service) Stop() {
+	s.stopIngest()
+	s.ingestWG.Wait()
+}
+
+// IngestItems creates a video for every pending playlist item and queues its analysis
+func (s *Service) IngestItems(ctx context.Context, id uuid.UUID) error {
+	playlist, err := s.playlistRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get playlist: %w", err)
+	}
+
+	failed := 0
+	for i := range playlist.Items {
+		item := &playlist.Items[i]
+		if item.Status != "pending" {
+			if item.Status == "failed" {
+				failed++
+			}
+			continue
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		video, err := s.videoCreator.CreateFromURL(ctx, item.YouTubeID)
+		if err != nil && ctx.Err() != nil {
+			// Interrupted, not failed: leave the item pending
+			return ctx.Err()
+		}
+		if err != nil {
+			s.logger.Warn("Failed to create video from playlist item",
+				zap.String("playlist_id", id.String()),
+				zap.String("youtube_id", item.YouTubeID),
+				zap.Error(err),
+			)
+			item.Status = "failed"
+			item.Error = err.Error()
+			failed++
+		} else {
+			item.VideoID = &video.ID
+			item.Status = "created"
+			item.Error = ""
+			// Only queue videos that haven't been analyzed yet
+			if video.Status == "pending" {
+				s.analysisQueue.Enqueue(ctx, video)
+			}
+		}
+
+		if err := s.playlistRepo.UpdateItem(ctx, item); err != nil {
+			s.logger.Warn("Failed to update playlist item",
+				zap.String("item_id", item.ID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+
+	playlist.Status = "completed"
+	if len(playlist.Items) > 0 && failed == len(playlist.Items) {
+		playlist.Status = "error"
+	}
+	if err := s.playlistRepo.Update(ctx, playlist); err != nil {
+		return fmt.Errorf("failed to update playlist: %w", err)
+	}
+
+	s.logger.Info("Playlist ingestion finished",
+		zap.String("playlist_id", id.String()),
+		zap.Int("items", len(playlist.Items)),
+		zap.Int("failed", failed),
+	)
+
+	return nil
+}
+
+func (s *Service) GetByID(ctx context.Context, id uuid.UUID) (*models.Playlist, error) {
+	playlist, err := s.playlistRepo.GetByID(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrPlaylistNotFound(id.String())
+		}
+		return nil, err
+	}
+	return playlist, nil
+}
+
+func (s *Service) List(ctx context.Context, limit, offset int) ([]*models.Playlist, int, error) {
+	return s.playlistRepo.List(ctx, limit, offset)
+}
+
+func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.playlistRepo.Delete(ctx, id)
+}
@@ -0,0 +1,167 @@
+package playlist
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+type MockPlaylistRepository struct {
+	mock.Mock
+}
+
+func (m *MockPlaylistRepository) Create(ctx context.Context, playlist *models.Playlist) error {
+	args := m.Called(ctx, playlist)
+	return args.Error(0)
+}
+
+func (m *MockPlaylistRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Playlist, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Playlist), args.Error(1)
+}
+
+func (m *MockPlaylistRepository) GetByYouTubePlaylistID(ctx context.Context, youtubePlaylistID string) (*models.Playlist, error) {
+	args := m.Called(ctx, youtubePlaylistID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Playlist), args.Error(1)
+}
+
+func (m *MockPlaylistRepository) List(ctx context.Context, limit, offset int) ([]*models.Playlist, int, error) {
+	args := m.Called(ctx, limit, offset)
+	return args.Get(0).([]*models.Playlist), args.Int(1), args.Error(2)
+}
+
+func (m *MockPlaylistRepository) Update(ctx context.Context, playlist *models.Playlist) error {
+	args := m.Called(ctx, playlist)
+	return args.Error(0)
+}
+
+func (m *MockPlaylistRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPlaylistRepository) CreateItems(ctx context.Context, items []*models.PlaylistItem) error {
+	args := m.Called(ctx, items)
+	return args.Error(0)
+}
+
+func (m *MockPlaylistRepository) UpdateItem(ctx context.Context, item *models.PlaylistItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+type MockVideoCreator struct {
+	mock.Mock
+}
+
+func (m *MockVideoCreator) CreateFromURL(ctx context.Context, url string) (*models.Video, error) {
+	args := m.Called(ctx, url)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Video), args.Error(1)
+}
+
+type MockAnalysisQueue struct {
+	mock.Mock
+}
+
+func (m *MockAnalysisQueue) Enqueue(ctx context.Context, video *models.Video) {
+	m.Called(ctx, video)
+}
+
+func TestService_IngestItems(t *testing.T) {
+	mockRepo := new(MockPlaylistRepository)
+	mockCreator := new(MockVideoCreator)
+	mockQueue := new(MockAnalysisQueue)
+	service := NewService(mockRepo, mockCreator, mockQueue, nil, zap.NewNop())
+
+	ctx := context.Background()
+	playlistID := uuid.New()
+	playlist := &models.Playlist{
+		ID: playlistID,
+		Items: []models.PlaylistItem{
+			{ID: uuid.New(), PlaylistID: playlistID, Position: 0, YouTubeID: "video_new_01", Status: "pending"},
+			{ID: uuid.New(), PlaylistID: playlistID, Position: 1, YouTubeID: "video_old_02", Status: "pending"},
+			{ID: uuid.New(), PlaylistID: playlistID, Position: 2, YouTubeID: "video_bad_03", Status: "pending"},
+		},
+	}
+
+	newVideo := &models.Video{ID: uuid.New(), YouTubeID: "video_new_01", Status: "pending"}
+	analyzedVideo := &models.Video{ID: uuid.New(), YouTubeID: "video_old_02", Status: "completed"}
+
+	mockRepo.On("GetByID", ctx, playlistID).Return(playlist, nil)
+	mockCreator.On("CreateFromURL", ctx, "video_new_01").Return(newVideo, nil)
+	mockCreator.On("CreateFromURL", ctx, "video_old_02").Return(analyzedVideo, nil)
+	mockCreator.On("CreateFromURL", ctx, "video_bad_03").Return(nil, fmt.Errorf("video not found"))
+	mockQueue.On("Enqueue", ctx, newVideo).Return().Once()
+	mockRepo.On("UpdateItem", ctx, mock.AnythingOfType("*models.PlaylistItem")).Return(nil)
+	mockRepo.On("Update", ctx, playlist).Return(nil)
+
+	err := service.IngestItems(ctx, playlistID)
+	require.NoError(t, err)
+
+	assert.Equal(t, "created", playlist.Items[0].Status)
+	assert.Equal(t, newVideo.ID, *playlist.Items[0].VideoID)
+	assert.Equal(t, "created", playlist.Items[1].Status)
+	assert.Equal(t, "failed", playlist.Items[2].Status)
+	assert.Contains(t, playlist.Items[2].Error, "video not found")
+	assert.Equal(t, "completed", playlist.Status)
+
+	mockQueue.AssertExpectations(t)
+	mockCreator.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_StartIngest_OneRunPerPlaylist(t *testing.T) {
+	mockRepo := new(MockPlaylistRepository)
+	mockCreator := new(MockVideoCreator)
+	mockQueue := new(MockAnalysisQueue)
+	service := NewService(mockRepo, mockCreator, mockQueue, nil, zap.NewNop())
+
+	playlistID := uuid.New()
+	playlist := &models.Playlist{
+		ID:    playlistID,
+		Items: []models.PlaylistItem{{ID: uuid.New(), PlaylistID: playlistID, YouTubeID: "video_new_01", Status: "pending"}},
+	}
+	video := &models.Video{ID: uuid.New(), YouTubeID: "video_new_01", Status: "pending"}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	mockRepo.On("GetByID", mock.Anything, playlistID).Return(playlist, nil)
+	mockCreator.On("CreateFromURL", mock.Anything, "video_new_01").Run(func(mock.Arguments) {
+		close(started)
+		<-release
+	}).Return(video, nil).Once()
+	mockQueue.On("Enqueue", mock.Anything, video).Return().Once()
+	mockRepo.On("UpdateItem", mock.Anything, mock.Anything).Return(nil)
+	passes := make(chan struct{}, 2)
+	mockRepo.On("Update", mock.Anything, playlist).Run(func(mock.Arguments) { passes <- struct{}{} }).Return(nil)
+
+	service.StartIngest(playlistID)
+	<-started
+	// A re-import while the first run is busy queues one more pass instead of a parallel run
+	service.StartIngest(playlistID)
+	service.StartIngest(playlistID)
+	close(release)
+	<-passes
+	<-passes
+	service.Stop()
+
+	mockRepo.AssertNumberOfCalls(t, "GetByID", 2)
+	mockCreator.AssertExpectations(t)
+	mockQueue.AssertExpectations(t)
+}
@@ -0,0 +1,73 @@
+package revision
+
+import (
+	"strings"
+
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+// maxDiffCells bounds the table diffWords builds; larger texts are shown as replaced whole
+const maxDiffCells = 1 << 20
+
+// diffWords returns the word diff turning before into after, joining runs of words with the
+// same operation into one part. Whitespace is not compared.
+func diffWords(before, after string) []models.DiffPart {
+	a, b := strings.Fields(before), strings.Fields(after)
+
+	var parts []models.DiffPart
+	add := func(op, word string) {
+		if n := len(parts); n > 0 && parts[n-1].Op == op {
+			parts[n-1].Text += " " + word
+			return
+		}
+		parts = append(parts, models.DiffPart{Op: op, Text: word})
+	}
+
+	if (len(a)+1)*(len(b)+1) > maxDiffCells {
+		for _, word := range a {
+			add(models.DiffDelete, word)
+		}
+		for _, word := range b {
+			add(models.DiffInsert, word)
+		}
+		return parts
+	}
+
+	// common[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	common := make([][]int, len(a)+1)
+	for i := range common {
+		common[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			add(models.DiffEqual, a[i])
+			i++
+			j++
+		case common[i+1][j] >= common[i][j+1]:
+			add(models.DiffDelete, a[i])
+			i++
+		default:
+			add(models.DiffInsert, b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		add(models.DiffDelete, a[i])
+	}
+	for ; j < len(b); j++ {
+		add(models.DiffInsert, b[j])
+	}
+	return parts
+}
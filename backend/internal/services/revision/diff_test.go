@@ -0,0 +1,26 @@
+package revision
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+func TestDiffWords(t *testing.T) {
+	assert.Equal(t, []models.DiffPart{
+		{Op: models.DiffEqual, Text: "we deploy to"},
+		{Op: models.DiffDelete, Text: "cooper netties"},
+		{Op: models.DiffInsert, Text: "Kubernetes"},
+		{Op: models.DiffEqual, Text: "every day"},
+	}, diffWords("we deploy to cooper netties every day", "we  deploy to Kubernetes every day"))
+
+	assert.Equal(t, []models.DiffPart{
+		{Op: models.DiffInsert, Text: "So"},
+		{Op: models.DiffEqual, Text: "this is it"},
+		{Op: models.DiffDelete, Text: "um"},
+	}, diffWords("this is it um", "So this is it"))
+
+	assert.Nil(t, diffWords("", ""))
+	assert.Equal(t, []models.DiffPart{{Op: models.DiffInsert, Text: "hello world"}}, diffWords("", "hello world"))
+}
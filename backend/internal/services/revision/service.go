@@ -0,0 +1,197 @@
+package revision
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/internal/repository"
+	"youtube-video-summarizer/backend/pkg/errors"
+)
+
+// maxAuthorLength is the longest author name stored with a revision
+const maxAuthorLength = 255
+
+// Edit is a correction of the text of some segments of a transcript
+type Edit struct {
+	Author   string        `json:"author"`
+	Comment  string        `json:"comment"`
+	Segments []SegmentEdit `json:"segments"`
+	// BaseRevision is the revision the editor saw, 0 for the transcript as created; the edit is
+	// refused when the transcript has been edited since. Unchecked when nil.
+	BaseRevision *int `json:"base_revision"`
+}
+
+// SegmentEdit replaces the text of the segment at Position
+type SegmentEdit struct {
+	Position int    `json:"position"`
+	Text     string `json:"text"`
+}
+
+type Service struct {
+	transcriptRepo repository.TranscriptRepository
+	revisionRepo   repository.TranscriptRevisionRepository
+	logger         *zap.Logger
+}
+
+func NewService(
+	transcriptRepo repository.TranscriptRepository,
+	revisionRepo repository.TranscriptRevisionRepository,
+	logger *zap.Logger,
+) *Service {
+	return &Service{
+		transcriptRepo: transcriptRepo,
+		revisionRepo:   revisionRepo,
+		logger:         logger,
+	}
+}
+
+// EditSegments corrects the text of segments of a transcript and records the change as a new
+// revision. Edited segments keep their timing but lose their word timings, which belong to
+// the words as transcribed, and the content is rebuilt from the segments. Summaries and
+// embeddings built from the transcript are marked stale.
+func (s *Service) EditSegments(ctx context.Context, videoID, transcriptID uuid.UUID, edit Edit) (*models.Transcript, *models.TranscriptRevision, error) {
+	author := strings.TrimSpace(edit.Author)
+	if author == "" {
+		return nil, nil, errors.New(errors.ErrorCodeBadRequest, errors.SubCodeInvalidInput, "Author is required")
+	}
+	if utf8.RuneCountInString(author) > maxAuthorLength {
+		return nil, nil, errors.New(errors.ErrorCodeBadRequest, errors.SubCodeInvalidInput,
+			fmt.Sprintf("Author must be at most %d characters", maxAuthorLength))
+	}
+	if len(edit.Segments) == 0 {
+		return nil, nil, errors.New(errors.ErrorCodeBadRequest, errors.SubCodeInvalidInput, "At least one segment edit is required")
+	}
+
+	// Read the revision number before the transcript: an edit saved in between then shows
+	// up as a conflict instead of being overwritten
+	latest, err := s.revisionRepo.LatestNumber(ctx, transcriptID)
+	if err != nil {
+		return nil, nil, errors.ErrDatabaseError("get transcript revision", err)
+	}
+	transcript, err := s.getTranscript(ctx, videoID, transcriptID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if edit.BaseRevision != nil && *edit.BaseRevision != latest {
+		return nil, nil, errors.ErrTranscriptRevisionConflict(transcriptID.String())
+	}
+
+	segments := make(models.TranscriptSegments, len(transcript.Segments))
+	copy(segments, transcript.Segments)
+	edited := make(map[int]bool, len(edit.Segments))
+	var changes models.SegmentChanges
+	for _, segmentEdit := range edit.Segments {
+		if segmentEdit.Position < 0 || segmentEdit.Position >= len(segments) {
+			return nil, nil, errors.New(errors.ErrorCodeBadRequest, errors.SubCodeInvalidInput,
+				fmt.Sprintf("Transcript has no segment %d", segmentEdit.Position))
+		}
+		if edited[segmentEdit.Position] {
+			return nil, nil, errors.New(errors.ErrorCodeBadRequest, errors.SubCodeInvalidInput,
+				fmt.Sprintf("Segment %d is edited more than once", segmentEdit.Position))
+		}
+		edited[segmentEdit.Position] = true
+
+		text := strings.Join(strings.Fields(segmentEdit.Text), " ")
+		if text == "" {
+			return nil, nil, errors.New(errors.ErrorCodeBadRequest, errors.SubCodeInvalidInput,
+				fmt.Sprintf("Text of segment %d cannot be empty", segmentEdit.Position))
+		}
+		seg := segments[segmentEdit.Position]
+		if text == strings.Join(strings.Fields(seg.Text), " ") {
+			continue
+		}
+
+		changes = append(changes, models.SegmentChange{
+			Position: segmentEdit.Position,
+			Start:    seg.Start,
+			End:      seg.End,
+			Before:   seg.Text,
+			After:    text,
+			Diff:     diffWords(seg.Text, text),
+		})
+		seg.Text = text
+		seg.Words = nil
+		segments[segmentEdit.Position] = seg
+	}
+	if len(changes) == 0 {
+		return nil, nil, errors.New(errors.ErrorCodeBadRequest, errors.SubCodeInvalidInput, "The edits do not change the transcript")
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Position < changes[j].Position })
+
+	var content []string
+	for _, seg := range segments {
+		if seg.Text != "" {
+			content = append(content, seg.Text)
+		}
+	}
+	transcript.Segments = segments
+	transcript.Content = strings.Join(content, " ")
+
+	revision := &models.TranscriptRevision{
+		Number:  latest + 1,
+		Author:  author,
+		Comment: strings.TrimSpace(edit.Comment),
+		Changes: changes,
+	}
+	if err := s.revisionRepo.Create(ctx, transcript, revision); err != nil {
+		if err == repository.ErrRevisionConflict {
+			return nil, nil, errors.ErrTranscriptRevisionConflict(transcriptID.String())
+		}
+		return nil, nil, errors.ErrDatabaseError("save transcript revision", err)
+	}
+
+	s.logger.Info("Transcript edited",
+		zap.String("video_id", videoID.String()),
+		zap.String("transcript_id", transcriptID.String()),
+		zap.Int("revision", revision.Number),
+		zap.String("author", author),
+		zap.Int("segments", len(changes)))
+
+	return transcript, revision, nil
+}
+
+// ListRevisions returns the revisions of one of a video's transcripts, newest first
+func (s *Service) ListRevisions(ctx context.Context, videoID, transcriptID uuid.UUID) ([]*models.TranscriptRevision, error) {
+	if _, err := s.getTranscript(ctx, videoID, transcriptID); err != nil {
+		return nil, err
+	}
+	revisions, err := s.revisionRepo.ListByTranscriptID(ctx, transcriptID)
+	if err != nil {
+		return nil, errors.ErrDatabaseError("list transcript revisions", err)
+	}
+	return revisions, nil
+}
+
+// GetRevision returns a revision of one of a video's transcripts by its number
+func (s *Service) GetRevision(ctx context.Context, videoID, transcriptID uuid.UUID, number int) (*models.TranscriptRevision, error) {
+	if _, err := s.getTranscript(ctx, videoID, transcriptID); err != nil {
+		return nil, err
+	}
+	revision, err := s.revisionRepo.Get(ctx, transcriptID, number)
+	if err == gorm.ErrRecordNotFound {
+		return nil, errors.ErrTranscriptRevisionNotFound(transcriptID.String(), number)
+	}
+	if err != nil {
+		return nil, errors.ErrDatabaseError("get transcript revision", err)
+	}
+	return revision, nil
+}
+
+// getTranscript returns a transcript, checking it belongs to the video
+func (s *Service) getTranscript(ctx context.Context, videoID, transcriptID uuid.UUID) (*models.Transcript, error) {
+	transcript, err := s.transcriptRepo.GetByID(ctx, transcriptID)
+	if err == gorm.ErrRecordNotFound || (err == nil && transcript.VideoID != videoID) {
+		return nil, errors.ErrTranscriptNotFound(videoID.String())
+	}
+	if err != nil {
+		return nil, errors.ErrDatabaseError("get transcript", err)
+	}
+	return transcript, nil
+}
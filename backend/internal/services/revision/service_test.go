@@ -0,0 +1,267 @@
+package revision
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/internal/repository"
+	"youtube-video-summarizer/backend/pkg/errors"
+)
+
+type MockTranscriptRepository struct {
+	mock.Mock
+}
+
+func (m *MockTranscriptRepository) Create(ctx context.Context, transcript *models.Transcript) error {
+	args := m.Called(ctx, transcript)
+	return args.Error(0)
+}
+
+func (m *MockTranscriptRepository) GetByVideoID(ctx context.Context, videoID uuid.UUID) (*models.Transcript, error) {
+	args := m.Called(ctx, videoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Transcript), args.Error(1)
+}
+
+func (m *MockTranscriptRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Transcript, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Transcript), args.Error(1)
+}
+
+func (m *MockTranscriptRepository) GetByLanguage(ctx context.Context, videoID uuid.UUID, language string) (*models.Transcript, error) {
+	args := m.Called(ctx, videoID, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Transcript), args.Error(1)
+}
+
+func (m *MockTranscriptRepository) GetByKey(ctx context.Context, videoID uuid.UUID, language, source string) (*models.Transcript, error) {
+	args := m.Called(ctx, videoID, language, source)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Transcript), args.Error(1)
+}
+
+func (m *MockTranscriptRepository) ListByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.Transcript, error) {
+	args := m.Called(ctx, videoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Transcript), args.Error(1)
+}
+
+func (m *MockTranscriptRepository) SetPrimary(ctx context.Context, videoID, id uuid.UUID) error {
+	args := m.Called(ctx, videoID, id)
+	return args.Error(0)
+}
+
+func (m *MockTranscriptRepository) Update(ctx context.Context, transcript *models.Transcript) error {
+	args := m.Called(ctx, transcript)
+	return args.Error(0)
+}
+
+func (m *MockTranscriptRepository) UpdateSpeakers(ctx context.Context, id uuid.UUID, speakers models.SpeakerNames) error {
+	args := m.Called(ctx, id, speakers)
+	return args.Error(0)
+}
+
+func (m *MockTranscriptRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockRevisionRepository struct {
+	mock.Mock
+}
+
+func (m *MockRevisionRepository) Create(ctx context.Context, transcript *models.Transcript, revision *models.TranscriptRevision) error {
+	args := m.Called(ctx, transcript, revision)
+	return args.Error(0)
+}
+
+func (m *MockRevisionRepository) ListByTranscriptID(ctx context.Context, transcriptID uuid.UUID) ([]*models.TranscriptRevision, error) {
+	args := m.Called(ctx, transcriptID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.TranscriptRevision), args.Error(1)
+}
+
+func (m *MockRevisionRepository) Get(ctx context.Context, transcriptID uuid.UUID, number int) (*models.TranscriptRevision, error) {
+	args := m.Called(ctx, transcriptID, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TranscriptRevision), args.Error(1)
+}
+
+func (m *MockRevisionRepository) LatestNumber(ctx context.Context, transcriptID uuid.UUID) (int, error) {
+	args := m.Called(ctx, transcriptID)
+	return args.Int(0), args.Error(1)
+}
+
+func testTranscript(videoID uuid.UUID) *models.Transcript {
+	return &models.Transcript{
+		ID:       uuid.New(),
+		VideoID:  videoID,
+		Language: "en",
+		Source:   "whisper",
+		Content:  "Welcome back. Today we deploy to cooper netties. Let's go.",
+		Segments: models.TranscriptSegments{
+			{Start: 0, End: 2, Text: "Welcome back."},
+			{Start: 2, End: 6, Text: "Today we deploy to cooper netties.", Speaker: "SPEAKER_00",
+				Words: []models.TranscriptWord{{Start: 2, End: 2.4, Text: "Today"}}},
+			{Start: 6, End: 8, Text: "Let's go."},
+		},
+	}
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func TestService_EditSegments(t *testing.T) {
+	transcriptRepo := new(MockTranscriptRepository)
+	revisionRepo := new(MockRevisionRepository)
+	service := NewService(transcriptRepo, revisionRepo, zap.NewNop())
+	ctx := context.Background()
+
+	videoID := uuid.New()
+	transcript := testTranscript(videoID)
+	transcriptRepo.On("GetByID", ctx, transcript.ID).Return(transcript, nil)
+	revisionRepo.On("LatestNumber", ctx, transcript.ID).Return(2, nil)
+	revisionRepo.On("Create", ctx, transcript, mock.AnythingOfType("*models.TranscriptRevision")).Return(nil)
+
+	edited, revision, err := service.EditSegments(ctx, videoID, transcript.ID, Edit{
+		Author:  " Dana ",
+		Comment: "Fix product name",
+		Segments: []SegmentEdit{
+			{Position: 2, Text: "Let's go."}, // unchanged
+			{Position: 1, Text: "Today we  deploy to Kubernetes."},
+		},
+		BaseRevision: intPtr(2),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, revision.Number)
+	assert.Equal(t, "Dana", revision.Author)
+	assert.Equal(t, "Fix product name", revision.Comment)
+	require.Len(t, revision.Changes, 1, "unchanged segments are not recorded")
+	change := revision.Changes[0]
+	assert.Equal(t, 1, change.Position)
+	assert.Equal(t, 2.0, change.Start)
+	assert.Equal(t, "Today we deploy to cooper netties.", change.Before)
+	assert.Equal(t, "Today we deploy to Kubernetes.", change.After)
+	assert.Contains(t, change.Diff, models.DiffPart{Op: models.DiffInsert, Text: "Kubernetes."})
+
+	seg := edited.Segments[1]
+	assert.Equal(t, "Today we deploy to Kubernetes.", seg.Text)
+	assert.Equal(t, 6.0, seg.End)
+	assert.Equal(t, "SPEAKER_00", seg.Speaker)
+	assert.Empty(t, seg.Words, "word timings no longer match the corrected text")
+	assert.Equal(t, "Welcome back. Today we deploy to Kubernetes. Let's go.", edited.Content)
+	revisionRepo.AssertExpectations(t)
+}
+
+func TestService_EditSegments_Rejects(t *testing.T) {
+	transcriptRepo := new(MockTranscriptRepository)
+	revisionRepo := new(MockRevisionRepository)
+	service := NewService(transcriptRepo, revisionRepo, zap.NewNop())
+	ctx := context.Background()
+
+	videoID := uuid.New()
+	transcript := testTranscript(videoID)
+	other := testTranscript(uuid.New())
+	transcriptRepo.On("GetByID", ctx, transcript.ID).Return(transcript, nil)
+	transcriptRepo.On("GetByID", ctx, other.ID).Return(other, nil)
+	revisionRepo.On("LatestNumber", ctx, mock.Anything).Return(1, nil)
+
+	fix := []SegmentEdit{{Position: 1, Text: "Today we deploy to Kubernetes."}}
+	tests := []struct {
+		name         string
+		transcriptID uuid.UUID
+		edit         Edit
+		subCode      errors.SubCode
+	}{
+		{"no author", transcript.ID, Edit{Author: "  ", Segments: fix}, errors.SubCodeInvalidInput},
+		{"no segments", transcript.ID, Edit{Author: "Dana"}, errors.SubCodeInvalidInput},
+		{"unknown segment", transcript.ID, Edit{Author: "Dana", Segments: []SegmentEdit{{Position: 3, Text: "x"}}}, errors.SubCodeInvalidInput},
+		{"segment twice", transcript.ID, Edit{Author: "Dana", Segments: append(fix, fix...)}, errors.SubCodeInvalidInput},
+		{"empty text", transcript.ID, Edit{Author: "Dana", Segments: []SegmentEdit{{Position: 0, Text: " "}}}, errors.SubCodeInvalidInput},
+		{"no change", transcript.ID, Edit{Author: "Dana", Segments: []SegmentEdit{{Position: 0, Text: "Welcome  back."}}}, errors.SubCodeInvalidInput},
+		{"outdated base", transcript.ID, Edit{Author: "Dana", Segments: fix, BaseRevision: intPtr(0)}, errors.SubCodeTranscriptRevisionConflict},
+		{"other video", other.ID, Edit{Author: "Dana", Segments: fix}, errors.SubCodeTranscriptNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := service.EditSegments(ctx, videoID, tt.transcriptID, tt.edit)
+			assertSubCode(t, err, tt.subCode)
+		})
+	}
+	revisionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_EditSegments_ConcurrentEdit(t *testing.T) {
+	transcriptRepo := new(MockTranscriptRepository)
+	revisionRepo := new(MockRevisionRepository)
+	service := NewService(transcriptRepo, revisionRepo, zap.NewNop())
+	ctx := context.Background()
+
+	videoID := uuid.New()
+	transcript := testTranscript(videoID)
+	transcriptRepo.On("GetByID", ctx, transcript.ID).Return(transcript, nil)
+	revisionRepo.On("LatestNumber", ctx, transcript.ID).Return(0, nil)
+	revisionRepo.On("Create", ctx, transcript, mock.Anything).Return(repository.ErrRevisionConflict)
+
+	_, _, err := service.EditSegments(ctx, videoID, transcript.ID, Edit{
+		Author:   "Dana",
+		Segments: []SegmentEdit{{Position: 0, Text: "Welcome back, everyone."}},
+	})
+	assertSubCode(t, err, errors.SubCodeTranscriptRevisionConflict)
+}
+
+func TestService_GetRevision(t *testing.T) {
+	transcriptRepo := new(MockTranscriptRepository)
+	revisionRepo := new(MockRevisionRepository)
+	service := NewService(transcriptRepo, revisionRepo, zap.NewNop())
+	ctx := context.Background()
+
+	videoID := uuid.New()
+	transcript := testTranscript(videoID)
+	stored := &models.TranscriptRevision{ID: uuid.New(), TranscriptID: transcript.ID, Number: 1, Author: "Dana"}
+	transcriptRepo.On("GetByID", ctx, transcript.ID).Return(transcript, nil)
+	revisionRepo.On("Get", ctx, transcript.ID, 1).Return(stored, nil)
+	revisionRepo.On("Get", ctx, transcript.ID, 2).Return(nil, gorm.ErrRecordNotFound)
+
+	revision, err := service.GetRevision(ctx, videoID, transcript.ID, 1)
+	require.NoError(t, err)
+	assert.Same(t, stored, revision)
+
+	_, err = service.GetRevision(ctx, videoID, transcript.ID, 2)
+	assertSubCode(t, err, errors.SubCodeTranscriptRevisionNotFound)
+
+	_, err = service.GetRevision(ctx, uuid.New(), transcript.ID, 1)
+	assertSubCode(t, err, errors.SubCodeTranscriptNotFound)
+}
+
+func assertSubCode(t *testing.T, err error, subCode errors.SubCode) {
+	t.Helper()
+	require.Error(t, err)
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok, err.Error())
+	assert.Equal(t, subCode, appErr.SubCode)
+}
@@ -0,0 +1,64 @@
+package search
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/internal/repository"
+	"youtube-video-summarizer/backend/pkg/errors"
+)
+
+const (
+	// DefaultLimit and MaxLimit bound the videos returned per page
+	DefaultLimit = 20
+	MaxLimit     = 100
+	// hitsPerVideo is how many matching segments are returned per video
+	hitsPerVideo = 3
+	// maxQueryLength keeps queries to what someone could have said in a few sentences
+	maxQueryLength = 200
+)
+
+type Service struct {
+	searchRepo repository.SearchRepository
+	logger     *zap.Logger
+}
+
+func NewService(searchRepo repository.SearchRepository, logger *zap.Logger) *Service {
+	return &Service{
+		searchRepo: searchRepo,
+		logger:     logger,
+	}
+}
+
+// SearchTranscripts finds the videos whose transcripts contain the words of query.Query, with
+// the segments they are said in. Quoted phrases, "or" and -word exclusions are supported.
+func (s *Service) SearchTranscripts(ctx context.Context, query models.TranscriptSearchQuery) ([]*models.TranscriptSearchResult, int, error) {
+	query.Query = strings.TrimSpace(query.Query)
+	if query.Query == "" {
+		return nil, 0, errors.New(errors.ErrorCodeBadRequest, errors.SubCodeMissingParameter, "Search query is required")
+	}
+	if len([]rune(query.Query)) > maxQueryLength {
+		return nil, 0, errors.New(errors.ErrorCodeBadRequest, errors.SubCodeInvalidInput, "Search query is too long")
+	}
+	if query.PublishedAfter != nil && query.PublishedBefore != nil && !query.PublishedBefore.After(*query.PublishedAfter) {
+		return nil, 0, errors.New(errors.ErrorCodeBadRequest, errors.SubCodeInvalidInput, "published_before must be after published_after")
+	}
+	query.Language = strings.ToLower(strings.TrimSpace(query.Language))
+	if query.Limit <= 0 {
+		query.Limit = DefaultLimit
+	}
+	if query.Limit > MaxLimit {
+		query.Limit = MaxLimit
+	}
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+
+	results, total, err := s.searchRepo.SearchTranscripts(ctx, query, hitsPerVideo)
+	if err != nil {
+		return nil, 0, errors.ErrDatabaseError("search transcripts", err)
+	}
+	return results, total, nil
+}
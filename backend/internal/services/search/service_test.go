@@ -0,0 +1,74 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/pkg/errors"
+)
+
+type MockSearchRepository struct {
+	mock.Mock
+}
+
+func (m *MockSearchRepository) SearchTranscripts(ctx context.Context, query models.TranscriptSearchQuery, hitsPerVideo int) ([]*models.TranscriptSearchResult, int, error) {
+	args := m.Called(ctx, query, hitsPerVideo)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*models.TranscriptSearchResult), args.Int(1), args.Error(2)
+}
+
+func TestService_SearchTranscripts(t *testing.T) {
+	repo := new(MockSearchRepository)
+	service := NewService(repo, zap.NewNop())
+	ctx := context.Background()
+
+	results := []*models.TranscriptSearchResult{{
+		Video:    &models.Video{ID: uuid.New(), Title: "Designing APIs"},
+		HitCount: 1,
+		Hits:     []models.TranscriptSearchHit{{Start: 312.4, End: 318, Snippet: "then the <mark>rate</mark> <mark>limiter</mark> kicks in"}},
+	}}
+	repo.On("SearchTranscripts", ctx, models.TranscriptSearchQuery{
+		Query:    `"rate limiter"`,
+		Language: "en",
+		Limit:    MaxLimit,
+	}, hitsPerVideo).Return(results, 1, nil)
+
+	got, total, err := service.SearchTranscripts(ctx, models.TranscriptSearchQuery{
+		Query:    `  "rate limiter" `,
+		Language: "EN",
+		Limit:    500,
+		Offset:   -3,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, results, got)
+	repo.AssertExpectations(t)
+}
+
+func TestService_SearchTranscripts_InvalidQuery(t *testing.T) {
+	service := NewService(new(MockSearchRepository), zap.NewNop())
+	ctx := context.Background()
+
+	after := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	before := after.AddDate(0, -1, 0)
+	for name, query := range map[string]models.TranscriptSearchQuery{
+		"empty":      {Query: "   "},
+		"too long":   {Query: string(make([]rune, maxQueryLength+1))},
+		"date range": {Query: "kubernetes", PublishedAfter: &after, PublishedBefore: &before},
+	} {
+		_, _, err := service.SearchTranscripts(ctx, query)
+		require.Error(t, err, name)
+		appErr, ok := err.(*errors.AppError)
+		require.True(t, ok, name)
+		assert.Equal(t, errors.ErrorCodeBadRequest, appErr.Code, name)
+	}
+}
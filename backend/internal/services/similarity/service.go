@@ -10,15 +10,15 @@ import (
 	"gorm.io/gorm"
 	"youtube-video-summarizer/backend/internal/models"
 	"youtube-video-summarizer/backend/internal/repository"
+	"youtube-video-summarizer/backend/internal/services/source"
 	"youtube-video-summarizer/backend/pkg/errors"
-	"youtube-video-summarizer/backend/pkg/youtube"
 )
 
 type Service struct {
 	similarityRepo repository.SimilarityRepository
 	embeddingRepo  repository.EmbeddingRepository
 	videoRepo      repository.VideoRepository
-	youtubeClient  *youtube.Client
+	sources        *source.Registry
 	logger         *zap.Logger
 }
 
@@ -26,14 +26,14 @@ func NewService(
 	similarityRepo repository.SimilarityRepository,
 	embeddingRepo repository.EmbeddingRepository,
 	videoRepo repository.VideoRepository,
-	youtubeClient *youtube.Client,
+	sources *source.Registry,
 	logger *zap.Logger,
 ) *Service {
 	return &Service{
 		similarityRepo: similarityRepo,
 		embeddingRepo:  embeddingRepo,
 		videoRepo:      videoRepo,
-		youtubeClient:  youtubeClient,
+		sources:        sources,
 		logger:         logger,
 	}
 }
@@ -90,7 +90,6 @@ func (s *Service) getSimilarityByType(ctx context.Context, videoID1, videoID2 uu
 }
 
 func (s *Service) FindSimilarVideos(ctx context.Context, videoID uuid.UUID, limit int, minThreshold float64) ([]models.SimilarVideo, error) {
-	// Get video to get YouTube ID
 	video, err := s.videoRepo.GetByID(ctx, videoID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -99,77 +98,74 @@ func (s *Service) FindSimilarVideos(ctx context.Context, videoID uuid.UUID, limi
 		return nil, errors.Wrap(err, errors.ErrorCodeVideoNotFound, errors.SubCodeVideoNotFound, "Failed to get video")
 	}
 
-	// Fetch similar videos directly from YouTube
-	if s.youtubeClient == nil {
-		return nil, errors.New(
-			errors.ErrorCodeYouTubeAPI,
-			errors.SubCodeYouTubeAPIFailed,
-			"YouTube client not configured",
-		)
+	if s.sources == nil {
+		return nil, errors.ErrInternalError("Media sources not configured", nil)
 	}
-
-	youtubeVideos, err := s.fetchSimilarFromYouTube(ctx, video.YouTubeID, limit)
+	src, err := s.sources.For(video)
 	if err != nil {
-		return nil, err
+		return nil, errors.NewWithError(errors.ErrorCodeBadRequest, errors.SubCodeInvalidInput, "Unsupported source", err)
 	}
 
-	return youtubeVideos, nil
-}
-
-// fetchSimilarFromYouTube fetches similar videos from YouTube API
-func (s *Service) fetchSimilarFromYouTube(ctx context.Context, youtubeID string, limit int) ([]models.SimilarVideo, error) {
-	if limit <= 0 {
+	// Uploads and podcasts have nothing to search related items from
+	finder, ok := src.(source.RelatedFinder)
+	if !ok || limit <= 0 {
 		return []models.SimilarVideo{}, nil
 	}
 
-	s.logger.Info("Fetching similar videos from YouTube",
-		zap.String("youtube_id", youtubeID),
+	return s.fetchRelated(ctx, src.Type(), finder, video, limit)
+}
+
+// fetchRelated asks the video's source for related items, using the stored video for items
+// already in the library
+func (s *Service) fetchRelated(ctx context.Context, sourceType string, finder source.RelatedFinder, video *models.Video, limit int) ([]models.SimilarVideo, error) {
+	s.logger.Info("Fetching related videos from source",
+		zap.String("video_id", video.ID.String()),
+		zap.String("source_type", sourceType),
 		zap.Int("limit", limit))
 
-	youtubeVideos, err := s.youtubeClient.SearchRelatedVideos(ctx, youtubeID, limit)
+	related, err := finder.FindRelated(ctx, video, limit)
 	if err != nil {
-		return nil, errors.Wrap(err, errors.ErrorCodeYouTubeAPI, errors.SubCodeYouTubeAPIFailed, "Failed to fetch related videos from YouTube")
-	}
-
-	// Convert YouTube VideoInfo to SimilarVideo
-	results := make([]models.SimilarVideo, 0, len(youtubeVideos))
-	for _, ytVideo := range youtubeVideos {
-		// Check if video already exists in database
-		existingVideo, err := s.videoRepo.GetByYouTubeID(ctx, ytVideo.ID)
-		if err == nil && existingVideo != nil {
-			// Video exists in database, use it
-			results = append(results, models.SimilarVideo{
-				Video:           existingVideo,
-				SimilarityScore: 0.0, // YouTube relevance, not calculated similarity
-				ComparisonType:  "youtube",
-			})
+		if appErr, ok := err.(*errors.AppError); ok {
+			return nil, appErr
+		}
+		return nil, errors.Wrap(err, errors.ErrorCodeYouTubeAPI, errors.SubCodeYouTubeAPIFailed, "Failed to fetch related videos")
+	}
+
+	results := make([]models.SimilarVideo, 0, len(related))
+	for _, meta := range related {
+		// Relevance comes from the source, not a calculated similarity
+		result := models.SimilarVideo{SimilarityScore: 0.0, ComparisonType: sourceType}
+
+		if existing, err := s.videoRepo.GetBySourceID(ctx, sourceType, meta.SourceID); err == nil && existing != nil {
+			result.Video = existing
 		} else {
-			// Create a minimal video object from YouTube data
-			video := &models.Video{
-				YouTubeID:    ytVideo.ID,
-				Title:        ytVideo.Title,
-				Description:  ytVideo.Description,
-				ChannelID:    ytVideo.ChannelID,
-				ChannelName:  ytVideo.ChannelName,
-				Duration:     ytVideo.Duration,
-				ViewCount:    ytVideo.ViewCount,
-				LikeCount:    ytVideo.LikeCount,
-				PublishedAt:  ytVideo.PublishedAt,
-				ThumbnailURL: ytVideo.ThumbnailURL,
-				Tags:         ytVideo.Tags,
-				Category:     ytVideo.Category,
-				Status:       "pending", // Not in database yet
+			// A minimal video that is not in the database yet
+			result.Video = &models.Video{
+				SourceType:   sourceType,
+				SourceID:     meta.SourceID,
+				SourceURL:    meta.SourceURL,
+				Title:        meta.Title,
+				Description:  meta.Description,
+				ChannelID:    meta.ChannelID,
+				ChannelName:  meta.ChannelName,
+				Duration:     meta.Duration,
+				ViewCount:    meta.ViewCount,
+				LikeCount:    meta.LikeCount,
+				PublishedAt:  meta.PublishedAt,
+				ThumbnailURL: meta.ThumbnailURL,
+				Tags:         meta.Tags,
+				Category:     meta.Category,
+				Status:       "pending",
+			}
+			if sourceType == models.SourceTypeYouTube {
+				result.Video.YouTubeID = meta.SourceID
 			}
-			results = append(results, models.SimilarVideo{
-				Video:           video,
-				SimilarityScore: 0.0, // YouTube relevance, not calculated similarity
-				ComparisonType:  "youtube",
-			})
 		}
+		results = append(results, result)
 	}
 
-	s.logger.Info("Fetched similar videos from YouTube",
-		zap.String("youtube_id", youtubeID),
+	s.logger.Info("Fetched related videos from source",
+		zap.String("video_id", video.ID.String()),
 		zap.Int("count", len(results)))
 
 	return results, nil
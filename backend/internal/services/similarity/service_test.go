@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -11,6 +12,7 @@ import (
 	"go.uber.org/zap"
 	"youtube-video-summarizer/backend/internal/models"
 	"youtube-video-summarizer/backend/internal/repository"
+	"youtube-video-summarizer/backend/internal/services/source"
 )
 
 type MockSimilarityRepository struct {
@@ -93,6 +95,14 @@ func (m *MockVideoRepository) GetByYouTubeID(ctx context.Context, youtubeID stri
 	return args.Get(0).(*models.Video), args.Error(1)
 }
 
+func (m *MockVideoRepository) GetBySourceID(ctx context.Context, sourceType, sourceID string) (*models.Video, error) {
+	args := m.Called(ctx, sourceType, sourceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Video), args.Error(1)
+}
+
 func (m *MockVideoRepository) Update(ctx context.Context, video *models.Video) error {
 	args := m.Called(ctx, video)
 	return args.Error(0)
@@ -103,6 +113,16 @@ func (m *MockVideoRepository) UpdateStatus(ctx context.Context, id uuid.UUID, st
 	return args.Error(0)
 }
 
+func (m *MockVideoRepository) ListScheduledDue(ctx context.Context, before time.Time) ([]*models.Video, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).([]*models.Video), args.Error(1)
+}
+
+func (m *MockVideoRepository) UpdateBroadcast(ctx context.Context, video *models.Video) error {
+	args := m.Called(ctx, video)
+	return args.Error(0)
+}
+
 func (m *MockVideoRepository) List(ctx context.Context, limit, offset int) ([]*models.Video, int, error) {
 	args := m.Called(ctx, limit, offset)
 	if args.Get(0) == nil {
@@ -160,30 +180,68 @@ func TestService_CalculateSimilarity(t *testing.T) {
 	mockEmbeddingRepo.AssertExpectations(t)
 }
 
+// relatedSource is a media source that suggests a fixed list of related items
+type relatedSource struct {
+	sourceType string
+	related    []*source.Metadata
+}
+
+func (s *relatedSource) Type() string { return s.sourceType }
+
+func (s *relatedSource) Identify(ref source.Reference) (string, bool) { return "", false }
+
+func (s *relatedSource) Resolve(ctx context.Context, ref source.Reference) (*source.Metadata, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (s *relatedSource) FetchCaptions(ctx context.Context, video *models.Video, language string) (*source.Captions, error) {
+	return nil, source.ErrNoCaptions
+}
+
+func (s *relatedSource) DownloadAudio(ctx context.Context, video *models.Video, dir string) (string, func(), error) {
+	return "", nil, fmt.Errorf("not implemented")
+}
+
+func (s *relatedSource) FindRelated(ctx context.Context, video *models.Video, limit int) ([]*source.Metadata, error) {
+	return s.related, nil
+}
+
 func TestService_FindSimilarVideos(t *testing.T) {
 	mockSimilarityRepo := new(MockSimilarityRepository)
 	mockEmbeddingRepo := new(MockEmbeddingRepository)
 	mockVideoRepo := new(MockVideoRepository)
 	logger := zap.NewNop()
 
-	// Service requires YouTube client, so we expect an error when it's nil
-	service := NewService(mockSimilarityRepo, mockEmbeddingRepo, mockVideoRepo, nil, logger)
+	sources := source.NewRegistry(
+		&relatedSource{sourceType: models.SourceTypeYouTube, related: []*source.Metadata{
+			{SourceID: "known000001", Title: "Known"},
+			{SourceID: "unknown0002", Title: "Unknown"},
+		}},
+		source.NewUploadSource(),
+	)
+	service := NewService(mockSimilarityRepo, mockEmbeddingRepo, mockVideoRepo, sources, logger)
 
 	ctx := context.Background()
 	videoID := uuid.New()
+	known := &models.Video{ID: uuid.New(), YouTubeID: "known000001", Title: "Known"}
+	mockVideoRepo.On("GetByID", ctx, videoID).Return(&models.Video{ID: videoID, YouTubeID: "test123"}, nil)
+	mockVideoRepo.On("GetBySourceID", ctx, models.SourceTypeYouTube, "known000001").Return(known, nil)
+	mockVideoRepo.On("GetBySourceID", ctx, models.SourceTypeYouTube, "unknown0002").Return(nil, fmt.Errorf("record not found"))
 
-	// Mock GetByID call (service calls this first)
-	expectedVideo := &models.Video{
-		ID:        videoID,
-		YouTubeID: "test123",
+	similar, err := service.FindSimilarVideos(ctx, videoID, 10, 0.5)
+	assert.NoError(t, err)
+	if assert.Len(t, similar, 2) {
+		assert.Same(t, known, similar[0].Video)
+		assert.Equal(t, "unknown0002", similar[1].Video.YouTubeID)
+		assert.Equal(t, "youtube", similar[1].ComparisonType)
 	}
-	mockVideoRepo.On("GetByID", ctx, videoID).Return(expectedVideo, nil)
 
-	// Since YouTube client is nil, we expect an error
-	_, err := service.FindSimilarVideos(ctx, videoID, 10, 0.5)
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "YouTube client not configured")
-	
+	// Sources without related items return an empty list
+	uploadID := uuid.New()
+	mockVideoRepo.On("GetByID", ctx, uploadID).Return(&models.Video{ID: uploadID, SourceType: models.SourceTypeUpload}, nil)
+	similar, err = service.FindSimilarVideos(ctx, uploadID, 10, 0.5)
+	assert.NoError(t, err)
+	assert.Empty(t, similar)
+
 	mockVideoRepo.AssertExpectations(t)
 }
-
@@ -0,0 +1,253 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/pkg/errors"
+	"youtube-video-summarizer/backend/pkg/podcast"
+)
+
+// maxTranscriptSize limits how much of a published podcast transcript is read
+const maxTranscriptSize = 10 << 20
+
+// audioExtensions are the enclosure file extensions Whisper recognises
+var audioExtensions = map[string]bool{
+	".mp3":  true,
+	".m4a":  true,
+	".mp4":  true,
+	".ogg":  true,
+	".opus": true,
+	".wav":  true,
+	".flac": true,
+	".webm": true,
+}
+
+// PodcastSource reads episodes from RSS/Atom podcast feeds. ChannelID holds the feed URL and
+// SourceURL the episode enclosure, so captions and audio can be fetched again later. GUIDs are only
+// unique within a feed, so SourceID is derived from the feed URL and the GUID (see episodeSourceID).
+type PodcastSource struct {
+	client *podcast.Client
+	logger *zap.Logger
+}
+
+func NewPodcastSource(client *podcast.Client, logger *zap.Logger) *PodcastSource {
+	return &PodcastSource{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (s *PodcastSource) Type() string {
+	return models.SourceTypePodcast
+}
+
+// Identify knows the episode only when its GUID was given; otherwise the feed must be read
+func (s *PodcastSource) Identify(ref Reference) (string, bool) {
+	if ref.EpisodeID == "" {
+		return "", false
+	}
+	feedURL, err := normalizeFeedURL(ref.URL)
+	if err != nil {
+		return "", false
+	}
+	return episodeSourceID(feedURL, ref.EpisodeID), true
+}
+
+// episodeSourceID identifies an episode across feeds: a SHA-256 of the feed URL and episode GUID
+func episodeSourceID(feedURL, guid string) string {
+	sum := sha256.Sum256([]byte(feedURL + "\n" + guid))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *PodcastSource) Resolve(ctx context.Context, ref Reference) (*Metadata, error) {
+	feed, err := s.GetFeed(ctx, ref.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	var episode *podcast.Episode
+	var ok bool
+	if ref.EpisodeID != "" {
+		if episode, ok = feed.FindEpisode(ref.EpisodeID); !ok {
+			return nil, errors.ErrPodcastEpisodeNotFound(ref.EpisodeID)
+		}
+	} else if episode, ok = feed.Latest(); !ok {
+		return nil, errors.ErrPodcastFeedInvalid(ref.URL, fmt.Errorf("feed has no episodes with audio"))
+	}
+
+	channelName := feed.Title
+	if channelName == "" {
+		channelName = feed.Author
+	}
+	publishedAt := episode.PublishedAt
+	if publishedAt.IsZero() {
+		publishedAt = time.Now()
+	}
+
+	return &Metadata{
+		SourceID:     episodeSourceID(feed.URL, episode.GUID),
+		SourceURL:    episode.AudioURL,
+		Title:        episode.Title,
+		Description:  episode.Description,
+		ChannelID:    feed.URL,
+		ChannelName:  channelName,
+		Duration:     episode.Duration,
+		PublishedAt:  publishedAt,
+		ThumbnailURL: episode.ImageURL,
+		Category:     "Podcast",
+	}, nil
+}
+
+// GetFeed fetches and parses a podcast feed
+func (s *PodcastSource) GetFeed(ctx context.Context, feedURL string) (*podcast.Feed, error) {
+	normalized, err := normalizeFeedURL(feedURL)
+	if err != nil {
+		return nil, errors.ErrPodcastFeedInvalid(feedURL, err)
+	}
+
+	feed, err := s.client.GetFeed(ctx, normalized)
+	if err != nil {
+		return nil, errors.ErrPodcastFeedInvalid(feedURL, err)
+	}
+	return feed, nil
+}
+
+// normalizeFeedURL checks that feedURL is an http(s) URL and returns it in the form feeds are fetched
+// and stored with
+func normalizeFeedURL(feedURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(feedURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("not an http(s) URL")
+	}
+	return u.String(), nil
+}
+
+// findEpisode returns the feed episode a stored video was created from
+func findEpisode(feed *podcast.Feed, video *models.Video) (*podcast.Episode, bool) {
+	for i := range feed.Episodes {
+		if episodeSourceID(feed.URL, feed.Episodes[i].GUID) == video.SourceID {
+			return &feed.Episodes[i], true
+		}
+	}
+	// Videos created before source IDs included the feed URL store the bare GUID until the
+	// startup migration rekeys them
+	if episode, ok := feed.FindEpisode(video.SourceID); ok {
+		return episode, true
+	}
+	if video.SourceURL != "" {
+		return feed.FindEpisode(video.SourceURL)
+	}
+	return nil, false
+}
+
+// FetchCaptions uses a WebVTT podcast:transcript published in the feed, if any
+func (s *PodcastSource) FetchCaptions(ctx context.Context, video *models.Video, language string) (*Captions, error) {
+	feed, err := s.GetFeed(ctx, video.ChannelID)
+	if err != nil {
+		return nil, err
+	}
+	episode, ok := findEpisode(feed, video)
+	if !ok {
+		return nil, ErrNoCaptions
+	}
+
+	var chosen *podcast.Transcript
+	for i := range episode.Transcripts {
+		t := &episode.Transcripts[i]
+		if t.Type != "text/vtt" {
+			continue
+		}
+		if language != "" && !strings.HasPrefix(strings.ToLower(t.Language), strings.ToLower(language)) {
+			continue
+		}
+		chosen = t
+		break
+	}
+	if chosen == nil {
+		return nil, ErrNoCaptions
+	}
+
+	s.logger.Info("Fetching podcast transcript",
+		zap.String("video_id", video.ID.String()),
+		zap.String("url", chosen.URL))
+
+	var buf bytes.Buffer
+	if _, err := s.client.Download(ctx, chosen.URL, &limitedWriter{w: &buf, remaining: maxTranscriptSize}); err != nil {
+		return nil, fmt.Errorf("failed to download podcast transcript: %w", err)
+	}
+
+	return &Captions{
+		Format:   "vtt",
+		Language: chosen.Language,
+		Content:  buf.String(),
+	}, nil
+}
+
+// DownloadAudio downloads the episode enclosure into dir
+func (s *PodcastSource) DownloadAudio(ctx context.Context, video *models.Video, dir string) (string, func(), error) {
+	if video.SourceURL == "" {
+		return "", nil, fmt.Errorf("podcast episode %s has no audio URL", video.ID)
+	}
+
+	audioPath := filepath.Join(dir, uuid.New().String()+enclosureExtension(video.SourceURL))
+	file, err := os.Create(audioPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create audio file: %w", err)
+	}
+
+	s.logger.Info("Downloading podcast audio",
+		zap.String("video_id", video.ID.String()),
+		zap.String("url", video.SourceURL),
+		zap.String("output_path", audioPath))
+
+	size, err := s.client.Download(ctx, video.SourceURL, file)
+	file.Close()
+	if err != nil {
+		os.Remove(audioPath)
+		return "", nil, fmt.Errorf("failed to download podcast audio: %w", err)
+	}
+
+	s.logger.Info("Podcast audio downloaded",
+		zap.String("video_id", video.ID.String()),
+		zap.Int64("size_bytes", size))
+
+	return audioPath, removeFile(audioPath, s.logger), nil
+}
+
+// enclosureExtension picks the file extension from the enclosure URL path, defaulting to .mp3
+func enclosureExtension(enclosureURL string) string {
+	if u, err := url.Parse(enclosureURL); err == nil {
+		if ext := strings.ToLower(path.Ext(u.Path)); audioExtensions[ext] {
+			return ext
+		}
+	}
+	return ".mp3"
+}
+
+// limitedWriter fails once more than remaining bytes are written
+type limitedWriter struct {
+	w         io.Writer
+	remaining int64
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) > l.remaining {
+		return 0, fmt.Errorf("transcript exceeds %d bytes", maxTranscriptSize)
+	}
+	l.remaining -= int64(len(p))
+	return l.w.Write(p)
+}
@@ -0,0 +1,149 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/pkg/errors"
+	"youtube-video-summarizer/backend/pkg/podcast"
+)
+
+const feedTemplate = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd" xmlns:podcast="https://podcastindex.org/namespace/1.0">
+  <channel>
+    <title>Test Cast</title>
+    <item>
+      <title>Newest</title>
+      <guid>ep-2</guid>
+      <pubDate>Tue, 14 May 2024 10:00:00 +0000</pubDate>
+      <itunes:duration>25:00</itunes:duration>
+      <enclosure url="%[1]s/audio/ep2.m4a" type="audio/mp4" length="5"/>
+      <podcast:transcript url="%[1]s/ep2.json" type="application/json"/>
+      <podcast:transcript url="%[1]s/ep2.vtt" type="text/vtt" language="en"/>
+    </item>
+    <item>
+      <title>Older</title>
+      <guid>ep-1</guid>
+      <pubDate>Mon, 6 May 2024 10:00:00 +0000</pubDate>
+      <enclosure url="%[1]s/audio/ep1.mp3" type="audio/mpeg" length="5"/>
+    </item>
+  </channel>
+</rss>`
+
+func newTestFeedServer(t *testing.T) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/feed.xml":
+			fmt.Fprintf(w, feedTemplate, server.URL)
+		case "/ep2.vtt":
+			w.Write([]byte("WEBVTT\n\n00:00:00.000 --> 00:00:02.000\nHello\n"))
+		case "/audio/ep2.m4a":
+			w.Write([]byte("audio"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPodcastSource_Resolve(t *testing.T) {
+	server := newTestFeedServer(t)
+	src := NewPodcastSource(podcast.NewClient(), zap.NewNop())
+	ctx := context.Background()
+
+	meta, err := src.Resolve(ctx, Reference{URL: server.URL + "/feed.xml"})
+	require.NoError(t, err)
+	assert.Equal(t, "Newest", meta.Title, "latest episode is used when none is given")
+	assert.Equal(t, episodeSourceID(server.URL+"/feed.xml", "ep-2"), meta.SourceID)
+	assert.Equal(t, server.URL+"/audio/ep2.m4a", meta.SourceURL)
+	assert.Equal(t, server.URL+"/feed.xml", meta.ChannelID)
+	assert.Equal(t, "Test Cast", meta.ChannelName)
+	assert.Equal(t, 1500, meta.Duration)
+
+	ref := Reference{URL: " " + server.URL + "/feed.xml", EpisodeID: "ep-1"}
+	meta, err = src.Resolve(ctx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, "Older", meta.Title)
+	id, ok := src.Identify(ref)
+	require.True(t, ok)
+	assert.Equal(t, meta.SourceID, id, "Identify and Resolve agree on the episode ID")
+
+	// The same GUID in another feed is a different episode
+	other, _ := src.Identify(Reference{URL: "https://other.example.com/feed.xml", EpisodeID: "ep-1"})
+	assert.NotEqual(t, id, other)
+
+	_, err = src.Resolve(ctx, Reference{URL: server.URL + "/feed.xml", EpisodeID: "missing"})
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok)
+	assert.Equal(t, errors.SubCodePodcastEpisodeNotFound, appErr.SubCode)
+
+	_, err = src.Resolve(ctx, Reference{URL: "ftp://example.com/feed.xml"})
+	appErr, ok = err.(*errors.AppError)
+	require.True(t, ok)
+	assert.Equal(t, errors.SubCodePodcastFeedInvalid, appErr.SubCode)
+}
+
+func TestPodcastSource_FetchCaptionsAndAudio(t *testing.T) {
+	server := newTestFeedServer(t)
+	src := NewPodcastSource(podcast.NewClient(), zap.NewNop())
+	ctx := context.Background()
+
+	video := &models.Video{
+		ID:         uuid.New(),
+		SourceType: models.SourceTypePodcast,
+		SourceID:   episodeSourceID(server.URL+"/feed.xml", "ep-2"),
+		SourceURL:  server.URL + "/audio/ep2.m4a",
+		ChannelID:  server.URL + "/feed.xml",
+	}
+
+	captions, err := src.FetchCaptions(ctx, video, "")
+	require.NoError(t, err)
+	assert.Equal(t, "vtt", captions.Format)
+	assert.Equal(t, "en", captions.Language)
+	assert.Contains(t, captions.Content, "Hello")
+
+	_, err = src.FetchCaptions(ctx, video, "de")
+	assert.ErrorIs(t, err, ErrNoCaptions)
+
+	// Videos stored before source IDs included the feed URL carry the bare GUID
+	legacy := *video
+	legacy.SourceID = "ep-2"
+	_, err = src.FetchCaptions(ctx, &legacy, "")
+	require.NoError(t, err)
+
+	path, cleanup, err := src.DownloadAudio(ctx, video, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, ".m4a", filepath.Ext(path))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "audio", string(data))
+
+	cleanup()
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRegistry_Get(t *testing.T) {
+	registry := NewRegistry(NewUploadSource(), NewPodcastSource(podcast.NewClient(), zap.NewNop()))
+
+	src, err := registry.Get(models.SourceTypePodcast)
+	require.NoError(t, err)
+	assert.Equal(t, models.SourceTypePodcast, src.Type())
+
+	_, err = registry.Get("")
+	assert.Error(t, err, "empty type means YouTube, which is not registered here")
+
+	assert.Equal(t, []string{"podcast", "upload"}, registry.Types())
+}
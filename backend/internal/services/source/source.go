@@ -0,0 +1,125 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/pkg/youtube"
+)
+
+// ErrNoCaptions is returned by FetchCaptions when the source has no captions for the item
+var ErrNoCaptions = fmt.Errorf("no captions available")
+
+// Reference points at a single item of a source: a video URL or ID for YouTube, a feed URL
+// plus an optional episode GUID (latest episode when empty) for podcasts.
+type Reference struct {
+	URL       string
+	EpisodeID string
+}
+
+// Metadata describes an item before it is stored as a models.Video
+type Metadata struct {
+	SourceID     string
+	SourceURL    string
+	Title        string
+	Description  string
+	ChannelID    string
+	ChannelName  string
+	Duration     int // seconds
+	StartTime    int // seconds
+	ViewCount    int64
+	LikeCount    int64
+	PublishedAt  time.Time
+	ThumbnailURL string
+	Tags         []string
+	Category     string
+	Chapters     []youtube.Chapter // from the description, when it lists chapters
+	// LiveBroadcastContent is "upcoming" or "live" for broadcasts that cannot be analyzed yet
+	LiveBroadcastContent string
+	ScheduledStartAt     *time.Time
+}
+
+// Captions are published captions in their original format, parsed by the transcript service
+type Captions struct {
+	Format   string // vtt, or json3/timedtext for captions downloaded natively from YouTube
+	Language string
+	Content  string
+	// AutoGenerated is set for speech recognition captions, when the source can tell
+	AutoGenerated bool
+}
+
+// CaptionTrackLister is implemented by sources that can list caption tracks without downloading them
+type CaptionTrackLister interface {
+	ListCaptionTracks(ctx context.Context, sourceID string) ([]youtube.CaptionTrack, error)
+}
+
+// ChapterFetcher is implemented by sources that can look up chapters the metadata did not
+// include, e.g. chapters YouTube generated automatically
+type ChapterFetcher interface {
+	FetchChapters(ctx context.Context, video *models.Video) ([]youtube.Chapter, error)
+}
+
+// RelatedFinder is implemented by sources that can suggest items related to a video, e.g.
+// YouTube search results for its title and tags
+type RelatedFinder interface {
+	FindRelated(ctx context.Context, video *models.Video, limit int) ([]*Metadata, error)
+}
+
+// MediaSource is where a video's metadata, captions and audio come from
+type MediaSource interface {
+	// Type is the models.Video SourceType handled by this source
+	Type() string
+	// Identify returns the item ID for ref when it can be derived without a network call
+	Identify(ref Reference) (string, bool)
+	// Resolve fetches the metadata of the item ref points at
+	Resolve(ctx context.Context, ref Reference) (*Metadata, error)
+	// FetchCaptions returns published captions, or ErrNoCaptions
+	FetchCaptions(ctx context.Context, video *models.Video, language string) (*Captions, error)
+	// DownloadAudio makes the video's audio available as a local file under dir. Call cleanup
+	// when done; it only removes files the source created.
+	DownloadAudio(ctx context.Context, video *models.Video, dir string) (path string, cleanup func(), err error)
+}
+
+// Registry looks up media sources by type
+type Registry struct {
+	sources map[string]MediaSource
+}
+
+func NewRegistry(sources ...MediaSource) *Registry {
+	r := &Registry{sources: make(map[string]MediaSource, len(sources))}
+	for _, src := range sources {
+		r.sources[src.Type()] = src
+	}
+	return r
+}
+
+// Get returns the source for sourceType; an empty type means YouTube, as for videos created
+// before sources existed
+func (r *Registry) Get(sourceType string) (MediaSource, error) {
+	if sourceType == "" {
+		sourceType = models.SourceTypeYouTube
+	}
+	src, ok := r.sources[sourceType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported source type: %s", sourceType)
+	}
+	return src, nil
+}
+
+// For returns the source a stored video came from
+func (r *Registry) For(video *models.Video) (MediaSource, error) {
+	return r.Get(video.SourceType)
+}
+
+// Types lists the registered source types
+func (r *Registry) Types() []string {
+	types := make([]string, 0, len(r.sources))
+	for t := range r.sources {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
@@ -0,0 +1,44 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+// UploadSource serves files stored by the upload service. Uploaded videos are created by that
+// service, so there is nothing to resolve, and they never have captions.
+type UploadSource struct{}
+
+func NewUploadSource() *UploadSource {
+	return &UploadSource{}
+}
+
+func (s *UploadSource) Type() string {
+	return models.SourceTypeUpload
+}
+
+func (s *UploadSource) Identify(ref Reference) (string, bool) {
+	return "", false
+}
+
+func (s *UploadSource) Resolve(ctx context.Context, ref Reference) (*Metadata, error) {
+	return nil, fmt.Errorf("uploaded files are added through the upload endpoints")
+}
+
+func (s *UploadSource) FetchCaptions(ctx context.Context, video *models.Video, language string) (*Captions, error) {
+	return nil, ErrNoCaptions
+}
+
+// DownloadAudio returns the stored file itself; cleanup is a no-op so the original is kept
+func (s *UploadSource) DownloadAudio(ctx context.Context, video *models.Video, dir string) (string, func(), error) {
+	if video.MediaPath == "" {
+		return "", nil, fmt.Errorf("uploaded video %s has no media file", video.ID)
+	}
+	if _, err := os.Stat(video.MediaPath); err != nil {
+		return "", nil, fmt.Errorf("uploaded media file not accessible: %w", err)
+	}
+	return video.MediaPath, func() {}, nil
+}
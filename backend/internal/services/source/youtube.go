@@ -0,0 +1,274 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/pkg/downloader"
+	"youtube-video-summarizer/backend/pkg/errors"
+	"youtube-video-summarizer/backend/pkg/youtube"
+)
+
+// YouTubeSource reads metadata from the YouTube Data API, captions from the timedtext endpoint
+// (yt-dlp as a fallback) and audio via yt-dlp
+type YouTubeSource struct {
+	client     *youtube.Client
+	downloader downloader.Downloader
+	logger     *zap.Logger
+}
+
+// defaultCaptionLanguages are asked from yt-dlp when no caption language is requested
+var defaultCaptionLanguages = []string{"en", "en-US", "en-GB", "tr", "de", "fr", "es", "it", "pt", "ru", "ja", "ko", "zh", "ar", "hi"}
+
+func NewYouTubeSource(client *youtube.Client, dl downloader.Downloader, logger *zap.Logger) *YouTubeSource {
+	return &YouTubeSource{
+		client:     client,
+		downloader: dl,
+		logger:     logger,
+	}
+}
+
+func (s *YouTubeSource) Type() string {
+	return models.SourceTypeYouTube
+}
+
+func (s *YouTubeSource) Identify(ref Reference) (string, bool) {
+	parsed, err := youtube.ParseVideoURL(ref.URL)
+	if err != nil {
+		return "", false
+	}
+	return parsed.VideoID, true
+}
+
+func (s *YouTubeSource) Resolve(ctx context.Context, ref Reference) (*Metadata, error) {
+	parsed, err := youtube.ParseVideoURL(ref.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid YouTube URL: %w", err)
+	}
+
+	info, err := s.client.GetVideoInfo(ctx, parsed.VideoID)
+	if errors.Is(err, youtube.ErrQuotaExceeded) {
+		return nil, errors.ErrYouTubeQuotaExceeded(err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch video info: %w", err)
+	}
+
+	meta := metadataFromInfo(info)
+	meta.StartTime = parsed.StartTime
+	return meta, nil
+}
+
+// FindRelated searches YouTube for videos related to video
+func (s *YouTubeSource) FindRelated(ctx context.Context, video *models.Video, limit int) ([]*Metadata, error) {
+	infos, err := s.client.SearchRelatedVideos(ctx, video.YouTubeID, limit)
+	if errors.Is(err, youtube.ErrQuotaExceeded) {
+		return nil, errors.ErrYouTubeQuotaExceeded(err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to search related videos: %w", err)
+	}
+
+	related := make([]*Metadata, 0, len(infos))
+	for i := range infos {
+		related = append(related, metadataFromInfo(&infos[i]))
+	}
+	return related, nil
+}
+
+func metadataFromInfo(info *youtube.VideoInfo) *Metadata {
+	return &Metadata{
+		SourceID:     info.ID,
+		Title:        info.Title,
+		Description:  info.Description,
+		ChannelID:    info.ChannelID,
+		ChannelName:  info.ChannelName,
+		Duration:     info.Duration,
+		ViewCount:    info.ViewCount,
+		LikeCount:    info.LikeCount,
+		PublishedAt:  info.PublishedAt,
+		ThumbnailURL: info.ThumbnailURL,
+		Tags:         info.Tags,
+		Category:     info.Category,
+		Chapters:     youtube.ParseDescriptionChapters(info.Description, info.Duration),
+
+		LiveBroadcastContent: info.LiveBroadcastContent,
+		ScheduledStartAt:     info.ScheduledStartTime,
+	}
+}
+
+// FetchChapters reads the chapter list yt-dlp reports, which also covers chapters YouTube
+// generated itself and never wrote into the description
+func (s *YouTubeSource) FetchChapters(ctx context.Context, video *models.Video) ([]youtube.Chapter, error) {
+	data, err := s.downloader.Metadata(ctx, watchURL(video.YouTubeID))
+	if err != nil {
+		s.logger.Debug("Failed to read chapters",
+			zap.String("youtube_id", video.YouTubeID),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to read chapters: %w", err)
+	}
+
+	return parseYtDlpChapters(data)
+}
+
+// parseYtDlpChapters extracts the "chapters" list from yt-dlp's JSON metadata
+func parseYtDlpChapters(data []byte) ([]youtube.Chapter, error) {
+	var info struct {
+		Chapters []struct {
+			Title     string  `json:"title"`
+			StartTime float64 `json:"start_time"`
+			EndTime   float64 `json:"end_time"`
+		} `json:"chapters"`
+	}
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("invalid yt-dlp metadata: %w", err)
+	}
+
+	chapters := make([]youtube.Chapter, 0, len(info.Chapters))
+	for _, c := range info.Chapters {
+		chapters = append(chapters, youtube.Chapter{
+			Title: strings.TrimSpace(c.Title),
+			Start: c.StartTime,
+			End:   c.EndTime,
+		})
+	}
+	return chapters, nil
+}
+
+// FetchCaptions downloads manual or auto-generated captions directly from YouTube. yt-dlp is
+// only used when the native lookup fails (e.g. the watch page layout changed or is blocked).
+func (s *YouTubeSource) FetchCaptions(ctx context.Context, video *models.Video, languageCode string) (*Captions, error) {
+	captions, err := s.fetchNativeCaptions(ctx, video, languageCode)
+	if err == nil || err == ErrNoCaptions {
+		return captions, err
+	}
+
+	s.logger.Warn("Native caption fetch failed, falling back to yt-dlp",
+		zap.String("youtube_id", video.YouTubeID),
+		zap.Error(err))
+	return s.fetchYtDlpCaptions(ctx, video, languageCode)
+}
+
+// ListCaptionTracks lists the manual and auto-generated caption tracks of a video
+func (s *YouTubeSource) ListCaptionTracks(ctx context.Context, youtubeID string) ([]youtube.CaptionTrack, error) {
+	return s.client.GetCaptions(ctx, youtubeID)
+}
+
+// fetchNativeCaptions discovers the caption tracks and downloads the best match as json3
+func (s *YouTubeSource) fetchNativeCaptions(ctx context.Context, video *models.Video, languageCode string) (*Captions, error) {
+	tracks, err := s.ListCaptionTracks(ctx, video.YouTubeID)
+	if err != nil {
+		return nil, err
+	}
+
+	track, ok := youtube.SelectCaptionTrack(tracks, languageCode)
+	if !ok {
+		s.logger.Debug("No matching caption track",
+			zap.String("youtube_id", video.YouTubeID),
+			zap.String("language", languageCode),
+			zap.Int("tracks", len(tracks)))
+		return nil, ErrNoCaptions
+	}
+
+	s.logger.Info("Fetching YouTube captions",
+		zap.String("youtube_id", video.YouTubeID),
+		zap.String("language", track.Language),
+		zap.Bool("auto_generated", track.AutoGenerated))
+
+	file, err := s.client.DownloadCaptions(ctx, track)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Captions{
+		Format:        file.Format,
+		Language:      file.Language,
+		Content:       string(file.Data),
+		AutoGenerated: track.AutoGenerated,
+	}, nil
+}
+
+// fetchYtDlpCaptions downloads captions as WebVTT with yt-dlp
+func (s *YouTubeSource) fetchYtDlpCaptions(ctx context.Context, video *models.Video, languageCode string) (*Captions, error) {
+	s.logger.Info("Fetching YouTube captions with yt-dlp",
+		zap.String("youtube_id", video.YouTubeID),
+		zap.String("language", languageCode))
+
+	// If language is specified, use it; otherwise prefer common languages
+	languages := defaultCaptionLanguages
+	if languageCode != "" {
+		languages = []string{languageCode}
+	}
+
+	subtitles, err := s.downloader.DownloadSubtitles(ctx, watchURL(video.YouTubeID), languages)
+	if err == downloader.ErrNoSubtitles {
+		return nil, ErrNoCaptions
+	}
+	if err != nil {
+		s.logger.Debug("Failed to download captions",
+			zap.String("youtube_id", video.YouTubeID),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to download captions: %w", err)
+	}
+
+	return &Captions{
+		Format:   "vtt",
+		Language: subtitles.Language,
+		Content:  string(subtitles.Content),
+	}, nil
+}
+
+// DownloadAudio extracts the audio track as mp3 with yt-dlp
+func (s *YouTubeSource) DownloadAudio(ctx context.Context, video *models.Video, dir string) (string, func(), error) {
+	youtubeID := video.YouTubeID
+
+	s.logger.Info("Downloading audio",
+		zap.String("youtube_id", youtubeID),
+		zap.String("dir", dir))
+
+	audioPath, err := s.downloader.DownloadAudio(ctx, watchURL(youtubeID), dir, youtubeID)
+	if err != nil {
+		s.logger.Error("yt-dlp failed",
+			zap.String("youtube_id", youtubeID),
+			zap.Error(err))
+		return "", nil, errors.NewWithError(
+			errors.ErrorCodeYouTubeDownload,
+			errors.SubCodeYouTubeDownloadFailed,
+			"Failed to download audio file",
+			err,
+		)
+	}
+
+	// Verify file exists and get size
+	info, err := os.Stat(audioPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("downloaded file not accessible: %w", err)
+	}
+
+	s.logger.Info("Audio downloaded successfully",
+		zap.String("youtube_id", youtubeID),
+		zap.String("path", audioPath),
+		zap.Int64("size_bytes", info.Size()))
+
+	return audioPath, removeFile(audioPath, s.logger), nil
+}
+
+func watchURL(youtubeID string) string {
+	return fmt.Sprintf("https://www.youtube.com/watch?v=%s", youtubeID)
+}
+
+// removeFile returns a cleanup func that deletes a temporary download
+func removeFile(path string, logger *zap.Logger) func() {
+	return func() {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			logger.Warn("Failed to cleanup audio file", zap.String("path", path), zap.Error(err))
+			return
+		}
+		logger.Debug("Audio file cleaned up", zap.String("path", path))
+	}
+}
@@ -0,0 +1,27 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"youtube-video-summarizer/backend/pkg/youtube"
+)
+
+func TestParseYtDlpChapters(t *testing.T) {
+	data := []byte(`{"id": "dQw4w9WgXcQ", "duration": 212, "chapters": [
+		{"start_time": 0.0, "title": "Intro ", "end_time": 18.5},
+		{"start_time": 18.5, "title": "Chorus", "end_time": 212.0}
+	]}`)
+
+	chapters, err := parseYtDlpChapters(data)
+	require.NoError(t, err)
+	assert.Equal(t, []youtube.Chapter{
+		{Title: "Intro", Start: 0, End: 18.5},
+		{Title: "Chorus", Start: 18.5, End: 212},
+	}, chapters)
+
+	chapters, err = parseYtDlpChapters([]byte(`{"id": "x", "chapters": null}`))
+	require.NoError(t, err)
+	assert.Empty(t, chapters)
+}
@@ -0,0 +1,307 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/internal/repository"
+	"youtube-video-summarizer/backend/pkg/errors"
+	"youtube-video-summarizer/backend/pkg/youtube"
+)
+
+const (
+	// defaultRefreshInterval is used when no positive interval is configured
+	defaultRefreshInterval = 24 * time.Hour
+	// defaultBatchSize is used when no positive batch size is configured
+	defaultBatchSize = 50
+)
+
+// YouTubeClient is the subset of youtube.Client used to re-fetch video metadata
+type YouTubeClient interface {
+	GetVideoInfo(ctx context.Context, videoID string) (*youtube.VideoInfo, error)
+	GetVideosInfo(ctx context.Context, videoIDs []string) (map[string]*youtube.VideoInfo, error)
+	CheckAvailability(ctx context.Context, videoID string) (string, error)
+}
+
+// Stats is the refresh history of a video
+type Stats struct {
+	VideoID        uuid.UUID                     `json:"video_id"`
+	ViewCount      int64                         `json:"view_count"`
+	LikeCount      int64                         `json:"like_count"`
+	Availability   string                        `json:"availability"`
+	StatsUpdatedAt *time.Time                    `json:"stats_updated_at,omitempty"`
+	Snapshots      []*models.VideoStatsSnapshot  `json:"snapshots"`
+	Changes        []*models.VideoMetadataChange `json:"changes"`
+}
+
+type Service struct {
+	videoRepo     repository.VideoRepository
+	statsRepo     repository.VideoStatsRepository
+	youtubeClient YouTubeClient
+	interval      time.Duration
+	batchSize     int
+	logger        *zap.Logger
+}
+
+func NewService(
+	videoRepo repository.VideoRepository,
+	statsRepo repository.VideoStatsRepository,
+	youtubeClient YouTubeClient,
+	interval time.Duration,
+	batchSize int,
+	logger *zap.Logger,
+) *Service {
+	if interval <= 0 {
+		interval = defaultRefreshInterval
+	}
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	return &Service{
+		videoRepo:     videoRepo,
+		statsRepo:     statsRepo,
+		youtubeClient: youtubeClient,
+		interval:      interval,
+		batchSize:     batchSize,
+		logger:        logger,
+	}
+}
+
+// RefreshDue refreshes every YouTube video whose metadata is older than the interval, one batch
+// (a single videos.list request) at a time. It stops early when a lookup fails, e.g. because the
+// daily quota is used up; the remaining videos are picked up by the next run.
+func (s *Service) RefreshDue(ctx context.Context) {
+	before := time.Now().Add(-s.interval)
+	due, refreshed := 0, 0
+	for ctx.Err() == nil {
+		listed, ok, err := s.refreshBatch(ctx, before)
+		due += listed
+		refreshed += ok
+		if err != nil {
+			s.logger.Warn("Stopping metadata refresh", zap.Error(err))
+			break
+		}
+		// Every listed video was refreshed or marked as attempted, so the next batch is new
+		if listed < s.batchSize {
+			break
+		}
+	}
+
+	if due > 0 {
+		s.logger.Info("Video metadata refreshed",
+			zap.Int("due", due),
+			zap.Int("refreshed", refreshed))
+	}
+}
+
+// refreshBatch refreshes up to batchSize due videos and returns how many were listed and refreshed.
+// Videos that fail on their own are marked as attempted so they do not block the queue.
+func (s *Service) refreshBatch(ctx context.Context, before time.Time) (int, int, error) {
+	videos, err := s.statsRepo.ListDueForRefresh(ctx, before, s.batchSize)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list videos due for refresh: %w", err)
+	}
+	if len(videos) == 0 {
+		return 0, 0, nil
+	}
+
+	youtubeIDs := make([]string, 0, len(videos))
+	for _, video := range videos {
+		youtubeIDs = append(youtubeIDs, video.YouTubeID)
+	}
+	// Snapshots are stamped with the current time, so they must not come from the response cache
+	infos, err := s.youtubeClient.GetVideosInfo(youtube.WithoutCache(ctx), youtubeIDs)
+	if err != nil {
+		return len(videos), 0, fmt.Errorf("failed to fetch metadata of %d videos: %w", len(videos), err)
+	}
+
+	refreshed := 0
+	var failed []uuid.UUID
+	for _, video := range videos {
+		if ctx.Err() != nil {
+			return len(videos), refreshed, ctx.Err()
+		}
+		if err := s.apply(ctx, video, infos[video.YouTubeID]); err != nil {
+			s.logger.Warn("Failed to refresh video metadata",
+				zap.String("video_id", video.ID.String()),
+				zap.String("youtube_id", video.YouTubeID),
+				zap.Error(err))
+			failed = append(failed, video.ID)
+			continue
+		}
+		refreshed++
+	}
+
+	if len(failed) > 0 {
+		if err := s.statsRepo.MarkRefreshAttempted(ctx, failed, time.Now()); err != nil {
+			return len(videos), refreshed, fmt.Errorf("failed to record refresh attempts: %w", err)
+		}
+	}
+	return len(videos), refreshed, nil
+}
+
+// Refresh re-fetches a single video on demand
+func (s *Service) Refresh(ctx context.Context, videoID uuid.UUID) (*models.Video, error) {
+	video, err := s.getVideo(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	if video.SourceType != models.SourceTypeYouTube || video.YouTubeID == "" {
+		return nil, errors.New(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidInput,
+			"Statistics are only available for YouTube videos",
+		)
+	}
+	if err := s.RefreshVideo(ctx, video); err != nil {
+		if errors.Is(err, youtube.ErrQuotaExceeded) {
+			return nil, errors.ErrYouTubeQuotaExceeded(err)
+		}
+		return nil, errors.Wrap(err, errors.ErrorCodeYouTubeAPI, errors.SubCodeUnknown, "Failed to refresh video metadata")
+	}
+	return video, nil
+}
+
+// RefreshVideo re-fetches one video and records the result
+func (s *Service) RefreshVideo(ctx context.Context, video *models.Video) error {
+	info, err := s.youtubeClient.GetVideoInfo(youtube.WithoutCache(ctx), video.YouTubeID)
+	if err != nil && err != youtube.ErrVideoNotFound {
+		return err
+	}
+	return s.apply(ctx, video, info)
+}
+
+// apply records a stats snapshot, any title/description edits and availability changes.
+// A nil info means the Data API no longer lists the video; oEmbed then tells private from deleted.
+func (s *Service) apply(ctx context.Context, video *models.Video, info *youtube.VideoInfo) error {
+	now := time.Now()
+
+	if info == nil {
+		availability, err := s.youtubeClient.CheckAvailability(ctx, video.YouTubeID)
+		if err != nil {
+			return fmt.Errorf("failed to check availability: %w", err)
+		}
+		// oEmbed may still serve videos the API hides (e.g. region blocks); keep the current state then
+		if availability != youtube.AvailabilityAvailable {
+			if err := s.recordChange(ctx, video, "availability", video.Availability, availability, now); err != nil {
+				return err
+			}
+			video.Availability = availability
+		}
+		video.StatsUpdatedAt = &now
+		video.StatsAttemptedAt = &now
+		return s.statsRepo.UpdateVideoMetadata(ctx, video)
+	}
+
+	if err := s.statsRepo.CreateSnapshot(ctx, &models.VideoStatsSnapshot{
+		VideoID:    video.ID,
+		ViewCount:  info.ViewCount,
+		LikeCount:  info.LikeCount,
+		CapturedAt: now,
+	}); err != nil {
+		return fmt.Errorf("failed to save stats snapshot: %w", err)
+	}
+
+	if err := s.recordChange(ctx, video, "title", video.Title, info.Title, now); err != nil {
+		return err
+	}
+	if err := s.recordChange(ctx, video, "description", video.Description, info.Description, now); err != nil {
+		return err
+	}
+	if err := s.recordChange(ctx, video, "availability", video.Availability, youtube.AvailabilityAvailable, now); err != nil {
+		return err
+	}
+
+	video.Title = info.Title
+	video.Description = info.Description
+	video.ViewCount = info.ViewCount
+	video.LikeCount = info.LikeCount
+	video.Availability = youtube.AvailabilityAvailable
+	video.StatsUpdatedAt = &now
+	video.StatsAttemptedAt = &now
+
+	return s.statsRepo.UpdateVideoMetadata(ctx, video)
+}
+
+// GetStats returns the snapshots captured since the given time and every recorded change
+func (s *Service) GetStats(ctx context.Context, videoID uuid.UUID, since time.Time) (*Stats, error) {
+	video, err := s.getVideo(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, err := s.statsRepo.ListSnapshots(ctx, videoID, since)
+	if err != nil {
+		return nil, errors.ErrDatabaseError("list stats snapshots", err)
+	}
+	changes, err := s.statsRepo.ListChanges(ctx, videoID)
+	if err != nil {
+		return nil, errors.ErrDatabaseError("list metadata changes", err)
+	}
+
+	availability := video.Availability
+	if availability == "" {
+		availability = youtube.AvailabilityAvailable
+	}
+
+	return &Stats{
+		VideoID:        video.ID,
+		ViewCount:      video.ViewCount,
+		LikeCount:      video.LikeCount,
+		Availability:   availability,
+		StatsUpdatedAt: video.StatsUpdatedAt,
+		Snapshots:      snapshots,
+		Changes:        changes,
+	}, nil
+}
+
+func (s *Service) getVideo(ctx context.Context, videoID uuid.UUID) (*models.Video, error) {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrVideoNotFound(videoID.String())
+		}
+		return nil, err
+	}
+	return video, nil
+}
+
+// recordChange stores a metadata change when the value differs. An empty old availability
+// predates tracking and counts as available.
+func (s *Service) recordChange(ctx context.Context, video *models.Video, field, oldValue, newValue string, now time.Time) error {
+	if field == "availability" && oldValue == "" {
+		oldValue = youtube.AvailabilityAvailable
+	}
+	if oldValue == newValue {
+		return nil
+	}
+
+	s.logger.Info("Video metadata changed",
+		zap.String("video_id", video.ID.String()),
+		zap.String("field", field),
+		zap.String("new_value", truncate(newValue, 100)))
+
+	if err := s.statsRepo.CreateChange(ctx, &models.VideoMetadataChange{
+		VideoID:    video.ID,
+		Field:      field,
+		OldValue:   oldValue,
+		NewValue:   newValue,
+		DetectedAt: now,
+	}); err != nil {
+		return fmt.Errorf("failed to save metadata change: %w", err)
+	}
+	return nil
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max]) + "..."
+}
@@ -0,0 +1,228 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/pkg/youtube"
+)
+
+type MockStatsRepository struct {
+	mock.Mock
+}
+
+func (m *MockStatsRepository) CreateSnapshot(ctx context.Context, snapshot *models.VideoStatsSnapshot) error {
+	args := m.Called(ctx, snapshot)
+	return args.Error(0)
+}
+
+func (m *MockStatsRepository) ListSnapshots(ctx context.Context, videoID uuid.UUID, since time.Time) ([]*models.VideoStatsSnapshot, error) {
+	args := m.Called(ctx, videoID, since)
+	return args.Get(0).([]*models.VideoStatsSnapshot), args.Error(1)
+}
+
+func (m *MockStatsRepository) CreateChange(ctx context.Context, change *models.VideoMetadataChange) error {
+	args := m.Called(ctx, change)
+	return args.Error(0)
+}
+
+func (m *MockStatsRepository) ListChanges(ctx context.Context, videoID uuid.UUID) ([]*models.VideoMetadataChange, error) {
+	args := m.Called(ctx, videoID)
+	return args.Get(0).([]*models.VideoMetadataChange), args.Error(1)
+}
+
+func (m *MockStatsRepository) ListDueForRefresh(ctx context.Context, before time.Time, limit int) ([]*models.Video, error) {
+	args := m.Called(ctx, before, limit)
+	return args.Get(0).([]*models.Video), args.Error(1)
+}
+
+func (m *MockStatsRepository) MarkRefreshAttempted(ctx context.Context, videoIDs []uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, videoIDs, at)
+	return args.Error(0)
+}
+
+func (m *MockStatsRepository) UpdateVideoMetadata(ctx context.Context, video *models.Video) error {
+	args := m.Called(ctx, video)
+	return args.Error(0)
+}
+
+type MockYouTubeClient struct {
+	mock.Mock
+}
+
+func (m *MockYouTubeClient) GetVideoInfo(ctx context.Context, videoID string) (*youtube.VideoInfo, error) {
+	args := m.Called(ctx, videoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*youtube.VideoInfo), args.Error(1)
+}
+
+func (m *MockYouTubeClient) GetVideosInfo(ctx context.Context, videoIDs []string) (map[string]*youtube.VideoInfo, error) {
+	args := m.Called(ctx, videoIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]*youtube.VideoInfo), args.Error(1)
+}
+
+func (m *MockYouTubeClient) CheckAvailability(ctx context.Context, videoID string) (string, error) {
+	args := m.Called(ctx, videoID)
+	return args.String(0), args.Error(1)
+}
+
+func TestService_RefreshVideo(t *testing.T) {
+	statsRepo := new(MockStatsRepository)
+	youtubeClient := new(MockYouTubeClient)
+	service := NewService(nil, statsRepo, youtubeClient, time.Hour, 10, zap.NewNop())
+	ctx := context.Background()
+
+	video := &models.Video{
+		ID:          uuid.New(),
+		YouTubeID:   "dQw4w9WgXcQ",
+		SourceType:  models.SourceTypeYouTube,
+		Title:       "Old title",
+		Description: "Same description",
+		ViewCount:   10,
+	}
+
+	youtubeClient.On("GetVideoInfo", youtube.WithoutCache(ctx), "dQw4w9WgXcQ").Return(&youtube.VideoInfo{
+		ID:          "dQw4w9WgXcQ",
+		Title:       "New title",
+		Description: "Same description",
+		ViewCount:   250,
+		LikeCount:   12,
+	}, nil)
+	statsRepo.On("CreateSnapshot", ctx, mock.MatchedBy(func(s *models.VideoStatsSnapshot) bool {
+		return s.VideoID == video.ID && s.ViewCount == 250 && s.LikeCount == 12
+	})).Return(nil)
+	statsRepo.On("CreateChange", ctx, mock.MatchedBy(func(c *models.VideoMetadataChange) bool {
+		return c.Field == "title" && c.OldValue == "Old title" && c.NewValue == "New title"
+	})).Return(nil)
+	statsRepo.On("UpdateVideoMetadata", ctx, video).Return(nil)
+
+	err := service.RefreshVideo(ctx, video)
+	require.NoError(t, err)
+
+	assert.Equal(t, "New title", video.Title)
+	assert.Equal(t, int64(250), video.ViewCount)
+	assert.Equal(t, youtube.AvailabilityAvailable, video.Availability)
+	assert.NotNil(t, video.StatsUpdatedAt)
+	statsRepo.AssertNumberOfCalls(t, "CreateChange", 1)
+	statsRepo.AssertExpectations(t)
+}
+
+func TestService_RefreshVideo_BecamePrivate(t *testing.T) {
+	statsRepo := new(MockStatsRepository)
+	youtubeClient := new(MockYouTubeClient)
+	service := NewService(nil, statsRepo, youtubeClient, time.Hour, 10, zap.NewNop())
+	ctx := context.Background()
+
+	video := &models.Video{
+		ID:         uuid.New(),
+		YouTubeID:  "dQw4w9WgXcQ",
+		SourceType: models.SourceTypeYouTube,
+		Title:      "Title",
+		ViewCount:  10,
+	}
+
+	youtubeClient.On("GetVideoInfo", youtube.WithoutCache(ctx), "dQw4w9WgXcQ").Return(nil, youtube.ErrVideoNotFound)
+	youtubeClient.On("CheckAvailability", ctx, "dQw4w9WgXcQ").Return(youtube.AvailabilityPrivate, nil)
+	statsRepo.On("CreateChange", ctx, mock.MatchedBy(func(c *models.VideoMetadataChange) bool {
+		return c.Field == "availability" && c.OldValue == "available" && c.NewValue == "private"
+	})).Return(nil)
+	statsRepo.On("UpdateVideoMetadata", ctx, video).Return(nil)
+
+	err := service.RefreshVideo(ctx, video)
+	require.NoError(t, err)
+
+	assert.Equal(t, youtube.AvailabilityPrivate, video.Availability)
+	assert.Equal(t, "Title", video.Title, "metadata is kept when the video disappears")
+	assert.Equal(t, int64(10), video.ViewCount)
+	statsRepo.AssertNotCalled(t, "CreateSnapshot", mock.Anything, mock.Anything)
+	statsRepo.AssertExpectations(t)
+}
+
+func TestService_RefreshDue_BatchesLookups(t *testing.T) {
+	statsRepo := new(MockStatsRepository)
+	youtubeClient := new(MockYouTubeClient)
+	service := NewService(nil, statsRepo, youtubeClient, time.Hour, 10, zap.NewNop())
+	ctx := context.Background()
+
+	listed := &models.Video{ID: uuid.New(), YouTubeID: "listed00001", Title: "Listed"}
+	removed := &models.Video{ID: uuid.New(), YouTubeID: "removed0001", Title: "Removed"}
+
+	statsRepo.On("ListDueForRefresh", ctx, mock.AnythingOfType("time.Time"), 10).Return([]*models.Video{listed, removed}, nil)
+	youtubeClient.On("GetVideosInfo", youtube.WithoutCache(ctx), []string{"listed00001", "removed0001"}).Return(map[string]*youtube.VideoInfo{
+		"listed00001": {ID: "listed00001", Title: "Listed", ViewCount: 99},
+	}, nil)
+	youtubeClient.On("CheckAvailability", ctx, "removed0001").Return(youtube.AvailabilityDeleted, nil)
+	statsRepo.On("CreateSnapshot", ctx, mock.Anything).Return(nil)
+	statsRepo.On("CreateChange", ctx, mock.Anything).Return(nil)
+	statsRepo.On("UpdateVideoMetadata", ctx, mock.Anything).Return(nil)
+
+	service.RefreshDue(ctx)
+
+	assert.Equal(t, int64(99), listed.ViewCount)
+	assert.Equal(t, youtube.AvailabilityDeleted, removed.Availability)
+	youtubeClient.AssertNotCalled(t, "GetVideoInfo", mock.Anything, mock.Anything)
+	statsRepo.AssertNumberOfCalls(t, "CreateSnapshot", 1)
+	statsRepo.AssertNumberOfCalls(t, "UpdateVideoMetadata", 2)
+}
+
+func TestService_RefreshDue_WorksThroughBacklog(t *testing.T) {
+	statsRepo := new(MockStatsRepository)
+	youtubeClient := new(MockYouTubeClient)
+	service := NewService(nil, statsRepo, youtubeClient, time.Hour, 2, zap.NewNop())
+	ctx := context.Background()
+
+	first := []*models.Video{
+		{ID: uuid.New(), YouTubeID: "video000001"},
+		{ID: uuid.New(), YouTubeID: "broken00002"},
+	}
+	second := []*models.Video{{ID: uuid.New(), YouTubeID: "video000003"}}
+
+	statsRepo.On("ListDueForRefresh", ctx, mock.AnythingOfType("time.Time"), 2).Return(first, nil).Once()
+	statsRepo.On("ListDueForRefresh", ctx, mock.AnythingOfType("time.Time"), 2).Return(second, nil).Once()
+	youtubeClient.On("GetVideosInfo", youtube.WithoutCache(ctx), []string{"video000001", "broken00002"}).Return(map[string]*youtube.VideoInfo{
+		"video000001": {ID: "video000001", ViewCount: 1},
+	}, nil)
+	youtubeClient.On("GetVideosInfo", youtube.WithoutCache(ctx), []string{"video000003"}).Return(map[string]*youtube.VideoInfo{
+		"video000003": {ID: "video000003", ViewCount: 3},
+	}, nil)
+	youtubeClient.On("CheckAvailability", ctx, "broken00002").Return("", fmt.Errorf("oEmbed unavailable"))
+	statsRepo.On("CreateSnapshot", ctx, mock.Anything).Return(nil)
+	statsRepo.On("UpdateVideoMetadata", ctx, mock.Anything).Return(nil)
+	// The failing video is pushed back instead of heading the next batch again
+	statsRepo.On("MarkRefreshAttempted", ctx, []uuid.UUID{first[1].ID}, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	service.RefreshDue(ctx)
+
+	assert.Equal(t, int64(3), second[0].ViewCount, "batches continue until no video is due")
+	assert.NotNil(t, first[0].StatsAttemptedAt)
+	statsRepo.AssertExpectations(t)
+}
+
+func TestService_RefreshDue_StopsWhenQuotaIsExhausted(t *testing.T) {
+	statsRepo := new(MockStatsRepository)
+	youtubeClient := new(MockYouTubeClient)
+	service := NewService(nil, statsRepo, youtubeClient, time.Hour, 1, zap.NewNop())
+	ctx := context.Background()
+
+	video := &models.Video{ID: uuid.New(), YouTubeID: "video000001"}
+	statsRepo.On("ListDueForRefresh", ctx, mock.AnythingOfType("time.Time"), 1).Return([]*models.Video{video}, nil)
+	youtubeClient.On("GetVideosInfo", youtube.WithoutCache(ctx), []string{"video000001"}).Return(nil, youtube.ErrQuotaExceeded)
+
+	service.RefreshDue(ctx)
+
+	statsRepo.AssertNumberOfCalls(t, "ListDueForRefresh", 1)
+	statsRepo.AssertNotCalled(t, "MarkRefreshAttempted", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -14,6 +14,7 @@ import (
 	"youtube-video-summarizer/backend/internal/services/cost"
 	settingsservice "youtube-video-summarizer/backend/internal/services/settings"
 	"youtube-video-summarizer/backend/internal/services/provider"
+	"youtube-video-summarizer/backend/pkg/errors"
 	"youtube-video-summarizer/backend/pkg/llm"
 	"youtube-video-summarizer/backend/pkg/prompts"
 	"youtube-video-summarizer/backend/pkg/whisper"
@@ -120,13 +121,14 @@ func (s *Service) GenerateSummaryFromAudio(
 	}
 
 	// Generate summary from transcript using audio analysis provider
-	return s.generateSummaryWithProvider(ctx, videoID, resp.Text, summaryType, llmProvider, language)
+	return s.generateSummaryWithProvider(ctx, videoID, nil, resp.Text, false, summaryType, llmProvider, language)
 }
 
+// GenerateSummary summarizes a stored transcript and records which one on the summary
 func (s *Service) GenerateSummary(
 	ctx context.Context,
 	videoID uuid.UUID,
-	transcript string,
+	transcript *models.Transcript,
 	summaryType string,
 	language string,
 ) (*models.Summary, error) {
@@ -136,31 +138,32 @@ func (s *Service) GenerateSummary(
 		return nil, fmt.Errorf("failed to get LLM provider: %w", err)
 	}
 	
-	return s.generateSummaryWithProvider(ctx, videoID, transcript, summaryType, llmProvider, language)
+	if transcript.HasSpeakers() {
+		return s.generateSummaryWithProvider(ctx, videoID, &transcript.ID, transcriptText(transcript, transcript.Segments), true, summaryType, llmProvider, language)
+	}
+	return s.generateSummaryWithProvider(ctx, videoID, &transcript.ID, transcript.Content, false, summaryType, llmProvider, language)
 }
 
-// generateSummaryWithProvider is a helper method that generates summary with a specific provider
+// generateSummaryWithProvider is a helper method that generates summary with a specific provider.
+// transcriptID is the stored transcript the text comes from, nil for a fresh transcription.
+// speakers tells that the text is written as speaker turns.
 func (s *Service) generateSummaryWithProvider(
 	ctx context.Context,
 	videoID uuid.UUID,
+	transcriptID *uuid.UUID,
 	transcript string,
+	speakers bool,
 	summaryType string,
 	llmProvider llm.LLMProvider,
 	language string,
 ) (*models.Summary, error) {
-	// Determine summary language: use provided language, or fallback to settings, or default to "auto"
-	var summaryLanguage string = "auto"
-	if language != "" {
-		summaryLanguage = language
-	} else if s.settingsService != nil {
-		settings, err := s.settingsService.GetSettings(ctx)
-		if err == nil && settings != nil && settings.SummaryLanguage != "" {
-			summaryLanguage = settings.SummaryLanguage
-		}
-	}
+	summaryLanguage := s.resolveLanguage(ctx, language)
 
 	// Get prompt template with language
 	promptTemplate := prompts.GetSummaryPrompt(summaryType, summaryLanguage)
+	if speakers {
+		promptTemplate = prompts.WithSpeakerAttribution(promptTemplate)
+	}
 	prompt := strings.ReplaceAll(promptTemplate, "{{.Transcript}}", transcript)
 
 	// Generate summary using LLM
@@ -182,9 +185,10 @@ func (s *Service) generateSummaryWithProvider(
 
 	// Create summary model
 	summary := &models.Summary{
-		VideoID:     videoID,
-		ModelUsed:   llmProvider.GetModelInfo().Name,
-		SummaryType: summaryType,
+		VideoID:      videoID,
+		TranscriptID: transcriptID,
+		ModelUsed:    llmProvider.GetModelInfo().Name,
+		SummaryType:  summaryType,
 		Content:     resp.Content,
 		KeyPoints:   keyPoints,
 	}
@@ -202,6 +206,153 @@ func (s *Service) generateSummaryWithProvider(
 	return summary, nil
 }
 
+// GenerateChapteredSummary summarizes each chapter's slice of the transcript separately and
+// stitches the results together under one heading per chapter
+func (s *Service) GenerateChapteredSummary(
+	ctx context.Context,
+	videoID uuid.UUID,
+	transcript *models.Transcript,
+	chapters []*models.Chapter,
+	language string,
+) (*models.Summary, error) {
+	if len(chapters) == 0 {
+		return nil, errors.ErrSummaryNoChapters(videoID.String())
+	}
+	segments := transcript.Segments
+	if len(segments) == 0 {
+		return nil, errors.ErrSummaryNoTranscript(videoID.String())
+	}
+
+	llmProvider, err := s.providerFactory.GetLLMProvider(ctx, "summary")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LLM provider: %w", err)
+	}
+	modelInfo := llmProvider.GetModelInfo()
+	promptTemplate := prompts.GetChapterSummaryPrompt(s.resolveLanguage(ctx, language))
+	if transcript.HasSpeakers() {
+		promptTemplate = prompts.WithSpeakerAttribution(promptTemplate)
+	}
+
+	var content strings.Builder
+	summarized := 0
+	for i, chapter := range chapters {
+		// The last chapter runs to the end, whatever the recorded duration says
+		text := chapterTranscript(transcript, chapter, i == len(chapters)-1)
+		if text == "" {
+			continue
+		}
+
+		prompt := strings.ReplaceAll(promptTemplate, "{{.Title}}", chapter.Title)
+		prompt = strings.ReplaceAll(prompt, "{{.Transcript}}", text)
+
+		resp, err := llmProvider.GenerateCompletion(ctx, llm.CompletionRequest{
+			Prompt:       prompt,
+			SystemPrompt: "You are an expert at analyzing and summarizing video content.",
+			MaxTokens:    600,
+			Temperature:  0.7,
+			TopP:         0.9,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to summarize chapter %q: %w", chapter.Title, err)
+		}
+
+		if s.costService != nil {
+			s.costService.RecordUsage(ctx, videoID, "summary", modelInfo.Provider, modelInfo.Name, resp.InputTokens, resp.OutputTokens)
+		}
+
+		fmt.Fprintf(&content, "## %s (%s)\n\n%s\n\n", chapter.Title, formatChapterTime(chapter.StartTime), strings.TrimSpace(resp.Content))
+		summarized++
+	}
+
+	if summarized == 0 {
+		return nil, errors.ErrSummaryNoTranscript(videoID.String())
+	}
+
+	stitched := strings.TrimSpace(content.String())
+	summary := &models.Summary{
+		VideoID:      videoID,
+		TranscriptID: &transcript.ID,
+		ModelUsed:    modelInfo.Name,
+		SummaryType:  models.SummaryTypeChapters,
+		Content:     stitched,
+		KeyPoints:   s.extractKeyPoints(stitched),
+	}
+
+	if err := s.summaryRepo.Create(ctx, summary); err != nil {
+		return nil, fmt.Errorf("failed to save summary: %w", err)
+	}
+
+	s.logger.Info("Chaptered summary generated",
+		zap.String("video_id", videoID.String()),
+		zap.Int("chapters", len(chapters)),
+		zap.Int("summarized", summarized))
+
+	return summary, nil
+}
+
+// chapterTranscript returns the text of the segments starting within the chapter
+func chapterTranscript(transcript *models.Transcript, chapter *models.Chapter, openEnded bool) string {
+	var segments []models.TranscriptSegment
+	for _, segment := range transcript.Segments {
+		if segment.Start < chapter.StartTime {
+			continue
+		}
+		if !openEnded && segment.Start >= chapter.EndTime {
+			continue
+		}
+		segments = append(segments, segment)
+	}
+	return transcriptText(transcript, segments)
+}
+
+// transcriptText joins the text of segments. Diarized segments are written as turns, "Name:
+// text", one paragraph per change of speaker.
+func transcriptText(transcript *models.Transcript, segments []models.TranscriptSegment) string {
+	var b strings.Builder
+	speaker := ""
+	for _, segment := range segments {
+		text := strings.TrimSpace(segment.Text)
+		if text == "" {
+			continue
+		}
+		if segment.Speaker != "" && segment.Speaker != speaker {
+			if b.Len() > 0 {
+				b.WriteString("\n\n")
+			}
+			b.WriteString(transcript.SpeakerName(segment.Speaker) + ": ")
+			speaker = segment.Speaker
+		} else if b.Len() > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString(text)
+	}
+	return b.String()
+}
+
+// formatChapterTime formats seconds as m:ss, or h:mm:ss for chapters past the first hour
+func formatChapterTime(seconds float64) string {
+	total := int(seconds)
+	h, m, sec := total/3600, (total%3600)/60, total%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, sec)
+	}
+	return fmt.Sprintf("%d:%02d", m, sec)
+}
+
+// resolveLanguage determines the summary language: the requested one, else the settings, else "auto"
+func (s *Service) resolveLanguage(ctx context.Context, language string) string {
+	if language != "" {
+		return language
+	}
+	if s.settingsService != nil {
+		settings, err := s.settingsService.GetSettings(ctx)
+		if err == nil && settings != nil && settings.SummaryLanguage != "" {
+			return settings.SummaryLanguage
+		}
+	}
+	return "auto"
+}
+
 // TranslateSummary translates an existing summary to a different language
 func (s *Service) TranslateSummary(
 	ctx context.Context,
@@ -293,7 +444,8 @@ Output:`,
 
 	// Create translated summary model (keep same type, update model info)
 	translatedSummary := &models.Summary{
-		VideoID:     videoID,
+		VideoID:      videoID,
+		TranscriptID: existingSummary.TranscriptID,
 		ModelUsed:   existingSummary.ModelUsed + " (translated to " + targetLanguage + ")",
 		SummaryType: existingSummary.SummaryType,
 		Content:     translatedContent,
@@ -2,6 +2,9 @@ package summary
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"youtube-video-summarizer/backend/internal/models"
 )
 
 
@@ -15,3 +18,41 @@ func TestService_GetByVideoID(t *testing.T) {
 	t.Skip("Skipping unit test - requires ProviderFactory mocking. Tested via integration tests.")
 }
 
+
+func TestChapterTranscript(t *testing.T) {
+	transcript := &models.Transcript{Segments: models.TranscriptSegments{
+		{Start: 0, End: 4, Text: "Welcome."},
+		{Start: 4, End: 9, Text: " Today we cook. "},
+		{Start: 60, End: 65, Text: "First, the dough."},
+		{Start: 130, End: 140, Text: "Thanks for watching."},
+	}}
+	intro := &models.Chapter{Title: "Intro", StartTime: 0, EndTime: 60}
+	dough := &models.Chapter{Title: "Dough", StartTime: 60, EndTime: 120}
+
+	assert.Equal(t, "Welcome. Today we cook.", chapterTranscript(transcript, intro, false))
+	assert.Equal(t, "First, the dough.", chapterTranscript(transcript, dough, false))
+	assert.Equal(t, "First, the dough. Thanks for watching.", chapterTranscript(transcript, dough, true),
+		"the last chapter keeps segments past its recorded end")
+}
+
+func TestTranscriptText_SpeakerTurns(t *testing.T) {
+	transcript := &models.Transcript{
+		Speakers: models.SpeakerNames{"SPEAKER_00": "Alice"},
+		Segments: models.TranscriptSegments{
+			{Start: 0, End: 3, Text: "Rates will fall.", Speaker: "SPEAKER_00"},
+			{Start: 3, End: 5, Text: " By spring. ", Speaker: "SPEAKER_00"},
+			{Start: 5, End: 8, Text: "I doubt it.", Speaker: "SPEAKER_01"},
+			{Start: 8, End: 9, Text: "Why?", Speaker: "SPEAKER_00"},
+		},
+	}
+	assert.True(t, transcript.HasSpeakers())
+
+	assert.Equal(t, "Alice: Rates will fall. By spring.\n\nSPEAKER_01: I doubt it.\n\nAlice: Why?",
+		transcriptText(transcript, transcript.Segments))
+}
+
+func TestFormatChapterTime(t *testing.T) {
+	assert.Equal(t, "0:00", formatChapterTime(0))
+	assert.Equal(t, "5:45", formatChapterTime(345.6))
+	assert.Equal(t, "1:02:03", formatChapterTime(3723))
+}
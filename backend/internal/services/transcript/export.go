@@ -0,0 +1,320 @@
+package transcript
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+// ExportFormat is a file format a transcript can be rendered to
+type ExportFormat string
+
+const (
+	FormatSRT  ExportFormat = "srt"
+	FormatVTT  ExportFormat = "vtt"
+	FormatTXT  ExportFormat = "txt"
+	FormatJSON ExportFormat = "json"
+)
+
+// DefaultLineLength is the subtitle line length used when none is given, the common
+// broadcast limit for one line of captions
+const DefaultLineLength = 42
+
+// maxMergeGap is the longest pause (seconds) bridged when merging short cues
+const maxMergeGap = 1.0
+
+// ParseExportFormat returns the format named by s, if supported
+func ParseExportFormat(s string) (ExportFormat, bool) {
+	switch f := ExportFormat(strings.ToLower(strings.TrimSpace(s))); f {
+	case FormatSRT, FormatVTT, FormatTXT, FormatJSON:
+		return f, true
+	}
+	return "", false
+}
+
+// ContentType returns the MIME type of files in the format
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case FormatSRT:
+		return "application/x-subrip; charset=utf-8"
+	case FormatVTT:
+		return "text/vtt; charset=utf-8"
+	case FormatJSON:
+		return "application/json; charset=utf-8"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
+
+// ExportOptions controls how a transcript is rendered
+type ExportOptions struct {
+	Format ExportFormat
+	// LineLength wraps cue text at this many characters. 0 uses DefaultLineLength for
+	// subtitles and leaves plain text unwrapped. JSON is never wrapped.
+	LineLength int
+	// MergeShorterThan merges cues shorter than this many seconds into the following cue.
+	// 0 keeps every cue as is.
+	MergeShorterThan float64
+	// From and To limit the export to cues within this range, in seconds. To 0 means the
+	// end of the transcript. Cues crossing a bound are clipped to it.
+	From float64
+	To   float64
+}
+
+// exportDocument is the JSON export of a transcript
+type exportDocument struct {
+	VideoID  string                     `json:"video_id"`
+	Language string                     `json:"language"`
+	Source   string                     `json:"source"`
+	Segments []models.TranscriptSegment `json:"segments"`
+}
+
+// Export renders the segments of t in the requested format
+func Export(t *models.Transcript, opts ExportOptions) ([]byte, error) {
+	if opts.To > 0 && opts.To <= opts.From {
+		return nil, fmt.Errorf("export range end %.3fs is not after its start %.3fs", opts.To, opts.From)
+	}
+	lineLength := opts.LineLength
+	if lineLength == 0 && (opts.Format == FormatSRT || opts.Format == FormatVTT) {
+		lineLength = DefaultLineLength
+	}
+
+	cues := clipCues(t.Segments, opts.From, opts.To)
+	if opts.MergeShorterThan > 0 {
+		cues = mergeShortCues(cues, opts.MergeShorterThan, lineLength)
+	}
+	if opts.Format == FormatSRT || opts.Format == FormatVTT {
+		cues = splitLongCues(cues, lineLength)
+	}
+
+	switch opts.Format {
+	case FormatSRT:
+		return renderSRT(cues, lineLength), nil
+	case FormatVTT:
+		return renderVTT(cues, t.Language, lineLength), nil
+	case FormatTXT:
+		return renderTXT(cues, lineLength), nil
+	case FormatJSON:
+		if cues == nil {
+			cues = []models.TranscriptSegment{}
+		}
+		return json.MarshalIndent(exportDocument{
+			VideoID:  t.VideoID.String(),
+			Language: t.Language,
+			Source:   t.Source,
+			Segments: cues,
+		}, "", "  ")
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", opts.Format)
+	}
+}
+
+// clipCues returns the non-empty segments overlapping [from, to), clipped to that range
+func clipCues(segments []models.TranscriptSegment, from, to float64) []models.TranscriptSegment {
+	var cues []models.TranscriptSegment
+	for _, seg := range segments {
+		text := strings.Join(strings.Fields(seg.Text), " ")
+		if text == "" || seg.End <= from || (to > 0 && seg.Start >= to) {
+			continue
+		}
+		seg.Text = text
+		seg.Start = math.Max(seg.Start, from)
+		if to > 0 {
+			seg.End = math.Min(seg.End, to)
+		}
+		if len(seg.Words) > 0 {
+			words := make([]models.TranscriptWord, 0, len(seg.Words))
+			for _, word := range seg.Words {
+				if word.End > from && (to <= 0 || word.Start < to) {
+					words = append(words, word)
+				}
+			}
+			seg.Words = words
+		}
+		cues = append(cues, seg)
+	}
+	return cues
+}
+
+// mergeShortCues joins every cue shorter than minDuration with the cue after it, as long as
+// they are close together and, when wrapping, the result still fits on two lines
+func mergeShortCues(cues []models.TranscriptSegment, minDuration float64, lineLength int) []models.TranscriptSegment {
+	var merged []models.TranscriptSegment
+	for _, cue := range cues {
+		if n := len(merged); n > 0 {
+			last := &merged[n-1]
+			text := last.Text + " " + cue.Text
+			fits := lineLength <= 0 || len(wrapText(text, lineLength)) <= 2
+			if last.End-last.Start < minDuration && cue.Start-last.End <= maxMergeGap && fits {
+				last.End = cue.End
+				last.Text = text
+				if len(last.Words) > 0 && len(cue.Words) > 0 {
+					last.Words = append(append([]models.TranscriptWord(nil), last.Words...), cue.Words...)
+				} else {
+					last.Words = nil
+				}
+				continue
+			}
+		}
+		merged = append(merged, cue)
+	}
+	return merged
+}
+
+// maxCueLines is the number of lines a subtitle cue is shown on at most
+const maxCueLines = 2
+
+// splitLongCues splits every cue that wraps to more than maxCueLines lines into consecutive
+// cues of at most that many lines. The word timings of a cue place each part where it is
+// spoken; without them the cue's time is shared out by the length of the parts.
+func splitLongCues(cues []models.TranscriptSegment, lineLength int) []models.TranscriptSegment {
+	if lineLength <= 0 {
+		return cues
+	}
+	var split []models.TranscriptSegment
+	for _, cue := range cues {
+		lines := wrapText(cue.Text, lineLength)
+		if len(lines) <= maxCueLines {
+			split = append(split, cue)
+			continue
+		}
+
+		var parts []string
+		for i := 0; i < len(lines); i += maxCueLines {
+			parts = append(parts, strings.Join(lines[i:min(i+maxCueLines, len(lines))], " "))
+		}
+		// Word timings only line up with the text when they cover every word of it
+		timed := len(cue.Words) == len(strings.Fields(cue.Text))
+
+		totalLength := len([]rune(strings.Join(parts, "")))
+		start, length, word := cue.Start, 0, 0
+		for i, text := range parts {
+			part := cue
+			part.Text = text
+			part.Start = start
+			part.Words = nil
+			count := len(strings.Fields(text))
+			length += len([]rune(text))
+			switch {
+			case i == len(parts)-1:
+				part.End = cue.End
+			case timed:
+				part.End = cue.Words[word+count-1].End
+			default:
+				part.End = cue.Start + (cue.End-cue.Start)*float64(length)/float64(totalLength)
+			}
+			if timed {
+				part.Words = cue.Words[word : word+count]
+				if i > 0 {
+					part.Start = math.Max(cue.Words[word].Start, start)
+				}
+			}
+			word += count
+			start = part.End
+			split = append(split, part)
+		}
+	}
+	return split
+}
+
+// wrapText breaks text into lines of at most width characters, at spaces. Words longer than
+// width get a line of their own.
+func wrapText(text string, width int) []string {
+	words := strings.Fields(text)
+	if width <= 0 {
+		return []string{strings.Join(words, " ")}
+	}
+	var lines []string
+	var line strings.Builder
+	for _, word := range words {
+		if line.Len() > 0 && len([]rune(line.String()))+1+len([]rune(word)) > width {
+			lines = append(lines, line.String())
+			line.Reset()
+		}
+		if line.Len() > 0 {
+			line.WriteByte(' ')
+		}
+		line.WriteString(word)
+	}
+	if line.Len() > 0 {
+		lines = append(lines, line.String())
+	}
+	return lines
+}
+
+func renderSRT(cues []models.TranscriptSegment, lineLength int) []byte {
+	var b strings.Builder
+	for i, cue := range cues {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%d\n%s --> %s\n", i+1, formatTimestamp(cue.Start, ','), formatTimestamp(cue.End, ','))
+		b.WriteString(strings.Join(wrapText(cue.Text, lineLength), "\n"))
+		b.WriteString("\n")
+	}
+	return []byte(b.String())
+}
+
+func renderVTT(cues []models.TranscriptSegment, language string, lineLength int) []byte {
+	var b strings.Builder
+	b.WriteString("WEBVTT\nKind: captions\n")
+	if language != "" {
+		b.WriteString("Language: " + language + "\n")
+	}
+	for _, cue := range cues {
+		fmt.Fprintf(&b, "\n%s --> %s\n", formatTimestamp(cue.Start, '.'), formatTimestamp(cue.End, '.'))
+		for _, line := range wrapText(cue.Text, lineLength) {
+			b.WriteString(vttEscaper.Replace(line))
+			b.WriteString("\n")
+		}
+	}
+	return []byte(b.String())
+}
+
+// vttEscaper escapes the characters WebVTT cue text reserves for markup
+var vttEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func renderTXT(cues []models.TranscriptSegment, lineLength int) []byte {
+	var b strings.Builder
+	for _, cue := range cues {
+		for _, line := range wrapText(cue.Text, lineLength) {
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+	return []byte(b.String())
+}
+
+// formatTimestamp formats seconds as HH:MM:SS followed by sep and milliseconds
+func formatTimestamp(seconds float64, sep byte) string {
+	ms := int64(math.Round(math.Max(seconds, 0) * 1000))
+	hours := ms / 3_600_000
+	minutes := ms / 60_000 % 60
+	secs := ms / 1000 % 60
+	return fmt.Sprintf("%02d:%02d:%02d%c%03d", hours, minutes, secs, sep, ms%1000)
+}
+
+// ParseSeconds reads a time given as seconds ("75.5") or as [HH:]MM:SS[.mmm]
+func ParseSeconds(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	var total float64
+	for _, part := range parts {
+		value, err := strconv.ParseFloat(part, 64)
+		if err != nil || value < 0 {
+			return 0, fmt.Errorf("invalid time %q", s)
+		}
+		total = total*60 + value
+	}
+	return total, nil
+}
@@ -0,0 +1,171 @@
+package transcript
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+func exportFixture() *models.Transcript {
+	return &models.Transcript{
+		VideoID:  uuid.New(),
+		Language: "en",
+		Source:   "whisper",
+		Segments: models.TranscriptSegments{
+			{Start: 1.2, End: 4, Text: "We're no strangers to love."},
+			{Start: 4, End: 8.5, Text: "You know the rules and so do I, a full commitment's what I'm thinking of."},
+			{Start: 8.5, End: 9.1, Text: "You wouldn't!"},
+			{Start: 9.3, End: 12.75, Text: "Get this from any other guy."},
+			{Start: 3725.004, End: 3727.5, Text: "  Never   gonna give you up.  "},
+		},
+	}
+}
+
+func TestExport_VTTRoundTrip(t *testing.T) {
+	transcript := exportFixture()
+
+	data, err := Export(transcript, ExportOptions{Format: FormatVTT})
+	require.NoError(t, err)
+
+	text, segments, language := parseVTT(string(data))
+	assert.Equal(t, "en", language)
+	require.Len(t, segments, len(transcript.Segments))
+	for i, want := range transcript.Segments {
+		assert.InDelta(t, want.Start, segments[i].Start, 0.0005)
+		assert.InDelta(t, want.End, segments[i].End, 0.0005)
+		assert.Equal(t, strings.Join(strings.Fields(want.Text), " "), segments[i].Text)
+	}
+	assert.True(t, strings.HasPrefix(text, "We're no strangers to love. You know the rules"))
+	assert.Contains(t, string(data), "01:02:05.004 --> 01:02:07.500")
+}
+
+func TestExport_VTTRoundTripsCaptions(t *testing.T) {
+	content, err := os.ReadFile("testdata/dQw4w9WgXcQ.en.vtt")
+	require.NoError(t, err)
+	text, segments, language := parseVTT(string(content))
+
+	data, err := Export(&models.Transcript{Language: language, Segments: segments}, ExportOptions{Format: FormatVTT})
+	require.NoError(t, err)
+
+	gotText, gotSegments, gotLanguage := parseVTT(string(data))
+	assert.Equal(t, text, gotText)
+	assert.Equal(t, segments, gotSegments)
+	assert.Equal(t, language, gotLanguage)
+}
+
+func TestExport_SRT(t *testing.T) {
+	data, err := Export(exportFixture(), ExportOptions{Format: FormatSRT, To: 8.5})
+	require.NoError(t, err)
+
+	want := "1\n" +
+		"00:00:01,200 --> 00:00:04,000\n" +
+		"We're no strangers to love.\n" +
+		"\n" +
+		"2\n" +
+		"00:00:04,000 --> 00:00:08,500\n" +
+		"You know the rules and so do I, a full\n" +
+		"commitment's what I'm thinking of.\n"
+	assert.Equal(t, want, string(data))
+}
+
+func TestExport_TXTWrapsOnlyWhenAsked(t *testing.T) {
+	data, err := Export(exportFixture(), ExportOptions{Format: FormatTXT, From: 4, To: 8.5})
+	require.NoError(t, err)
+	assert.Equal(t, "You know the rules and so do I, a full commitment's what I'm thinking of.\n", string(data))
+
+	data, err = Export(exportFixture(), ExportOptions{Format: FormatTXT, LineLength: 30, From: 4, To: 8.5})
+	require.NoError(t, err)
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		assert.LessOrEqual(t, len(line), 30, line)
+	}
+}
+
+func TestExport_MergesShortCues(t *testing.T) {
+	data, err := Export(exportFixture(), ExportOptions{Format: FormatJSON, MergeShorterThan: 1})
+	require.NoError(t, err)
+
+	var doc exportDocument
+	require.NoError(t, json.Unmarshal(data, &doc))
+	require.Len(t, doc.Segments, 4)
+	assert.Equal(t, models.TranscriptSegment{Start: 8.5, End: 12.75, Text: "You wouldn't! Get this from any other guy."}, doc.Segments[2])
+	assert.Equal(t, "Never gonna give you up.", doc.Segments[3].Text, "a cue far from the next is kept")
+}
+
+func TestExport_TimeRangeClipsCues(t *testing.T) {
+	data, err := Export(exportFixture(), ExportOptions{Format: FormatJSON, From: 2, To: 9})
+	require.NoError(t, err)
+
+	var doc exportDocument
+	require.NoError(t, json.Unmarshal(data, &doc))
+	require.Len(t, doc.Segments, 3)
+	assert.Equal(t, 2.0, doc.Segments[0].Start)
+	assert.Equal(t, 9.0, doc.Segments[2].End)
+	assert.Equal(t, "en", doc.Language)
+
+	_, err = Export(exportFixture(), ExportOptions{Format: FormatJSON, From: 9, To: 2})
+	assert.Error(t, err)
+}
+
+func TestExport_EscapesVTTMarkup(t *testing.T) {
+	data, err := Export(&models.Transcript{Segments: models.TranscriptSegments{
+		{Start: 0, End: 1, Text: "Tom & Jerry <3"},
+	}}, ExportOptions{Format: FormatVTT})
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Tom &amp; Jerry &lt;3")
+
+	_, segments, _ := parseVTT(string(data))
+	require.Len(t, segments, 1)
+	assert.Equal(t, "Tom & Jerry <3", segments[0].Text)
+}
+
+func TestParseSeconds(t *testing.T) {
+	for input, want := range map[string]float64{"": 0, "75.5": 75.5, "01:15.5": 75.5, "1:00:00": 3600} {
+		got, err := ParseSeconds(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+	for _, input := range []string{"abc", "-5", "1:2:3:4"} {
+		_, err := ParseSeconds(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestExport_SplitsLongCues(t *testing.T) {
+	text := "one two three four five six seven eight nine ten eleven twelve"
+	segment := models.TranscriptSegment{Start: 10, End: 22, Text: text}
+
+	data, err := Export(&models.Transcript{Segments: models.TranscriptSegments{segment}}, ExportOptions{Format: FormatSRT, LineLength: 14})
+	require.NoError(t, err)
+	want := "1\n" +
+		"00:00:10,000 --> 00:00:15,400\n" +
+		"one two three\n" +
+		"four five six\n" +
+		"\n" +
+		"2\n" +
+		"00:00:15,400 --> 00:00:19,400\n" +
+		"seven eight\n" +
+		"nine ten\n" +
+		"\n" +
+		"3\n" +
+		"00:00:19,400 --> 00:00:22,000\n" +
+		"eleven twelve\n"
+	assert.Equal(t, want, string(data), "five lines become cues of two, timed by their length")
+
+	// Word timings put each part where it is spoken
+	for i, word := range strings.Fields(text) {
+		start := 10 + float64(i)
+		segment.Words = append(segment.Words, models.TranscriptWord{Start: start, End: start + 0.5, Text: word})
+	}
+	data, err = Export(&models.Transcript{Segments: models.TranscriptSegments{segment}}, ExportOptions{Format: FormatVTT, LineLength: 14})
+	require.NoError(t, err)
+	assert.Equal(t, "WEBVTT\nKind: captions\n"+
+		"\n00:00:10.000 --> 00:00:15.500\none two three\nfour five six\n"+
+		"\n00:00:16.000 --> 00:00:19.500\nseven eight\nnine ten\n"+
+		"\n00:00:20.000 --> 00:00:22.000\neleven twelve\n", string(data), "each part starts and ends with its words")
+}
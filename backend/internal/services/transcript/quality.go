@@ -0,0 +1,213 @@
+package transcript
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+const (
+	// Speech runs at roughly 2-3 words per second; captions well outside this range are
+	// missing most of the speech or are not a transcript of it
+	minWordsPerSecond = 0.5
+	maxWordsPerSecond = 5.0
+	// normalRepetition is the share of repeated word trigrams ordinary speech reaches
+	normalRepetition = 0.2
+	// minScriptLetters is how many letters a transcript needs before its script is judged
+	minScriptLetters = 50
+	// minLanguageWords is how many words a transcript needs before its language is judged
+	minLanguageWords = 30
+)
+
+// annotationPattern matches sound annotations such as [Music], (applause) and ♪ notes
+var annotationPattern = regexp.MustCompile(`\[[^\]]*\]|\([^)]*\)|[♪♫]+`)
+
+// languageScripts are the scripts captions in a language are written in, for the languages
+// not written in Latin script
+var languageScripts = map[string][]*unicode.RangeTable{
+	"ar": {unicode.Arabic},
+	"fa": {unicode.Arabic},
+	"ur": {unicode.Arabic},
+	"he": {unicode.Hebrew},
+	"ru": {unicode.Cyrillic},
+	"uk": {unicode.Cyrillic},
+	"bg": {unicode.Cyrillic},
+	"sr": {unicode.Cyrillic, unicode.Latin},
+	"el": {unicode.Greek},
+	"hi": {unicode.Devanagari},
+	"mr": {unicode.Devanagari},
+	"bn": {unicode.Bengali},
+	"ta": {unicode.Tamil},
+	"th": {unicode.Thai},
+	"ka": {unicode.Georgian},
+	"hy": {unicode.Armenian},
+	"zh": {unicode.Han},
+	"ja": {unicode.Han, unicode.Hiragana, unicode.Katakana},
+	"ko": {unicode.Hangul, unicode.Han},
+}
+
+// stopWords are frequent function words of common Latin-script languages, which tell these
+// languages apart where the script cannot
+var stopWords = wordSets(map[string][]string{
+	"en": {"the", "and", "is", "of", "to", "in", "that", "it", "you", "this", "was", "for", "with", "are"},
+	"es": {"el", "la", "que", "de", "y", "los", "las", "en", "es", "por", "una", "con", "para", "del"},
+	"fr": {"le", "la", "les", "et", "est", "que", "des", "une", "dans", "pour", "pas", "qui", "sur", "du"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "ich", "zu", "den", "mit", "ein", "eine", "auch", "sie"},
+	"pt": {"o", "os", "que", "de", "e", "não", "uma", "um", "para", "com", "do", "da", "em", "é"},
+	"it": {"il", "che", "di", "e", "la", "non", "per", "un", "una", "sono", "con", "del", "della", "è"},
+	"nl": {"de", "het", "een", "en", "van", "is", "niet", "dat", "ik", "op", "te", "zijn", "met", "voor"},
+})
+
+// captionSignals are the measurements the quality of published captions is judged by
+type captionSignals struct {
+	AutoGenerated    bool
+	WordsPerSecond   float64 // spoken words per second of video; 0 when the duration is unknown
+	AnnotationRatio  float64 // share of the words that are sound annotations
+	RepetitionRatio  float64 // share of word trigrams repeating an earlier one
+	LanguageMismatch bool    // the text is not in the script of its declared language, or reads as another one
+}
+
+// measureCaptions takes the quality signals of caption segments for a video of duration
+// seconds. language is the language the captions declare. Captions in another language than
+// the one requested are not judged worse for it: Whisper would transcribe the same speech.
+func measureCaptions(segments []models.TranscriptSegment, duration int, language string, autoGenerated bool) captionSignals {
+	signals := captionSignals{AutoGenerated: autoGenerated}
+
+	var spoken []string
+	annotated := 0
+	for _, seg := range segments {
+		for _, annotation := range annotationPattern.FindAllString(seg.Text, -1) {
+			annotated += max(len(strings.Fields(annotation)), 1)
+		}
+		spoken = append(spoken, strings.Fields(annotationPattern.ReplaceAllString(seg.Text, " "))...)
+	}
+
+	if total := annotated + len(spoken); total > 0 {
+		signals.AnnotationRatio = float64(annotated) / float64(total)
+	}
+	if duration > 0 {
+		signals.WordsPerSecond = float64(len(spoken)) / float64(duration)
+	}
+	signals.RepetitionRatio = repetitionRatio(spoken)
+	signals.LanguageMismatch = !inExpectedScript(spoken, language) || !inExpectedLanguage(spoken, language)
+	return signals
+}
+
+// score combines the signals into a quality score from 0 (unusable) to 1, each problem
+// scaling the score down
+func (s captionSignals) score() float64 {
+	score := 1.0
+	if s.AutoGenerated {
+		score *= 0.9
+	}
+	if s.WordsPerSecond > 0 && s.WordsPerSecond < minWordsPerSecond {
+		score *= s.WordsPerSecond / minWordsPerSecond
+	} else if s.WordsPerSecond > maxWordsPerSecond {
+		score *= maxWordsPerSecond / s.WordsPerSecond
+	}
+	score *= 1 - s.AnnotationRatio
+	if s.RepetitionRatio > normalRepetition {
+		score *= 1 - (s.RepetitionRatio-normalRepetition)/(1-normalRepetition)
+	}
+	if s.LanguageMismatch {
+		score *= 0.5
+	}
+	return math.Round(score*100) / 100
+}
+
+// repetitionRatio returns the share of the word trigrams in words that occurred before,
+// which is high for captions stuck repeating a phrase
+func repetitionRatio(words []string) float64 {
+	if len(words) < 3 {
+		return 0
+	}
+	seen := make(map[string]bool)
+	repeated := 0
+	for i := 0; i+3 <= len(words); i++ {
+		trigram := strings.ToLower(strings.Join(words[i:i+3], " "))
+		if seen[trigram] {
+			repeated++
+		}
+		seen[trigram] = true
+	}
+	return float64(repeated) / float64(len(words)-2)
+}
+
+// inExpectedScript reports whether most letters of words are in the script of language.
+// Languages missing from languageScripts are taken to be written in Latin script; short
+// texts always pass.
+func inExpectedScript(words []string, language string) bool {
+	scripts, ok := languageScripts[baseLanguage(language)]
+	if !ok {
+		scripts = []*unicode.RangeTable{unicode.Latin}
+	}
+
+	letters, matching := 0, 0
+	for _, word := range words {
+		for _, r := range word {
+			if !unicode.IsLetter(r) {
+				continue
+			}
+			letters++
+			if unicode.In(r, scripts...) {
+				matching++
+			}
+		}
+	}
+	if letters < minScriptLetters {
+		return true
+	}
+	return matching*2 >= letters
+}
+
+// inExpectedLanguage reports whether words read as language rather than as another language
+// in stopWords: the text fails when another language's stop words make up at least a tenth of
+// it and are more than twice as frequent as those of language. Languages missing from stopWords
+// and short texts always pass.
+func inExpectedLanguage(words []string, language string) bool {
+	language = baseLanguage(language)
+	if _, ok := stopWords[language]; !ok || len(words) < minLanguageWords {
+		return true
+	}
+
+	counts := make(map[string]int, len(stopWords))
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimFunc(word, func(r rune) bool { return !unicode.IsLetter(r) }))
+		for lang, set := range stopWords {
+			if set[word] {
+				counts[lang]++
+			}
+		}
+	}
+
+	for lang, count := range counts {
+		if lang != language && count*10 >= len(words) && count > counts[language]*2 {
+			return false
+		}
+	}
+	return true
+}
+
+// wordSets turns word lists into sets
+func wordSets(lists map[string][]string) map[string]map[string]bool {
+	sets := make(map[string]map[string]bool, len(lists))
+	for key, words := range lists {
+		sets[key] = make(map[string]bool, len(words))
+		for _, word := range words {
+			sets[key][word] = true
+		}
+	}
+	return sets
+}
+
+// baseLanguage returns the language of a code without its region, e.g. "pt" for "pt-BR"
+func baseLanguage(code string) string {
+	code = strings.ToLower(code)
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		code = code[:i]
+	}
+	return code
+}
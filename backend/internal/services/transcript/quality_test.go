@@ -0,0 +1,108 @@
+package transcript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+var vocabulary = strings.Fields(`the engine load test speed data model cache query index server client
+	request answer memory thread lock queue worker batch stream file disk network packet latency
+	budget metric graph signal error retry timeout limit buffer socket kernel driver`)
+
+// lectureSegments returns n segments of English-looking speech, 5 seconds and 12 words each,
+// with words drawn pseudo-randomly so phrases rarely repeat
+func lectureSegments(n int) []models.TranscriptSegment {
+	segments := make([]models.TranscriptSegment, n)
+	seed := uint32(1)
+	for i := range segments {
+		words := make([]string, 12)
+		for j := range words {
+			seed = seed*1103515245 + 12345
+			words[j] = vocabulary[int(seed>>16)%len(vocabulary)]
+		}
+		segments[i] = models.TranscriptSegment{
+			Start: float64(i) * 5,
+			End:   float64(i)*5 + 5,
+			Text:  strings.Join(words, " ") + ".",
+		}
+	}
+	return segments
+}
+
+func TestMeasureCaptions_GoodCaptions(t *testing.T) {
+	signals := measureCaptions(lectureSegments(120), 600, "en", false)
+
+	assert.InDelta(t, 2.4, signals.WordsPerSecond, 0.01)
+	assert.Zero(t, signals.AnnotationRatio)
+	assert.Less(t, signals.RepetitionRatio, normalRepetition)
+	assert.False(t, signals.LanguageMismatch)
+	assert.Equal(t, 1.0, signals.score())
+
+	signals.AutoGenerated = true
+	assert.Equal(t, 0.9, signals.score())
+}
+
+func TestMeasureCaptions_Annotations(t *testing.T) {
+	segments := lectureSegments(10)
+	for i := 0; i < 30; i++ {
+		segments = append(segments, models.TranscriptSegment{Text: "[Music]"}, models.TranscriptSegment{Text: "♪ ♪ (applause)"})
+	}
+	signals := measureCaptions(segments, 120, "en", false)
+
+	// 120 words of speech against 30 + 90 annotations
+	assert.InDelta(t, 0.5, signals.AnnotationRatio, 0.01)
+	assert.InDelta(t, 1.0, signals.WordsPerSecond, 0.01, "annotations are not speech")
+	assert.Equal(t, 0.5, signals.score())
+}
+
+func TestMeasureCaptions_Repetition(t *testing.T) {
+	segments := make([]models.TranscriptSegment, 100)
+	for i := range segments {
+		segments[i] = models.TranscriptSegment{Text: "thank you for watching"}
+	}
+	signals := measureCaptions(segments, 200, "en", true)
+
+	assert.Greater(t, signals.RepetitionRatio, 0.95)
+	assert.Less(t, signals.score(), 0.1)
+}
+
+func TestMeasureCaptions_WordRate(t *testing.T) {
+	sparse := measureCaptions(lectureSegments(5), 1200, "en", false)
+	assert.InDelta(t, 0.05, sparse.WordsPerSecond, 0.001)
+	assert.Equal(t, 0.1, sparse.score(), "captions covering a fraction of the speech")
+
+	dense := measureCaptions(lectureSegments(100), 100, "en", false)
+	assert.Equal(t, 0.42, dense.score(), "more words than can be spoken in the video")
+
+	unknown := measureCaptions(lectureSegments(5), 0, "en", false)
+	assert.Zero(t, unknown.WordsPerSecond)
+	assert.Equal(t, 1.0, unknown.score(), "the word rate is not judged without a duration")
+}
+
+func TestMeasureCaptions_LanguageMismatch(t *testing.T) {
+	english := lectureSegments(20)
+	assert.False(t, measureCaptions(english, 100, "en-US", false).LanguageMismatch, "regions are ignored")
+	assert.True(t, measureCaptions(english, 100, "ja", false).LanguageMismatch, "Latin text declared Japanese")
+	assert.Equal(t, 0.5, measureCaptions(english, 100, "ja", false).score())
+
+	japanese := []models.TranscriptSegment{{Text: strings.Repeat("今日はエンジンの負荷について説明します。", 10)}}
+	assert.False(t, measureCaptions(japanese, 0, "ja", false).LanguageMismatch)
+	assert.True(t, measureCaptions(japanese, 0, "en", false).LanguageMismatch)
+}
+
+func TestMeasureCaptions_LatinLanguageMismatch(t *testing.T) {
+	english := []models.TranscriptSegment{{Text: strings.Repeat("This is the part of the talk where we look at the engine and what it does with the load. ", 4)}}
+	spanish := []models.TranscriptSegment{{Text: strings.Repeat("Esta es la parte de la charla en la que vemos el motor y lo que hace con la carga. ", 4)}}
+
+	assert.False(t, measureCaptions(english, 0, "en", false).LanguageMismatch)
+	assert.False(t, measureCaptions(spanish, 0, "es-419", false).LanguageMismatch)
+	assert.True(t, measureCaptions(english, 0, "es", false).LanguageMismatch, "English text declared Spanish")
+	assert.True(t, measureCaptions(spanish, 0, "de", false).LanguageMismatch, "Spanish text declared German")
+	assert.False(t, measureCaptions(english, 0, "sw", false).LanguageMismatch, "only the script is judged without stop words")
+
+	short := []models.TranscriptSegment{{Text: "This is the part of the talk where we look at the engine."}}
+	assert.False(t, measureCaptions(short, 0, "es", false).LanguageMismatch, "short texts are not judged")
+}
@@ -1,20 +1,21 @@
 package transcript
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
-	"path/filepath"
 	"strings"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 	"youtube-video-summarizer/backend/internal/models"
 	"youtube-video-summarizer/backend/internal/repository"
 	"youtube-video-summarizer/backend/internal/services/cost"
 	"youtube-video-summarizer/backend/internal/services/provider"
+	"youtube-video-summarizer/backend/internal/services/source"
+	"youtube-video-summarizer/backend/pkg/artifact"
+	"youtube-video-summarizer/backend/pkg/downloader"
 	"youtube-video-summarizer/backend/pkg/errors"
 	"youtube-video-summarizer/backend/pkg/whisper"
 	"youtube-video-summarizer/backend/pkg/youtube"
@@ -24,36 +25,137 @@ type Service struct {
 	transcriptRepo  repository.TranscriptRepository
 	videoRepo       repository.VideoRepository
 	providerFactory *provider.ProviderFactory
-	youtubeClient   *youtube.Client
-	costService     *cost.Service
-	logger          *zap.Logger
-	tempDir         string
+	sources         *source.Registry
+	downloader      downloader.Downloader
+	workspace       *downloader.Workspace
+	artifacts       artifact.Store // nil disables reuse of downloaded audio
+	chunker         *whisper.Chunker // nil disables splitting of audio too large for a provider
+	// minCaptionQuality is the quality score below which captions are transcribed again with
+	// Whisper; 0 keeps all captions
+	minCaptionQuality float64
+	diarize           bool // ask Whisper providers to label speakers
+	costService       *cost.Service
+	logger            *zap.Logger
 }
 
 func NewService(
 	transcriptRepo repository.TranscriptRepository,
 	videoRepo repository.VideoRepository,
 	providerFactory *provider.ProviderFactory,
-	youtubeClient *youtube.Client,
+	sources *source.Registry,
+	dl downloader.Downloader,
+	workspace *downloader.Workspace,
+	artifacts artifact.Store,
+	chunker *whisper.Chunker,
+	minCaptionQuality float64,
+	diarize bool,
 	costService *cost.Service,
 	logger *zap.Logger,
 ) *Service {
-	tempDir := os.TempDir()
 	return &Service{
-		transcriptRepo:  transcriptRepo,
-		videoRepo:       videoRepo,
-		providerFactory: providerFactory,
-		youtubeClient:   youtubeClient,
-		costService:     costService,
-		logger:          logger,
-		tempDir:         tempDir,
+		transcriptRepo:    transcriptRepo,
+		videoRepo:         videoRepo,
+		providerFactory:   providerFactory,
+		sources:           sources,
+		downloader:        dl,
+		workspace:         workspace,
+		artifacts:         artifacts,
+		chunker:           chunker,
+		minCaptionQuality: minCaptionQuality,
+		diarize:           diarize,
+		costService:       costService,
+		logger:            logger,
 	}
 }
 
+// GetByVideoID returns the primary transcript of a video
 func (s *Service) GetByVideoID(ctx context.Context, videoID uuid.UUID) (*models.Transcript, error) {
 	return s.transcriptRepo.GetByVideoID(ctx, videoID)
 }
 
+// ListByVideoID returns every stored transcript of a video, the primary one first
+func (s *Service) ListByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.Transcript, error) {
+	return s.transcriptRepo.ListByVideoID(ctx, videoID)
+}
+
+// SetPrimary makes one of a video's transcripts the one its analysis uses
+func (s *Service) SetPrimary(ctx context.Context, videoID, transcriptID uuid.UUID) (*models.Transcript, error) {
+	if err := s.transcriptRepo.SetPrimary(ctx, videoID, transcriptID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrTranscriptNotFound(videoID.String())
+		}
+		return nil, errors.ErrDatabaseError("set primary transcript", err)
+	}
+	return s.transcriptRepo.GetByID(ctx, transcriptID)
+}
+
+// Get returns one of a video's stored transcripts
+func (s *Service) Get(ctx context.Context, videoID, transcriptID uuid.UUID) (*models.Transcript, error) {
+	transcript, err := s.transcriptRepo.GetByID(ctx, transcriptID)
+	if err == gorm.ErrRecordNotFound || (err == nil && transcript.VideoID != videoID) {
+		return nil, errors.ErrTranscriptNotFound(videoID.String())
+	}
+	if err != nil {
+		return nil, errors.ErrDatabaseError("get transcript", err)
+	}
+	return transcript, nil
+}
+
+// GetStored returns the stored transcript of a video in a language, or its primary transcript
+// when language is empty. Unlike GetOrCreateTranscript it never fetches or transcribes one.
+func (s *Service) GetStored(ctx context.Context, videoID uuid.UUID, language string) (*models.Transcript, error) {
+	var transcript *models.Transcript
+	var err error
+	if language == "" {
+		transcript, err = s.transcriptRepo.GetByVideoID(ctx, videoID)
+	} else {
+		transcript, err = s.transcriptRepo.GetByLanguage(ctx, videoID, language)
+	}
+	if err == gorm.ErrRecordNotFound {
+		return nil, errors.ErrTranscriptNotFound(videoID.String())
+	}
+	if err != nil {
+		return nil, errors.ErrDatabaseError("get transcript", err)
+	}
+	return transcript, nil
+}
+
+// NameSpeakers sets display names for the speaker labels of a transcript. An empty name
+// removes the name, showing the label again.
+func (s *Service) NameSpeakers(ctx context.Context, videoID, transcriptID uuid.UUID, names map[string]string) (*models.Transcript, error) {
+	transcript, err := s.Get(ctx, videoID, transcriptID)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make(map[string]bool)
+	for _, seg := range transcript.Segments {
+		if seg.Speaker != "" {
+			labels[seg.Speaker] = true
+		}
+	}
+	if transcript.Speakers == nil {
+		transcript.Speakers = models.SpeakerNames{}
+	}
+	for label, name := range names {
+		if !labels[label] {
+			return nil, errors.New(errors.ErrorCodeBadRequest, errors.SubCodeInvalidInput,
+				fmt.Sprintf("Transcript has no speaker %q", label))
+		}
+		if name = strings.TrimSpace(name); name == "" {
+			delete(transcript.Speakers, label)
+		} else {
+			transcript.Speakers[label] = name
+		}
+	}
+
+	// Only the names are written, so a segment edit saved meanwhile is kept
+	if err := s.transcriptRepo.UpdateSpeakers(ctx, transcript.ID, transcript.Speakers); err != nil {
+		return nil, errors.ErrDatabaseError("update transcript speakers", err)
+	}
+	return transcript, nil
+}
+
 // AvailableLanguage represents a language available for captions
 // This matches the handlers.AvailableLanguage type
 type AvailableLanguage struct {
@@ -64,27 +166,28 @@ type AvailableLanguage struct {
 
 // ListAvailableLanguages lists all available caption languages for a YouTube video
 func (s *Service) ListAvailableLanguages(ctx context.Context, youtubeID string) ([]AvailableLanguage, error) {
+	if youtubeID == "" {
+		// Uploaded files have no captions
+		return []AvailableLanguage{}, nil
+	}
+
+	languages, err := s.listNativeLanguages(ctx, youtubeID)
+	if err == nil {
+		return languages, nil
+	}
+	s.logger.Debug("Native caption track listing failed, falling back to yt-dlp",
+		zap.String("youtube_id", youtubeID),
+		zap.Error(err))
+
 	url := fmt.Sprintf("https://www.youtube.com/watch?v=%s", youtubeID)
 	
 	s.logger.Info("Listing available caption languages", zap.String("youtube_id", youtubeID))
 	
 	// Use yt-dlp to list available subtitle tracks
-	cmd := exec.Command("yt-dlp",
-		"--list-subs",        // List available subtitles
-		"--no-playlist",      // Don't download playlists
-		"--quiet",            // Less verbose output
-		"--no-warnings",      // Suppress warnings
-		url,
-	)
-	
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-	
-	if err := cmd.Run(); err != nil {
+	output, err := s.downloader.ListSubtitles(ctx, url)
+	if err != nil {
 		s.logger.Debug("Failed to list subtitles", 
 			zap.String("youtube_id", youtubeID),
-			zap.String("error", stderr.String()),
 			zap.Error(err))
 		return nil, fmt.Errorf("failed to list subtitles: %w", err)
 	}
@@ -96,8 +199,7 @@ func (s *Service) ListAvailableLanguages(ctx context.Context, youtubeID string)
 	// en       English vtt, ttml, srv3, srv2, srv1, json3
 	// tr       Turkish vtt, ttml, srv3, srv2, srv1, json3
 	// ...
-	output := stdout.String()
-	languages := parseLanguageList(output)
+	languages = parseLanguageList(output)
 	
 	if len(languages) == 0 {
 		s.logger.Debug("No subtitles available", zap.String("youtube_id", youtubeID))
@@ -111,6 +213,37 @@ func (s *Service) ListAvailableLanguages(ctx context.Context, youtubeID string)
 	return languages, nil
 }
 
+// listNativeLanguages lists caption tracks through the YouTube source without spawning yt-dlp
+func (s *Service) listNativeLanguages(ctx context.Context, youtubeID string) ([]AvailableLanguage, error) {
+	src, err := s.sources.Get(models.SourceTypeYouTube)
+	if err != nil {
+		return nil, err
+	}
+	lister, ok := src.(source.CaptionTrackLister)
+	if !ok {
+		return nil, fmt.Errorf("source %s cannot list caption tracks", src.Type())
+	}
+
+	tracks, err := lister.ListCaptionTracks(ctx, youtubeID)
+	if err != nil {
+		return nil, err
+	}
+
+	languages := make([]AvailableLanguage, 0, len(tracks))
+	for _, track := range tracks {
+		name := track.Name
+		if name == "" {
+			name = track.Language
+		}
+		languages = append(languages, AvailableLanguage{
+			Code:            track.Language,
+			Name:            name,
+			IsAutoGenerated: track.AutoGenerated,
+		})
+	}
+	return languages, nil
+}
+
 // parseLanguageList parses yt-dlp --list-subs output
 func parseLanguageList(output string) []AvailableLanguage {
 	var languages []AvailableLanguage
@@ -201,20 +334,29 @@ func parseLanguageList(output string) []AvailableLanguage {
 	return languages
 }
 
+// GetOrCreateTranscript returns the primary transcript of a video, or its transcript in
+// languageCode when one is given, creating it from captions or Whisper when missing. Captions
+// scoring below minCaptionQuality are transcribed again with Whisper, and only kept when that
+// fails. Whisper runs at most once per video: it transcribes the spoken language, so later
+// requests for a language it did not produce get that transcript back. A new transcript only
+// becomes primary when the video has none, so asking for another language never changes what
+// summaries and embeddings are built from.
 func (s *Service) GetOrCreateTranscript(ctx context.Context, videoID uuid.UUID, languageCode ...string) (*models.Transcript, error) {
-	// Check if transcript already exists (with specific language if provided)
-	existing, err := s.transcriptRepo.GetByVideoID(ctx, videoID)
+	// Determine language to use
+	lang := ""
+	if len(languageCode) > 0 {
+		lang = languageCode[0]
+	}
+
+	var existing *models.Transcript
+	var err error
+	if lang != "" {
+		existing, err = s.transcriptRepo.GetByLanguage(ctx, videoID, lang)
+	} else {
+		existing, err = s.transcriptRepo.GetByVideoID(ctx, videoID)
+	}
 	if err == nil && existing != nil {
-		// If language is specified and matches, return existing
-		if len(languageCode) > 0 && languageCode[0] != "" {
-			if existing.Language == languageCode[0] {
-				return existing, nil
-			}
-			// Language doesn't match, continue to create new one
-		} else {
-			// No language specified, return existing
-			return existing, nil
-		}
+		return existing, nil
 	}
 
 	// Get video info
@@ -222,26 +364,46 @@ func (s *Service) GetOrCreateTranscript(ctx context.Context, videoID uuid.UUID,
 	if err != nil {
 		return nil, errors.Wrap(err, errors.ErrorCodeVideoNotFound, errors.SubCodeVideoNotFound, "Video not found")
 	}
+	if video.Status == models.VideoStatusScheduled {
+		return nil, errors.ErrVideoScheduled(videoID.String())
+	}
 
-	// Determine language to use
-	lang := ""
-	if len(languageCode) > 0 {
-		lang = languageCode[0]
+	src, err := s.sources.For(video)
+	if err != nil {
+		return nil, err
 	}
 
-	// Try YouTube captions first
-	transcript, err := s.fetchYouTubeCaptions(ctx, video.YouTubeID, lang)
+	// Try published captions first
+	transcript, err := s.fetchCaptions(ctx, src, video, lang)
 	if err == nil && transcript != nil {
 		transcript.VideoID = videoID
-		transcript.Source = "youtube"
-		if err := s.transcriptRepo.Create(ctx, transcript); err != nil {
-			return nil, err
+		transcript.Source = src.Type()
+		if s.minCaptionQuality <= 0 || *transcript.QualityScore >= s.minCaptionQuality {
+			return s.saveCreated(ctx, transcript)
+		}
+
+		s.logger.Info("Captions below quality threshold, transcribing with Whisper",
+			zap.String("video_id", videoID.String()),
+			zap.Float64("quality_score", *transcript.QualityScore),
+			zap.Float64("min_quality", s.minCaptionQuality))
+		transcribed, err := s.createWhisperTranscript(ctx, video)
+		if err == nil {
+			return transcribed, nil
 		}
-		s.updateVideoTranscriptStatus(ctx, videoID, true)
-		return transcript, nil
+		// Poor captions still beat no transcript
+		s.logger.Warn("Whisper fallback failed, keeping low-quality captions",
+			zap.String("video_id", videoID.String()),
+			zap.Error(err))
+		return s.saveCreated(ctx, transcript)
 	}
 
-	// Fallback to Whisper - get provider from settings
+	return s.createWhisperTranscript(ctx, video)
+}
+
+// createWhisperTranscript transcribes a video with the configured Whisper provider and stores
+// the transcript
+func (s *Service) createWhisperTranscript(ctx context.Context, video *models.Video) (*models.Transcript, error) {
+	// Get provider from settings
 	whisperProvider, err := s.providerFactory.GetWhisperProvider(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, errors.ErrorCodeProviderConfiguration, errors.SubCodeProviderConfigMissing, "Failed to get whisper provider")
@@ -249,17 +411,24 @@ func (s *Service) GetOrCreateTranscript(ctx context.Context, videoID uuid.UUID,
 	
 	if whisperProvider == nil {
 		// YouTube provider selected, but captions not available
-		return nil, fmt.Errorf("YouTube captions not available and no whisper provider configured")
+		return nil, fmt.Errorf("captions not available and no whisper provider configured")
+	}
+
+	// Whisper transcribes the spoken language whatever was requested, so transcribing again
+	// for another language would only produce the same transcript
+	if existing, err := s.findWhisperTranscript(ctx, video.ID); err != nil || existing != nil {
+		return existing, err
 	}
 
-	transcript, err = s.transcribeWithWhisper(ctx, video.YouTubeID, whisperProvider)
+	transcript, err := s.transcribeWithWhisper(ctx, video, whisperProvider)
 	if err != nil {
 		return nil, fmt.Errorf("failed to transcribe: %w", err)
 	}
 
-	transcript.VideoID = videoID
+	transcript.VideoID = video.ID
 	transcript.Source = "whisper"
-	if err := s.transcriptRepo.Create(ctx, transcript); err != nil {
+	transcript, err = s.save(ctx, transcript)
+	if err != nil {
 		return nil, err
 	}
 
@@ -271,7 +440,7 @@ func (s *Service) GetOrCreateTranscript(ctx context.Context, videoID uuid.UUID,
 		
 		_ = s.costService.RecordUsage(
 			ctx,
-			videoID,
+			video.ID,
 			"transcription",
 			modelInfo.Provider,
 			modelInfo.Name,
@@ -280,258 +449,169 @@ func (s *Service) GetOrCreateTranscript(ctx context.Context, videoID uuid.UUID,
 		)
 	}
 
-	s.updateVideoTranscriptStatus(ctx, videoID, true)
+	s.updateVideoTranscriptStatus(ctx, video.ID, true)
 	return transcript, nil
 }
 
-func (s *Service) fetchYouTubeCaptions(ctx context.Context, youtubeID string, languageCode string) (*models.Transcript, error) {
-	url := fmt.Sprintf("https://www.youtube.com/watch?v=%s", youtubeID)
-	
-	s.logger.Info("Fetching YouTube captions", 
-		zap.String("youtube_id", youtubeID),
-		zap.String("language", languageCode))
-	
-	// Download captions
-	captionPath := filepath.Join(s.tempDir, fmt.Sprintf("%s.%%(ext)s", youtubeID))
-	
-	cmdArgs := []string{
-		"--write-auto-subs",     // Include auto-generated subtitles
-		"--write-subs",           // Write subtitle file
-		"--sub-format", "vtt",   // Use WebVTT format (easier to parse)
-		"--skip-download",       // Don't download video/audio
-		"--no-playlist",         // Don't download playlists
-		"--quiet",               // Less verbose output
-		"--no-warnings",         // Suppress warnings
-		"-o", captionPath,     // Output path
+// findWhisperTranscript returns the Whisper transcript of a video, or nil when it has none
+func (s *Service) findWhisperTranscript(ctx context.Context, videoID uuid.UUID) (*models.Transcript, error) {
+	transcripts, err := s.transcriptRepo.ListByVideoID(ctx, videoID)
+	if err != nil {
+		return nil, errors.ErrDatabaseError("list transcripts", err)
 	}
-	
-	// If language is specified, use it; otherwise let yt-dlp choose
-	if languageCode != "" {
-		cmdArgs = append(cmdArgs, "--sub-lang", languageCode)
-	} else {
-		// Default: prefer common languages, but let yt-dlp choose best available
-		cmdArgs = append(cmdArgs, "--sub-lang", "en,en-US,en-GB,tr,de,fr,es,it,pt,ru,ja,ko,zh,ar,hi")
+	for _, transcript := range transcripts {
+		if transcript.Source == "whisper" {
+			return transcript, nil
+		}
 	}
-	
-	cmdArgs = append(cmdArgs, url)
-	cmd := exec.Command("yt-dlp", cmdArgs...)
-	
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-	
-	if err := cmd.Run(); err != nil {
-		errorMsg := stderr.String()
-		s.logger.Debug("Failed to download captions", 
-			zap.String("youtube_id", youtubeID),
-			zap.String("error", errorMsg),
-			zap.Error(err))
-		return nil, fmt.Errorf("failed to download captions: %w", err)
+	return nil, nil
+}
+
+// saveCreated stores a transcript made from captions and marks the video as transcribed
+func (s *Service) saveCreated(ctx context.Context, transcript *models.Transcript) (*models.Transcript, error) {
+	transcript, err := s.save(ctx, transcript)
+	if err != nil {
+		return nil, err
 	}
-	
-	// Find the downloaded caption file
-	pattern := filepath.Join(s.tempDir, fmt.Sprintf("%s.*.vtt", youtubeID))
-	matches, err := filepath.Glob(pattern)
-	if err != nil || len(matches) == 0 {
-		// Try without language code
-		pattern = filepath.Join(s.tempDir, fmt.Sprintf("%s.vtt", youtubeID))
-		matches, err = filepath.Glob(pattern)
-		if err != nil || len(matches) == 0 {
-			return nil, fmt.Errorf("caption file not found after download")
-		}
+	s.updateVideoTranscriptStatus(ctx, transcript.VideoID, true)
+	return transcript, nil
+}
+
+// save stores a new transcript, unless the video already has one in the same language from
+// the same source (e.g. captions published in another language than requested, or stored by a
+// concurrent request), which is returned instead
+func (s *Service) save(ctx context.Context, transcript *models.Transcript) (*models.Transcript, error) {
+	existing, err := s.transcriptRepo.GetByKey(ctx, transcript.VideoID, transcript.Language, transcript.Source)
+	if err == nil {
+		return existing, nil
 	}
-	
-	captionFile := matches[0]
-	defer func() {
-		// Clean up caption file
-		if err := os.Remove(captionFile); err != nil {
-			s.logger.Debug("Failed to cleanup caption file", zap.String("path", captionFile), zap.Error(err))
+	if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+	if err := s.transcriptRepo.Create(ctx, transcript); err != nil {
+		if !errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, err
 		}
-	}()
-	
-	// Parse VTT file
-	content, err := os.ReadFile(captionFile)
+		// Lost the race to another request: use the transcript it stored
+		return s.transcriptRepo.GetByKey(ctx, transcript.VideoID, transcript.Language, transcript.Source)
+	}
+	return transcript, nil
+}
+
+// fetchCaptions gets the captions published by the video's source and parses them
+func (s *Service) fetchCaptions(ctx context.Context, src source.MediaSource, video *models.Video, languageCode string) (*models.Transcript, error) {
+	captions, err := src.FetchCaptions(ctx, video, languageCode)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read caption file: %w", err)
+		return nil, err
 	}
-	
-	transcript, segments, detectedLang := parseVTT(string(content))
-	
+
+	var transcript, detectedLang string
+	var segments []models.TranscriptSegment
+	switch captions.Format {
+	case "vtt":
+		transcript, segments, detectedLang = parseVTT(captions.Content)
+	case youtube.CaptionFormatJSON3, youtube.CaptionFormatTimedText:
+		transcript, segments, err = parseCaptionCues(captions)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported caption format: %s", captions.Format)
+	}
+
 	if transcript == "" {
 		return nil, fmt.Errorf("no transcript content found in captions")
 	}
-	
-	// Use detected language or fallback to provided/requested language
+
+	// Use detected language or fallback to the source's/requested language
 	finalLang := detectedLang
+	if finalLang == "" {
+		finalLang = captions.Language
+	}
 	if finalLang == "" && languageCode != "" {
 		finalLang = languageCode
 	}
 	if finalLang == "" {
 		finalLang = "en" // Ultimate fallback
 	}
-	
-	s.logger.Info("YouTube captions fetched successfully",
-		zap.String("youtube_id", youtubeID),
+
+	// Captions with word timings are the rolling captions of speech recognition
+	autoGenerated := captions.AutoGenerated
+	for _, seg := range segments {
+		autoGenerated = autoGenerated || len(seg.Words) > 0
+	}
+	signals := measureCaptions(segments, video.Duration, finalLang, autoGenerated)
+	score := signals.score()
+
+	s.logger.Info("Captions fetched successfully",
+		zap.String("video_id", video.ID.String()),
+		zap.String("source", src.Type()),
 		zap.String("language", finalLang),
 		zap.Int("content_length", len(transcript)),
-		zap.Int("segments", len(segments)))
-	
+		zap.Int("segments", len(segments)),
+		zap.Float64("quality_score", score),
+		zap.Bool("auto_generated", signals.AutoGenerated),
+		zap.Float64("words_per_second", signals.WordsPerSecond),
+		zap.Float64("annotation_ratio", signals.AnnotationRatio),
+		zap.Float64("repetition_ratio", signals.RepetitionRatio),
+		zap.Bool("language_mismatch", signals.LanguageMismatch))
+
 	return &models.Transcript{
-		Language: finalLang,
-		Content:  transcript,
-		Segments: models.TranscriptSegments(segments),
+		Language:     finalLang,
+		Content:      transcript,
+		Segments:     models.TranscriptSegments(segments),
+		QualityScore: &score,
 	}, nil
 }
 
-// parseVTT parses WebVTT format and extracts transcript text, segments, and language
-func parseVTT(vttContent string) (string, []models.TranscriptSegment, string) {
-	lines := strings.Split(vttContent, "\n")
-	var transcript strings.Builder
-	var segments []models.TranscriptSegment
-	var detectedLang string
-	
-	var currentSegment *models.TranscriptSegment
-	var segmentText strings.Builder
-	
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		
-		// Extract language from Language: line
-		if strings.HasPrefix(line, "Language:") {
-			langPart := strings.TrimPrefix(line, "Language:")
-			langPart = strings.TrimSpace(langPart)
-			if langPart != "" {
-				// Extract language code (e.g., "en" from "Language: en" or "Language: en-US")
-				langParts := strings.Fields(langPart)
-				if len(langParts) > 0 {
-					detectedLang = strings.ToLower(langParts[0])
-				}
-			}
-			continue
-		}
-		
-		// Skip VTT header and metadata
-		if strings.HasPrefix(line, "WEBVTT") || 
-		   strings.HasPrefix(line, "NOTE") ||
-		   line == "" ||
-		   strings.HasPrefix(line, "Kind:") {
-			continue
-		}
-		
-		// Parse timestamp line (format: 00:00:00.000 --> 00:00:05.000)
-		if strings.Contains(line, "-->") {
-			// Save previous segment if exists
-			if currentSegment != nil && segmentText.Len() > 0 {
-				currentSegment.Text = strings.TrimSpace(segmentText.String())
-				segments = append(segments, *currentSegment)
-				transcript.WriteString(currentSegment.Text)
-				transcript.WriteString(" ")
-				segmentText.Reset()
-			}
-			
-			// Parse timestamps
-			parts := strings.Split(line, "-->")
-			if len(parts) == 2 {
-				start := parseVTTTime(parts[0])
-				end := parseVTTTime(parts[1])
-				
-				currentSegment = &models.TranscriptSegment{
-					Start: start,
-					End:   end,
-				}
-			}
-			continue
-		}
-		
-		// Text content
-		if currentSegment != nil && line != "" {
-			segmentText.WriteString(line)
-			segmentText.WriteString(" ")
-		}
-	}
-	
-	// Save last segment
-	if currentSegment != nil && segmentText.Len() > 0 {
-		currentSegment.Text = strings.TrimSpace(segmentText.String())
-		segments = append(segments, *currentSegment)
-		transcript.WriteString(currentSegment.Text)
+// parseCaptionCues parses YouTube json3/timedtext captions into transcript text and segments
+func parseCaptionCues(captions *source.Captions) (string, []models.TranscriptSegment, error) {
+	cues, err := youtube.ParseCaptions(&youtube.CaptionFile{
+		Format:   captions.Format,
+		Language: captions.Language,
+		Data:     []byte(captions.Content),
+	})
+	if err != nil {
+		return "", nil, err
 	}
-	
-	return strings.TrimSpace(transcript.String()), segments, detectedLang
-}
 
-// parseVTTTime parses VTT timestamp format (HH:MM:SS.mmm or MM:SS.mmm)
-func parseVTTTime(timeStr string) float64 {
-	timeStr = strings.TrimSpace(timeStr)
-	
-	// Remove any cue settings (e.g., "00:00:00.000 align:start position:0%")
-	if idx := strings.Index(timeStr, " "); idx > 0 {
-		timeStr = timeStr[:idx]
-	}
-	
-	parts := strings.Split(timeStr, ":")
-	if len(parts) < 2 {
-		return 0
-	}
-	
-	var hours, minutes, seconds float64
-	
-	if len(parts) == 3 {
-		// Format: HH:MM:SS.mmm
-		fmt.Sscanf(parts[0], "%f", &hours)
-		fmt.Sscanf(parts[1], "%f", &minutes)
-		fmt.Sscanf(parts[2], "%f", &seconds)
-	} else {
-		// Format: MM:SS.mmm
-		fmt.Sscanf(parts[0], "%f", &minutes)
-		fmt.Sscanf(parts[1], "%f", &seconds)
+	texts := make([]string, 0, len(cues))
+	segments := make([]models.TranscriptSegment, 0, len(cues))
+	for _, cue := range cues {
+		texts = append(texts, cue.Text)
+		segments = append(segments, models.TranscriptSegment{
+			Start: cue.Start,
+			End:   cue.End,
+			Text:  cue.Text,
+		})
 	}
-	
-	return hours*3600 + minutes*60 + seconds
+	return strings.Join(texts, " "), segments, nil
 }
 
-func (s *Service) transcribeWithWhisper(ctx context.Context, youtubeID string, whisperProvider whisper.WhisperProvider) (*models.Transcript, error) {
-	// Download audio using yt-dlp
-	audioPath, err := s.downloadAudio(youtubeID)
+func (s *Service) transcribeWithWhisper(ctx context.Context, video *models.Video, whisperProvider whisper.WhisperProvider) (*models.Transcript, error) {
+	audioPath, cleanup, err := s.GetAudio(ctx, video)
 	if err != nil {
-		return nil, errors.Wrap(err, errors.ErrorCodeYouTubeDownload, errors.SubCodeYouTubeDownloadFailed, "Failed to download audio")
+		return nil, err
 	}
-	
-	// Ensure audio file is cleaned up, even on error
-	// Use a helper function to safely remove the file
-	defer func() {
-		if err := s.cleanupAudioFile(audioPath); err != nil {
-			s.logger.Warn("Failed to cleanup audio file", 
-				zap.String("path", audioPath), 
-				zap.Error(err))
-		}
-	}()
+	defer cleanup()
 
-	// Check file size - Groq has 25MB limit
-	info, err := os.Stat(audioPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get audio file info: %w", err)
+	req := whisper.TranscribeRequest{
+		AudioPath: audioPath,
+		Task:        "transcribe",
+		WordTimings: true,
+		Diarize:     s.diarize,
 	}
 
-	// Groq API limit is 25MB (25 * 1024 * 1024 bytes)
-	const groqMaxSize = 25 * 1024 * 1024
-	modelInfo := whisperProvider.GetModelInfo()
-	
-	if modelInfo.Provider == "groq" && info.Size() > groqMaxSize {
-		fileSizeMB := float64(info.Size()) / (1024 * 1024)
-		maxSizeMB := float64(groqMaxSize) / (1024 * 1024)
-		s.logger.Warn("Audio file too large for Groq API",
-			zap.String("youtube_id", youtubeID),
-			zap.Float64("file_size_mb", fileSizeMB),
-			zap.Float64("max_size_mb", maxSizeMB),
-			zap.String("suggestion", "Use local whisper provider in settings"))
-		return nil, errors.ErrProviderFileTooLarge("groq", fileSizeMB, maxSizeMB)
+	// Transcribe with Whisper; audio over the provider's upload limit is split into chunks
+	var resp *whisper.TranscribeResponse
+	if s.chunker != nil {
+		resp, err = s.chunker.Transcribe(ctx, whisperProvider, req)
+	} else {
+		if err := s.checkUploadLimit(video, whisperProvider, audioPath); err != nil {
+			return nil, err
+		}
+		resp, err = whisperProvider.Transcribe(ctx, req)
 	}
-
-	// Transcribe with Whisper
-	resp, err := whisperProvider.Transcribe(ctx, whisper.TranscribeRequest{
-		AudioPath: audioPath,
-		Task:      "transcribe",
-	})
 	if err != nil {
 		return nil, errors.Wrap(err, errors.ErrorCodeWhisperAPI, errors.SubCodeWhisperAPIFailed, "Whisper transcription failed")
 	}
@@ -540,9 +620,17 @@ func (s *Service) transcribeWithWhisper(ctx context.Context, youtubeID string, w
 	segments := make([]models.TranscriptSegment, len(resp.Segments))
 	for i, seg := range resp.Segments {
 		segments[i] = models.TranscriptSegment{
-			Start: seg.Start,
-			End:   seg.End,
-			Text:  seg.Text,
+			Start:   seg.Start,
+			End:     seg.End,
+			Text:    seg.Text,
+			Speaker: seg.Speaker,
+		}
+		for _, word := range seg.Words {
+			segments[i].Words = append(segments[i].Words, models.TranscriptWord{
+				Start: word.Start,
+				End:   word.End,
+				Text:  word.Text,
+			})
 		}
 	}
 
@@ -553,112 +641,95 @@ func (s *Service) transcribeWithWhisper(ctx context.Context, youtubeID string, w
 	}, nil
 }
 
-// DownloadAudio downloads audio for a YouTube video and returns the file path
-func (s *Service) DownloadAudio(youtubeID string) (string, error) {
-	return s.downloadAudio(youtubeID)
+// checkUploadLimit rejects audio larger than the provider accepts, for when chunking is off
+func (s *Service) checkUploadLimit(video *models.Video, whisperProvider whisper.WhisperProvider, audioPath string) error {
+	modelInfo := whisperProvider.GetModelInfo()
+	if modelInfo.MaxFileSize <= 0 {
+		return nil
+	}
+	info, err := os.Stat(audioPath)
+	if err != nil {
+		return fmt.Errorf("failed to get audio file info: %w", err)
+	}
+	if info.Size() <= modelInfo.MaxFileSize {
+		return nil
+	}
+
+	fileSizeMB := float64(info.Size()) / (1024 * 1024)
+	maxSizeMB := float64(modelInfo.MaxFileSize) / (1024 * 1024)
+	s.logger.Warn("Audio file too large for provider",
+		zap.String("video_id", video.ID.String()),
+		zap.String("provider", modelInfo.Provider),
+		zap.Float64("file_size_mb", fileSizeMB),
+		zap.Float64("max_size_mb", maxSizeMB),
+		zap.String("suggestion", "Enable audio chunking or use local whisper provider in settings"))
+	return errors.ErrProviderFileTooLarge(modelInfo.Provider, fileSizeMB, maxSizeMB)
 }
 
-func (s *Service) downloadAudio(youtubeID string) (string, error) {
-	url := fmt.Sprintf("https://www.youtube.com/watch?v=%s", youtubeID)
-	outputPath := filepath.Join(s.tempDir, fmt.Sprintf("%s.%%(ext)s", youtubeID))
-
-	// Clean up any existing files for this video ID before downloading
-	// This prevents rename errors from yt-dlp
-	cleanupPattern := filepath.Join(s.tempDir, fmt.Sprintf("%s.*", youtubeID))
-	existingFiles, _ := filepath.Glob(cleanupPattern)
-	for _, match := range existingFiles {
-		// Skip .part files as they might be in use
-		if !strings.HasSuffix(match, ".part") {
-			if err := os.Remove(match); err != nil {
-				s.logger.Debug("Failed to remove existing file", 
-					zap.String("path", match), 
-					zap.Error(err))
-			}
-		}
+// GetAudio returns a local audio (or media) file for a video: the stored file for uploads,
+// otherwise the audio kept in the artifact store by an earlier stage or a fresh download, which
+// is then stored for the next one. Concurrent calls for the same video share one download in
+// its own job directory. Call cleanup when done with the file; the local copy is removed once
+// every caller has, and an uploaded original is never removed.
+func (s *Service) GetAudio(ctx context.Context, video *models.Video) (string, func(), error) {
+	src, err := s.sources.For(video)
+	if err != nil {
+		return "", nil, err
 	}
 
-	s.logger.Info("Downloading audio", 
-		zap.String("youtube_id", youtubeID),
-		zap.String("output_path", outputPath))
-
-	cmd := exec.Command("yt-dlp",
-		"-x",                    // Extract audio
-		"--audio-format", "mp3", // Format
-		"--no-playlist",         // Don't download playlists
-		"--quiet",               // Less verbose output
-		"--no-warnings",         // Suppress warnings
-		"--no-mtime",            // Don't use modification time (prevents some file issues)
-		"-o", outputPath,        // Output path
-		url,
-	)
-
-	// Capture stderr for better error messages
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		errorMsg := stderr.String()
-		s.logger.Error("yt-dlp failed", 
-			zap.String("youtube_id", youtubeID),
-			zap.String("error", errorMsg),
-			zap.Error(err))
-		if errorMsg != "" {
-			return "", errors.NewWithError(
-				errors.ErrorCodeYouTubeDownload,
-				errors.SubCodeYouTubeDownloadFailed,
-				"Failed to download audio file",
-				fmt.Errorf("yt-dlp: %s", errorMsg),
-			)
+	path, cleanup, err := s.workspace.Fetch(ctx, video.ID.String(), func(ctx context.Context, dir string) (string, func(), error) {
+		if path, ok := s.fetchArtifact(ctx, video, dir); ok {
+			return path, nil, nil
+		}
+		path, cleanup, err := src.DownloadAudio(ctx, video, dir)
+		if err != nil {
+			return "", nil, err
 		}
-		return "", errors.Wrap(err, errors.ErrorCodeYouTubeDownload, errors.SubCodeYouTubeDownloadFailed, "Failed to download audio file")
+		s.storeArtifact(ctx, video, path)
+		return path, cleanup, nil
+	})
+	if errors.Is(err, downloader.ErrDiskQuotaExceeded) {
+		s.logger.Warn("Download disk quota exceeded", zap.String("video_id", video.ID.String()))
+		return "", nil, errors.ErrDownloadDiskQuota(err)
 	}
+	return path, cleanup, err
+}
 
-	// Find the actual output file
-	pattern := filepath.Join(s.tempDir, fmt.Sprintf("%s.*", youtubeID))
-	var matches []string
-	matches, err := filepath.Glob(pattern)
-	if err != nil {
-		return "", fmt.Errorf("failed to find output file: %w", err)
+// fetchArtifact copies the stored audio of a video into dir, if there is any
+func (s *Service) fetchArtifact(ctx context.Context, video *models.Video, dir string) (string, bool) {
+	if s.artifacts == nil || video.SourceType == models.SourceTypeUpload {
+		return "", false
 	}
-	if len(matches) == 0 {
-		return "", fmt.Errorf("output file not found after download")
+	stored, err := s.artifacts.Find(ctx, video.ID.String())
+	if err != nil {
+		if !errors.Is(err, artifact.ErrNotFound) {
+			s.logger.Warn("Failed to look up stored audio", zap.String("video_id", video.ID.String()), zap.Error(err))
+		}
+		return "", false
 	}
-
-	audioPath := matches[0]
-	
-	// Verify file exists and get size
-	info, err := os.Stat(audioPath)
+	path, err := s.artifacts.Fetch(ctx, stored, dir)
 	if err != nil {
-		return "", fmt.Errorf("downloaded file not accessible: %w", err)
+		s.logger.Warn("Failed to fetch stored audio, downloading it again",
+			zap.String("video_id", video.ID.String()),
+			zap.String("key", stored.Key),
+			zap.Error(err))
+		return "", false
 	}
-	
-	s.logger.Info("Audio downloaded successfully",
-		zap.String("youtube_id", youtubeID),
-		zap.String("path", audioPath),
-		zap.Int64("size_bytes", info.Size()))
-
-	return audioPath, nil
+	s.logger.Info("Reusing stored audio",
+		zap.String("video_id", video.ID.String()),
+		zap.String("key", stored.Key))
+	return path, true
 }
 
-// cleanupAudioFile safely removes an audio file, handling errors gracefully
-func (s *Service) cleanupAudioFile(audioPath string) error {
-	if audioPath == "" {
-		return nil
-	}
-
-	// Check if file exists before trying to remove
-	if _, err := os.Stat(audioPath); os.IsNotExist(err) {
-		// File doesn't exist, nothing to clean up
-		return nil
+// storeArtifact keeps a downloaded audio file for later stages. Failing to store it only costs
+// a download later, so errors are logged.
+func (s *Service) storeArtifact(ctx context.Context, video *models.Video, path string) {
+	if s.artifacts == nil || video.SourceType == models.SourceTypeUpload {
+		return
 	}
-
-	// Remove the file
-	if err := os.Remove(audioPath); err != nil {
-		return fmt.Errorf("failed to remove audio file: %w", err)
+	if _, err := s.artifacts.Put(ctx, video.ID.String(), path); err != nil {
+		s.logger.Warn("Failed to store audio", zap.String("video_id", video.ID.String()), zap.Error(err))
 	}
-
-	s.logger.Debug("Audio file cleaned up", zap.String("path", audioPath))
-	return nil
 }
 
 func (s *Service) updateVideoTranscriptStatus(ctx context.Context, videoID uuid.UUID, hasTranscript bool) {
@@ -0,0 +1,282 @@
+package transcript
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+const (
+	// maxSegmentDuration bounds a merged segment, since auto-generated captions have no
+	// punctuation to end sentences at
+	maxSegmentDuration = 20.0
+	// segmentPause is a gap between cues long enough to start a new segment
+	segmentPause = 2.0
+)
+
+var (
+	// vttTagPattern matches cue markup: <c>, </c>, <c.colorE5E5E5>, <v Speaker>, <i>, and
+	// the <00:00:01.000> timestamps of auto-generated captions
+	vttTagPattern       = regexp.MustCompile(`<[^>]*>`)
+	vttTimestampPattern = regexp.MustCompile(`^(?:\d+:)?\d{2}:\d{2}\.\d{3}$`)
+)
+
+// vttCue is one cue of a WebVTT file
+type vttCue struct {
+	start float64
+	end   float64
+	lines []string // raw cue text, markup included
+}
+
+// captionWord is a word of cue text, with the time it is spoken when the cue says so
+type captionWord struct {
+	text  string
+	start float64
+	timed bool
+}
+
+// captionPiece is the text a cue adds to the transcript once repeated lines are dropped
+type captionPiece struct {
+	start float64
+	end   float64
+	words []captionWord
+}
+
+// parseVTT parses a WebVTT file into transcript text, sentence-level segments, and the
+// language declared in its header.
+//
+// YouTube's auto-generated captions roll: every cue repeats the line shown before it and
+// times each new word with inline tags. Lines already shown by the previous cue are dropped,
+// markup is stripped, and the inline timings are kept as segment words.
+func parseVTT(vttContent string) (string, []models.TranscriptSegment, string) {
+	cues, language := readVTTCues(vttContent)
+	segments := mergeCaptionPieces(dropRepeatedLines(cues))
+
+	texts := make([]string, 0, len(segments))
+	for _, seg := range segments {
+		texts = append(texts, seg.Text)
+	}
+	return strings.Join(texts, " "), segments, language
+}
+
+// readVTTCues splits a WebVTT file into cues and reads the language from its header. NOTE,
+// STYLE and REGION blocks are skipped.
+func readVTTCues(content string) ([]vttCue, string) {
+	content = strings.TrimPrefix(content, "\ufeff")
+	content = strings.ReplaceAll(strings.ReplaceAll(content, "\r\n", "\n"), "\r", "\n")
+
+	var cues []vttCue
+	var language string
+	for i, block := range strings.Split(content, "\n\n") {
+		lines := strings.Split(strings.Trim(block, "\n"), "\n")
+		if i == 0 && strings.HasPrefix(lines[0], "WEBVTT") {
+			for _, line := range lines[1:] {
+				if value, ok := strings.CutPrefix(line, "Language:"); ok {
+					if fields := strings.Fields(value); len(fields) > 0 {
+						language = strings.ToLower(fields[0])
+					}
+				}
+			}
+			continue
+		}
+
+		timing := -1
+		for j, line := range lines {
+			if strings.Contains(line, "-->") {
+				timing = j
+				break
+			}
+		}
+		// Not a cue (NOTE, STYLE, REGION), or a cue whose identifier is the only line left
+		if timing < 0 {
+			continue
+		}
+		parts := strings.SplitN(lines[timing], "-->", 2)
+		cue := vttCue{start: parseVTTTime(parts[0]), end: parseVTTTime(parts[1])}
+		for _, line := range lines[timing+1:] {
+			if strings.TrimSpace(line) != "" {
+				cue.lines = append(cue.lines, line)
+			}
+		}
+		if len(cue.lines) > 0 {
+			cues = append(cues, cue)
+		}
+	}
+	return cues, language
+}
+
+// dropRepeatedLines returns what each cue adds to the transcript. A line the previous cue
+// already showed is dropped; a line that continues one of them keeps only the new words.
+func dropRepeatedLines(cues []vttCue) []captionPiece {
+	var pieces []captionPiece
+	var shown [][]string
+	for _, cue := range cues {
+		piece := captionPiece{start: cue.start, end: cue.end}
+		visible := make([][]string, 0, len(cue.lines))
+		for _, line := range cue.lines {
+			words := parseCueLine(line, cue.start)
+			if len(words) == 0 {
+				continue
+			}
+			texts := normalizedWords(words)
+			visible = append(visible, texts)
+			piece.words = append(piece.words, words[repeatedPrefix(texts, shown):]...)
+		}
+		shown = visible
+
+		if len(piece.words) == 0 {
+			continue
+		}
+		if piece.words[0].timed && piece.words[0].start > piece.start {
+			piece.start = piece.words[0].start
+		}
+		pieces = append(pieces, piece)
+	}
+	return pieces
+}
+
+// repeatedPrefix returns how many leading words of a line were already shown by one of the
+// previous cue's lines
+func repeatedPrefix(line []string, shown [][]string) int {
+	longest := 0
+	for _, prev := range shown {
+		if len(prev) > len(line) || len(prev) <= longest {
+			continue
+		}
+		match := true
+		for i := range prev {
+			if prev[i] != line[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			longest = len(prev)
+		}
+	}
+	return longest
+}
+
+// parseCueLine splits one line of cue text into words, timing them from the inline
+// timestamps when the line has any. Words before the first timestamp start with the cue.
+func parseCueLine(line string, cueStart float64) []captionWord {
+	timed := false
+	start := cueStart
+	var words []captionWord
+	var text strings.Builder
+	last := 0
+	for _, loc := range vttTagPattern.FindAllStringIndex(line, -1) {
+		text.WriteString(line[last:loc[0]])
+		last = loc[1]
+		// Other markup is dropped without breaking the text around it ("<i>every</i>thing")
+		tag := line[loc[0]+1 : loc[1]-1]
+		if !vttTimestampPattern.MatchString(tag) {
+			continue
+		}
+		words = appendCaptionWords(words, text.String(), start)
+		text.Reset()
+		timed = true
+		start = parseVTTTime(tag)
+	}
+	text.WriteString(line[last:])
+	words = appendCaptionWords(words, text.String(), start)
+
+	if timed {
+		for i := range words {
+			words[i].timed = true
+		}
+	}
+	return words
+}
+
+func appendCaptionWords(words []captionWord, text string, start float64) []captionWord {
+	for _, word := range strings.Fields(html.UnescapeString(text)) {
+		words = append(words, captionWord{text: word, start: start})
+	}
+	return words
+}
+
+// normalizedWords returns the words of a line as compared for repetition
+func normalizedWords(words []captionWord) []string {
+	texts := make([]string, len(words))
+	for i, word := range words {
+		texts[i] = strings.ToLower(word.text)
+	}
+	return texts
+}
+
+// mergeCaptionPieces joins consecutive pieces into segments ending at the end of a sentence,
+// at a pause, or once a segment would grow longer than maxSegmentDuration
+func mergeCaptionPieces(pieces []captionPiece) []models.TranscriptSegment {
+	var segments []models.TranscriptSegment
+	var current []captionPiece
+	flush := func() {
+		if len(current) > 0 {
+			segments = append(segments, buildSegment(current))
+			current = nil
+		}
+	}
+
+	for _, piece := range pieces {
+		if n := len(current); n > 0 {
+			last := current[n-1]
+			if endsSentence(last.words[len(last.words)-1].text) ||
+				piece.start-last.end > segmentPause ||
+				piece.end-current[0].start > maxSegmentDuration {
+				flush()
+			}
+		}
+		current = append(current, piece)
+	}
+	flush()
+	return segments
+}
+
+// buildSegment turns pieces into one segment, keeping word timings only when every word
+// has one. A word lasts until the next word starts, the last one until its cue ends.
+func buildSegment(pieces []captionPiece) models.TranscriptSegment {
+	seg := models.TranscriptSegment{Start: pieces[0].start, End: pieces[len(pieces)-1].end}
+	allTimed := true
+	var texts []string
+	var words []models.TranscriptWord
+	for _, piece := range pieces {
+		for i, word := range piece.words {
+			texts = append(texts, word.text)
+			allTimed = allTimed && word.timed
+			end := piece.end
+			if i+1 < len(piece.words) {
+				end = piece.words[i+1].start
+			}
+			words = append(words, models.TranscriptWord{Start: word.start, End: end, Text: word.text})
+		}
+	}
+	seg.Text = strings.Join(texts, " ")
+	if allTimed {
+		seg.Words = words
+	}
+	return seg
+}
+
+// endsSentence reports whether a word ends a sentence
+func endsSentence(word string) bool {
+	word = strings.TrimRight(word, `"')]»”’`)
+	return strings.HasSuffix(word, ".") || strings.HasSuffix(word, "!") || strings.HasSuffix(word, "?") ||
+		strings.HasSuffix(word, "…") || strings.HasSuffix(word, "。") || strings.HasSuffix(word, "！") ||
+		strings.HasSuffix(word, "？")
+}
+
+// parseVTTTime parses VTT timestamp format (HH:MM:SS.mmm or MM:SS.mmm)
+func parseVTTTime(timeStr string) float64 {
+	fields := strings.Fields(timeStr)
+	if len(fields) == 0 {
+		return 0
+	}
+	// Cue settings may follow the time (e.g., "00:00:00.000 align:start position:0%")
+	seconds, err := ParseSeconds(strings.Replace(fields[0], ",", ".", 1))
+	if err != nil {
+		return 0
+	}
+	return seconds
+}
@@ -0,0 +1,78 @@
+package transcript
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"youtube-video-summarizer/backend/internal/models"
+)
+
+func TestParseVTT_RollingAutoCaptions(t *testing.T) {
+	content, err := os.ReadFile("testdata/auto-captions.en.vtt")
+	require.NoError(t, err)
+
+	text, segments, language := parseVTT(string(content))
+
+	assert.Equal(t, "en", language)
+	assert.Equal(t, "we're no strangers to love you know the rules and so do I a full commitment's what I'm thinking of", text)
+	// Cues follow each other until the pause at 5.04s
+	require.Len(t, segments, 2)
+	assert.Equal(t, 0.0, segments[0].Start)
+	assert.Equal(t, 5.03, segments[0].End)
+	assert.Equal(t, "we're no strangers to love you know the rules and so do I", segments[0].Text)
+	assert.Equal(t, 9.0, segments[1].Start)
+	assert.Equal(t, 11.27, segments[1].End)
+
+	words := segments[0].Words
+	require.Len(t, words, 13)
+	assert.Equal(t, models.TranscriptWord{Start: 0, End: 0.48, Text: "we're"}, words[0])
+	assert.Equal(t, models.TranscriptWord{Start: 1.439, End: 2.35, Text: "love"}, words[4])
+	assert.Equal(t, models.TranscriptWord{Start: 2.36, End: 2.6, Text: "you"}, words[5])
+	assert.Equal(t, models.TranscriptWord{Start: 4.319, End: 5.03, Text: "I"}, words[12])
+}
+
+func TestParseVTT_SentenceSegments(t *testing.T) {
+	vtt := "\ufeffWEBVTT\r\n\r\n" +
+		"NOTE exported from the editor\r\n\r\n" +
+		"STYLE\r\n::cue { color: yellow }\r\n\r\n" +
+		"intro\r\n00:00:01.000 --> 00:00:03.000\r\n<v Host>Welcome back to the show,</v>\r\n\r\n" +
+		"00:00:03.000 --> 00:00:05.500 line:90%\r\nwhere we talk &amp; argue\r\nabout <i>everything</i>.\r\n\r\n" +
+		"00:00:05.500 --> 00:00:07.000\r\nToday?\r\n\r\n" +
+		"00:00:07.000 --> 00:00:09.000\r\nToday?\r\n\r\n" +
+		"00:00:09.000 --> 00:00:12.000\r\nNo guests, just us.\r\n"
+
+	text, segments, language := parseVTT(vtt)
+
+	assert.Equal(t, "", language)
+	assert.Equal(t, "Welcome back to the show, where we talk & argue about everything. Today? No guests, just us.", text)
+	assert.Equal(t, []models.TranscriptSegment{
+		{Start: 1, End: 5.5, Text: "Welcome back to the show, where we talk & argue about everything."},
+		{Start: 5.5, End: 7, Text: "Today?"},
+		{Start: 9, End: 12, Text: "No guests, just us."},
+	}, segments)
+}
+
+func TestParseVTT_LongUnpunctuatedSpeech(t *testing.T) {
+	vtt := "WEBVTT\n\n"
+	for i := 0; i < 12; i++ {
+		vtt += formatTimestamp(float64(i*3), '.') + " --> " + formatTimestamp(float64(i*3+3), '.') + "\nand then we kept going\n\n"
+		vtt += formatTimestamp(float64(i*3+3), '.') + " --> " + formatTimestamp(float64(i*3+3), '.') + "\nwith more words\n\n"
+	}
+
+	_, segments, _ := parseVTT(vtt)
+
+	require.Len(t, segments, 2)
+	for _, seg := range segments {
+		assert.LessOrEqual(t, seg.End-seg.Start, maxSegmentDuration)
+		assert.Nil(t, seg.Words, "plain cues have no word timings")
+	}
+}
+
+func TestParseVTTTime(t *testing.T) {
+	assert.Equal(t, 3661.5, parseVTTTime(" 01:01:01.500 align:start position:0%"))
+	assert.Equal(t, 62.25, parseVTTTime("01:02.250"))
+	assert.Equal(t, 1.2, parseVTTTime("00:00:01,200"))
+	assert.Equal(t, 0.0, parseVTTTime("garbage"))
+}
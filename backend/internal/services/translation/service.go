@@ -0,0 +1,278 @@
+package translation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/internal/repository"
+	"youtube-video-summarizer/backend/pkg/errors"
+	"youtube-video-summarizer/backend/pkg/llm"
+	"youtube-video-summarizer/backend/pkg/prompts"
+)
+
+// TranscriptSource is the source of transcripts created by translating another transcript
+const TranscriptSource = "translated"
+
+const (
+	// maxBatchSegments and maxBatchChars bound the segments sent in one request, keeping the
+	// answer well within the output limit of the model
+	maxBatchSegments = 50
+	maxBatchChars    = 4000
+	// maxAttempts is how often a batch is sent before an answer missing segments is an error
+	maxAttempts = 2
+)
+
+// LLMProviderFactory returns the LLM configured for an operation
+type LLMProviderFactory interface {
+	GetLLMProvider(ctx context.Context, operation string) (llm.LLMProvider, error)
+}
+
+// UsageRecorder records token usage, implemented by cost.Service
+type UsageRecorder interface {
+	RecordUsage(ctx context.Context, videoID uuid.UUID, operation, provider, model string, inputTokens, outputTokens int) error
+}
+
+type Service struct {
+	transcriptRepo  repository.TranscriptRepository
+	providerFactory LLMProviderFactory
+	costService     UsageRecorder
+	logger          *zap.Logger
+}
+
+func NewService(
+	transcriptRepo repository.TranscriptRepository,
+	providerFactory LLMProviderFactory,
+	costService UsageRecorder,
+	logger *zap.Logger,
+) *Service {
+	return &Service{
+		transcriptRepo:  transcriptRepo,
+		providerFactory: providerFactory,
+		costService:     costService,
+		logger:          logger,
+	}
+}
+
+// TranslateTranscript translates a transcript of a video into language, segment by segment,
+// and stores the result as a transcript of its own with the same timestamps. The primary
+// transcript is translated unless transcriptID is set. An earlier translation into the same
+// language is replaced. The translation records the transcript it was made from, so that
+// editing it marks the translation stale.
+func (s *Service) TranslateTranscript(ctx context.Context, videoID, transcriptID uuid.UUID, language string) (*models.Transcript, error) {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if language == "" || language == "auto" {
+		return nil, errors.New(errors.ErrorCodeBadRequest, errors.SubCodeInvalidInput, "Language is required and cannot be 'auto'")
+	}
+
+	source, err := s.getTranscript(ctx, videoID, transcriptID)
+	if err != nil {
+		return nil, err
+	}
+	if source.Language == language {
+		return nil, errors.New(errors.ErrorCodeBadRequest, errors.SubCodeInvalidInput,
+			fmt.Sprintf("Transcript is already in %s", prompts.LanguageName(language)))
+	}
+	if len(source.Segments) == 0 {
+		return nil, errors.New(errors.ErrorCodeBadRequest, errors.SubCodeInvalidInput, "Transcript has no segments to translate")
+	}
+
+	llmProvider, err := s.providerFactory.GetLLMProvider(ctx, "summary")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LLM provider: %w", err)
+	}
+
+	texts := make([]string, len(source.Segments))
+	for _, batch := range batchSegments(source.Segments) {
+		translated, err := s.translateBatch(ctx, llmProvider, videoID, source.Segments, batch, language)
+		if err != nil {
+			return nil, errors.NewWithError(errors.ErrorCodeTranscriptGeneration, errors.SubCodeTranscriptTranslationFailed,
+				"Failed to translate transcript", err)
+		}
+		for _, i := range batch {
+			texts[i] = translated[i]
+		}
+	}
+
+	segments := make(models.TranscriptSegments, len(source.Segments))
+	var content []string
+	for i, seg := range source.Segments {
+		// Word timings belong to the original words and have no counterpart in the translation
+		segments[i] = models.TranscriptSegment{Start: seg.Start, End: seg.End, Text: texts[i], Speaker: seg.Speaker}
+		if texts[i] != "" {
+			content = append(content, texts[i])
+		}
+	}
+
+	translation, err := s.transcriptRepo.GetByKey(ctx, videoID, language, TranscriptSource)
+	switch {
+	case err == nil:
+		translation.Content = strings.Join(content, " ")
+		translation.Segments = segments
+		translation.Speakers = source.Speakers
+		translation.TranslatedFrom = &source.ID
+		translation.Stale = false
+		if err := s.transcriptRepo.Update(ctx, translation); err != nil {
+			return nil, errors.ErrDatabaseError("update translated transcript", err)
+		}
+	case err == gorm.ErrRecordNotFound:
+		translation = &models.Transcript{
+			VideoID:        videoID,
+			Language:       language,
+			Source:         TranscriptSource,
+			Content:        strings.Join(content, " "),
+			Segments:       segments,
+			Speakers:       source.Speakers,
+			TranslatedFrom: &source.ID,
+		}
+		if err := s.transcriptRepo.Create(ctx, translation); err != nil {
+			return nil, errors.ErrDatabaseError("save translated transcript", err)
+		}
+	default:
+		return nil, errors.ErrDatabaseError("get translated transcript", err)
+	}
+
+	s.logger.Info("Transcript translated",
+		zap.String("video_id", videoID.String()),
+		zap.String("from", source.Language),
+		zap.String("to", language),
+		zap.Int("segments", len(segments)))
+
+	return translation, nil
+}
+
+// getTranscript returns the transcript to translate: transcriptID, or the primary one when nil
+func (s *Service) getTranscript(ctx context.Context, videoID, transcriptID uuid.UUID) (*models.Transcript, error) {
+	var transcript *models.Transcript
+	var err error
+	if transcriptID == uuid.Nil {
+		transcript, err = s.transcriptRepo.GetByVideoID(ctx, videoID)
+	} else {
+		transcript, err = s.transcriptRepo.GetByID(ctx, transcriptID)
+	}
+	if err == gorm.ErrRecordNotFound || (err == nil && transcript.VideoID != videoID) {
+		return nil, errors.ErrTranscriptNotFound(videoID.String())
+	}
+	if err != nil {
+		return nil, errors.ErrDatabaseError("get transcript", err)
+	}
+	return transcript, nil
+}
+
+// translateBatch translates the segments at the indices of batch, returning the translations
+// by index. The batch is sent again once when the answer leaves out a segment.
+func (s *Service) translateBatch(
+	ctx context.Context,
+	llmProvider llm.LLMProvider,
+	videoID uuid.UUID,
+	segments []models.TranscriptSegment,
+	batch []int,
+	language string,
+) (map[int]string, error) {
+	var lines strings.Builder
+	for _, i := range batch {
+		fmt.Fprintf(&lines, "[%d] %s\n", i, strings.Join(strings.Fields(segments[i].Text), " "))
+	}
+	prompt := strings.ReplaceAll(prompts.GetSegmentTranslationPrompt(language), "{{.Segments}}", lines.String())
+	modelInfo := llmProvider.GetModelInfo()
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		resp, err := llmProvider.GenerateCompletion(ctx, llm.CompletionRequest{
+			Prompt:       prompt,
+			SystemPrompt: "You are an expert subtitle translator. You answer with valid JSON only.",
+			MaxTokens:    4000,
+			Temperature:  0.2,
+			TopP:         0.95,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if s.costService != nil {
+			s.costService.RecordUsage(ctx, videoID, "transcript_translation", modelInfo.Provider, modelInfo.Name, resp.InputTokens, resp.OutputTokens)
+		}
+
+		translated, err := parseTranslations(resp.Content)
+		if err == nil {
+			err = checkComplete(translated, batch)
+		}
+		if err == nil {
+			return translated, nil
+		}
+		lastErr = err
+		s.logger.Warn("Incomplete transcript translation",
+			zap.String("video_id", videoID.String()),
+			zap.Int("first_segment", batch[0]),
+			zap.Int("attempt", attempt),
+			zap.Error(err))
+	}
+	return nil, lastErr
+}
+
+// batchSegments groups the indices of the segments that have text into batches of at most
+// maxBatchSegments segments and about maxBatchChars characters
+func batchSegments(segments []models.TranscriptSegment) [][]int {
+	var batches [][]int
+	var current []int
+	chars := 0
+	for i, seg := range segments {
+		n := len(strings.TrimSpace(seg.Text))
+		if n == 0 {
+			continue
+		}
+		if len(current) > 0 && (len(current) == maxBatchSegments || chars+n > maxBatchChars) {
+			batches = append(batches, current)
+			current, chars = nil, 0
+		}
+		current = append(current, i)
+		chars += n
+	}
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+	return batches
+}
+
+// parseTranslations reads the JSON answer, tolerating Markdown fences or text around it
+func parseTranslations(content string) (map[int]string, error) {
+	start := strings.Index(content, "{")
+	end := strings.LastIndex(content, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("no JSON object in response")
+	}
+
+	var result struct {
+		Segments []struct {
+			ID   int    `json:"id"`
+			Text string `json:"text"`
+		} `json:"segments"`
+	}
+	if err := json.Unmarshal([]byte(content[start:end+1]), &result); err != nil {
+		return nil, err
+	}
+
+	translated := make(map[int]string, len(result.Segments))
+	for _, seg := range result.Segments {
+		translated[seg.ID] = strings.Join(strings.Fields(seg.Text), " ")
+	}
+	return translated, nil
+}
+
+// checkComplete returns an error unless every segment of batch has a translation
+func checkComplete(translated map[int]string, batch []int) error {
+	var missing []string
+	for _, i := range batch {
+		if translated[i] == "" {
+			missing = append(missing, fmt.Sprint(i))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("no translation for segments %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+package translation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/pkg/errors"
+	"youtube-video-summarizer/backend/pkg/llm"
+)
+
+type MockTranscriptRepository struct {
+	mock.Mock
+}
+
+func (m *MockTranscriptRepository) Create(ctx context.Context, transcript *models.Transcript) error {
+	args := m.Called(ctx, transcript)
+	return args.Error(0)
+}
+
+func (m *MockTranscriptRepository) GetByVideoID(ctx context.Context, videoID uuid.UUID) (*models.Transcript, error) {
+	args := m.Called(ctx, videoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Transcript), args.Error(1)
+}
+
+func (m *MockTranscriptRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Transcript, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Transcript), args.Error(1)
+}
+
+func (m *MockTranscriptRepository) GetByLanguage(ctx context.Context, videoID uuid.UUID, language string) (*models.Transcript, error) {
+	args := m.Called(ctx, videoID, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Transcript), args.Error(1)
+}
+
+func (m *MockTranscriptRepository) GetByKey(ctx context.Context, videoID uuid.UUID, language, source string) (*models.Transcript, error) {
+	args := m.Called(ctx, videoID, language, source)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Transcript), args.Error(1)
+}
+
+func (m *MockTranscriptRepository) ListByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.Transcript, error) {
+	args := m.Called(ctx, videoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Transcript), args.Error(1)
+}
+
+func (m *MockTranscriptRepository) SetPrimary(ctx context.Context, videoID, id uuid.UUID) error {
+	args := m.Called(ctx, videoID, id)
+	return args.Error(0)
+}
+
+func (m *MockTranscriptRepository) Update(ctx context.Context, transcript *models.Transcript) error {
+	args := m.Called(ctx, transcript)
+	return args.Error(0)
+}
+
+func (m *MockTranscriptRepository) UpdateSpeakers(ctx context.Context, id uuid.UUID, speakers models.SpeakerNames) error {
+	args := m.Called(ctx, id, speakers)
+	return args.Error(0)
+}
+
+func (m *MockTranscriptRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockUsageRecorder struct {
+	mock.Mock
+}
+
+func (m *MockUsageRecorder) RecordUsage(ctx context.Context, videoID uuid.UUID, operation, provider, model string, inputTokens, outputTokens int) error {
+	args := m.Called(ctx, videoID, operation, provider, model, inputTokens, outputTokens)
+	return args.Error(0)
+}
+
+// segmentLine matches a segment in the translation prompt
+var segmentLine = regexp.MustCompile(`(?m)^\[(\d+)\] (.*)$`)
+
+// fakeTranslator "translates" every segment of the prompt by prefixing it with the target
+// language, leaving out the segments in skip on the first request
+type fakeTranslator struct {
+	skip     map[int]bool
+	requests int
+}
+
+func (f *fakeTranslator) GenerateCompletion(ctx context.Context, req llm.CompletionRequest) (*llm.CompletionResponse, error) {
+	f.requests++
+	type segment struct {
+		ID   int    `json:"id"`
+		Text string `json:"text"`
+	}
+	var answer struct {
+		Segments []segment `json:"segments"`
+	}
+	for _, match := range segmentLine.FindAllStringSubmatch(req.Prompt, -1) {
+		id, _ := strconv.Atoi(match[1])
+		if f.requests == 1 && f.skip[id] {
+			continue
+		}
+		answer.Segments = append(answer.Segments, segment{ID: id, Text: "ES: " + match[2]})
+	}
+	data, _ := json.Marshal(answer)
+	return &llm.CompletionResponse{Content: "```json\n" + string(data) + "\n```", InputTokens: 100, OutputTokens: 80}, nil
+}
+
+func (f *fakeTranslator) GenerateCompletionStream(ctx context.Context, req llm.CompletionRequest) (<-chan llm.StreamChunk, error) {
+	return nil, nil
+}
+
+func (f *fakeTranslator) GenerateEmbedding(ctx context.Context, text string) ([]float32, error) {
+	return nil, nil
+}
+
+func (f *fakeTranslator) GenerateBatchEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	return nil, nil
+}
+
+func (f *fakeTranslator) GetModelInfo() llm.ModelInfo {
+	return llm.ModelInfo{Name: "gemini-test", Provider: "gemini"}
+}
+
+func (f *fakeTranslator) ListAvailableModels() ([]string, error) {
+	return nil, nil
+}
+
+type fakeProviderFactory struct {
+	provider llm.LLMProvider
+}
+
+func (f *fakeProviderFactory) GetLLMProvider(ctx context.Context, operation string) (llm.LLMProvider, error) {
+	return f.provider, nil
+}
+
+func englishTranscript(videoID uuid.UUID, segments int) *models.Transcript {
+	transcript := &models.Transcript{ID: uuid.New(), VideoID: videoID, Language: "en", Source: "youtube", IsPrimary: true}
+	for i := 0; i < segments; i++ {
+		transcript.Segments = append(transcript.Segments, models.TranscriptSegment{
+			Start: float64(i) * 2.5,
+			End:   float64(i)*2.5 + 2,
+			Text:  fmt.Sprintf("Sentence number %d.", i),
+			Words: []models.TranscriptWord{{Start: float64(i) * 2.5, End: float64(i)*2.5 + 1, Text: "Sentence"}},
+		})
+	}
+	return transcript
+}
+
+func TestService_TranslateTranscript(t *testing.T) {
+	transcriptRepo := new(MockTranscriptRepository)
+	costs := new(MockUsageRecorder)
+	model := &fakeTranslator{}
+	service := NewService(transcriptRepo, &fakeProviderFactory{provider: model}, costs, zap.NewNop())
+	ctx := context.Background()
+
+	videoID := uuid.New()
+	source := englishTranscript(videoID, 120)
+	source.Segments[7].Text = "  "
+	source.Segments[3].Speaker = "SPEAKER_01"
+	source.Speakers = models.SpeakerNames{"SPEAKER_01": "Alice"}
+
+	transcriptRepo.On("GetByVideoID", ctx, videoID).Return(source, nil)
+	transcriptRepo.On("GetByKey", ctx, videoID, "es", TranscriptSource).Return(nil, gorm.ErrRecordNotFound)
+	transcriptRepo.On("Create", ctx, mock.Anything).Return(nil)
+	costs.On("RecordUsage", ctx, videoID, "transcript_translation", "gemini", "gemini-test", 100, 80).Return(nil)
+
+	translation, err := service.TranslateTranscript(ctx, videoID, uuid.Nil, "ES")
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, model.requests, "119 segments with text are sent in batches of 50")
+	assert.Equal(t, "es", translation.Language)
+	assert.Equal(t, TranscriptSource, translation.Source)
+	assert.Equal(t, models.SpeakerNames{"SPEAKER_01": "Alice"}, translation.Speakers)
+	assert.Equal(t, &source.ID, translation.TranslatedFrom)
+	require.Len(t, translation.Segments, len(source.Segments))
+	for i, seg := range translation.Segments {
+		assert.Equal(t, source.Segments[i].Start, seg.Start)
+		assert.Equal(t, source.Segments[i].End, seg.End)
+		assert.Empty(t, seg.Words)
+	}
+	assert.Equal(t, "ES: Sentence number 0.", translation.Segments[0].Text)
+	assert.Equal(t, "ES: Sentence number 119.", translation.Segments[119].Text)
+	assert.Empty(t, translation.Segments[7].Text, "a segment without text stays empty")
+	assert.Equal(t, "SPEAKER_01", translation.Segments[3].Speaker)
+	assert.Contains(t, translation.Content, "ES: Sentence number 6. ES: Sentence number 8.")
+	transcriptRepo.AssertExpectations(t)
+	costs.AssertNumberOfCalls(t, "RecordUsage", 3)
+}
+
+func TestService_TranslateTranscript_RetriesIncompleteBatch(t *testing.T) {
+	transcriptRepo := new(MockTranscriptRepository)
+	costs := new(MockUsageRecorder)
+	model := &fakeTranslator{skip: map[int]bool{2: true}}
+	service := NewService(transcriptRepo, &fakeProviderFactory{provider: model}, costs, zap.NewNop())
+	ctx := context.Background()
+
+	videoID := uuid.New()
+	source := englishTranscript(videoID, 5)
+	existing := &models.Transcript{ID: uuid.New(), VideoID: videoID, Language: "es", Source: TranscriptSource, Content: "old", Stale: true}
+
+	transcriptRepo.On("GetByID", ctx, source.ID).Return(source, nil)
+	transcriptRepo.On("GetByKey", ctx, videoID, "es", TranscriptSource).Return(existing, nil)
+	transcriptRepo.On("Update", ctx, existing).Return(nil)
+	costs.On("RecordUsage", ctx, videoID, "transcript_translation", "gemini", "gemini-test", 100, 80).Return(nil)
+
+	translation, err := service.TranslateTranscript(ctx, videoID, source.ID, "es")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, model.requests)
+	assert.Same(t, existing, translation, "an earlier translation is replaced")
+	assert.False(t, translation.Stale, "translating again brings the translation up to date")
+	assert.Equal(t, &source.ID, translation.TranslatedFrom)
+	assert.Equal(t, "ES: Sentence number 2.", translation.Segments[2].Text)
+	transcriptRepo.AssertExpectations(t)
+}
+
+func TestService_TranslateTranscript_Rejects(t *testing.T) {
+	transcriptRepo := new(MockTranscriptRepository)
+	service := NewService(transcriptRepo, &fakeProviderFactory{provider: &fakeTranslator{}}, nil, zap.NewNop())
+	ctx := context.Background()
+
+	videoID := uuid.New()
+	source := englishTranscript(videoID, 2)
+	other := englishTranscript(uuid.New(), 2)
+	transcriptRepo.On("GetByVideoID", ctx, videoID).Return(source, nil)
+	transcriptRepo.On("GetByID", ctx, other.ID).Return(other, nil)
+
+	_, err := service.TranslateTranscript(ctx, videoID, uuid.Nil, "auto")
+	assertSubCode(t, err, errors.SubCodeInvalidInput)
+
+	_, err = service.TranslateTranscript(ctx, videoID, uuid.Nil, "en")
+	assertSubCode(t, err, errors.SubCodeInvalidInput)
+
+	_, err = service.TranslateTranscript(ctx, videoID, other.ID, "es")
+	assertSubCode(t, err, errors.SubCodeTranscriptNotFound)
+}
+
+func TestBatchSegments(t *testing.T) {
+	segments := []models.TranscriptSegment{{Text: "a"}, {Text: ""}, {Text: "b"}}
+	assert.Equal(t, [][]int{{0, 2}}, batchSegments(segments), "segments without text are not sent")
+
+	text := models.TranscriptSegment{Text: fmt.Sprintf("%01500d", 0)}
+	assert.Equal(t, [][]int{{0, 1}, {2}}, batchSegments([]models.TranscriptSegment{text, text, text}),
+		"a batch stays within maxBatchChars")
+}
+
+func assertSubCode(t *testing.T, err error, subCode errors.SubCode) {
+	t.Helper()
+	require.Error(t, err)
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok, err.Error())
+	assert.Equal(t, subCode, appErr.SubCode)
+}
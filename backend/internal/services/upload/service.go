@@ -0,0 +1,365 @@
+package upload
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/internal/repository"
+	"youtube-video-summarizer/backend/pkg/errors"
+)
+
+// allowedExtensions are the audio/video formats the Whisper providers accept as-is
+var allowedExtensions = map[string]bool{
+	".mp3":  true,
+	".mpga": true,
+	".m4a":  true,
+	".wav":  true,
+	".flac": true,
+	".ogg":  true,
+	".opus": true,
+	".webm": true,
+	".mp4":  true,
+	".mpeg": true,
+}
+
+// AnalysisQueue queues analysis for a newly created video
+type AnalysisQueue interface {
+	Enqueue(ctx context.Context, video *models.Video)
+}
+
+type Service struct {
+	uploadRepo    repository.UploadRepository
+	videoRepo     repository.VideoRepository
+	analysisQueue AnalysisQueue
+	dir           string
+	maxSize       int64
+	chunkLocks    uploadLocks
+	logger        *zap.Logger
+}
+
+// uploadLocks serializes chunk writes per upload. Clients resume by re-sending a chunk, so two
+// requests for the same offset can overlap; only one of them may write and advance the offset.
+type uploadLocks struct {
+	mu    sync.Mutex
+	locks map[uuid.UUID]*uploadLock
+}
+
+type uploadLock struct {
+	sync.Mutex
+	refs int
+}
+
+// lock acquires the lock of an upload and returns its unlock function
+func (l *uploadLocks) lock(id uuid.UUID) func() {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[uuid.UUID]*uploadLock)
+	}
+	lock, ok := l.locks[id]
+	if !ok {
+		lock = &uploadLock{}
+		l.locks[id] = lock
+	}
+	lock.refs++
+	l.mu.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		l.mu.Lock()
+		if lock.refs--; lock.refs == 0 {
+			delete(l.locks, id)
+		}
+		l.mu.Unlock()
+	}
+}
+
+func NewService(
+	uploadRepo repository.UploadRepository,
+	videoRepo repository.VideoRepository,
+	analysisQueue AnalysisQueue,
+	dir string,
+	maxSizeMB int,
+	logger *zap.Logger,
+) *Service {
+	return &Service{
+		uploadRepo:    uploadRepo,
+		videoRepo:     videoRepo,
+		analysisQueue: analysisQueue,
+		dir:           dir,
+		maxSize:       int64(maxSizeMB) * 1024 * 1024,
+		logger:        logger,
+	}
+}
+
+// CreateFromFile stores a complete file from a single (multipart) request and creates its video
+func (s *Service) CreateFromFile(ctx context.Context, filename, title string, r io.Reader) (*models.Video, error) {
+	if err := s.validateFilename(filename); err != nil {
+		return nil, err
+	}
+
+	path, err := s.newFilePath(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	written, err := s.writeFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0, r, s.maxSize)
+	if err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+	if written == 0 {
+		os.Remove(path)
+		return nil, errors.New(errors.ErrorCodeBadRequest, errors.SubCodeInvalidInput, "Uploaded file is empty")
+	}
+
+	video, err := s.createVideo(ctx, filename, title, path)
+	if err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+
+	return video, nil
+}
+
+// StartUpload begins a resumable upload of size bytes. Chunks are then sent with WriteChunk.
+func (s *Service) StartUpload(ctx context.Context, filename, title string, size int64) (*models.Upload, error) {
+	if err := s.validateFilename(filename); err != nil {
+		return nil, err
+	}
+	if size <= 0 {
+		return nil, errors.New(errors.ErrorCodeBadRequest, errors.SubCodeInvalidInput, "size must be greater than zero")
+	}
+	if size > s.maxSize {
+		return nil, s.tooLarge(size)
+	}
+
+	path, err := s.newFilePath(filename)
+	if err != nil {
+		return nil, err
+	}
+	// Reserve the file so chunks can be written into it
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upload file: %w", err)
+	}
+	file.Close()
+
+	upload := &models.Upload{
+		Filename: filepath.Base(filename),
+		Title:    strings.TrimSpace(title),
+		Size:     size,
+		Path:     path,
+		Status:   "uploading",
+	}
+	if err := s.uploadRepo.Create(ctx, upload); err != nil {
+		os.Remove(path)
+		return nil, fmt.Errorf("failed to save upload: %w", err)
+	}
+
+	return upload, nil
+}
+
+// WriteChunk writes a chunk at offset, which must equal the bytes received so far. When the last
+// byte arrives the video is created and queued for analysis; the upload then carries its VideoID.
+func (s *Service) WriteChunk(ctx context.Context, id uuid.UUID, offset int64, r io.Reader) (*models.Upload, error) {
+	defer s.chunkLocks.lock(id)()
+
+	upload, err := s.GetUpload(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if upload.Status != "uploading" {
+		return nil, errors.NewWithDetail(
+			errors.ErrorCodeConflict,
+			errors.SubCodeUploadNotInProgress,
+			"Upload is not in progress",
+			fmt.Sprintf("Upload %s is %s", id, upload.Status),
+		)
+	}
+	if offset != upload.Offset {
+		return nil, errors.ErrUploadOffsetMismatch(upload.Offset, offset)
+	}
+
+	// Write at the stored offset rather than appending, overwriting anything left behind by a chunk
+	// whose offset was never saved
+	written, err := s.writeFile(upload.Path, os.O_WRONLY, upload.Offset, r, upload.Size-upload.Offset)
+	upload.Offset += written
+	if err != nil {
+		// Keep what was written; the client resumes from the stored offset
+		if updateErr := s.uploadRepo.Update(ctx, upload); updateErr != nil {
+			s.logger.Warn("Failed to update upload", zap.String("upload_id", id.String()), zap.Error(updateErr))
+		}
+		return nil, err
+	}
+
+	if upload.Offset == upload.Size {
+		video, err := s.createVideo(ctx, upload.Filename, upload.Title, upload.Path)
+		if err != nil {
+			upload.Status = "failed"
+			if updateErr := s.uploadRepo.Update(ctx, upload); updateErr != nil {
+				s.logger.Warn("Failed to update upload", zap.String("upload_id", id.String()), zap.Error(updateErr))
+			}
+			return nil, err
+		}
+		upload.Status = "completed"
+		upload.VideoID = &video.ID
+	}
+
+	if err := s.uploadRepo.Update(ctx, upload); err != nil {
+		return nil, fmt.Errorf("failed to update upload: %w", err)
+	}
+
+	return upload, nil
+}
+
+func (s *Service) GetUpload(ctx context.Context, id uuid.UUID) (*models.Upload, error) {
+	upload, err := s.uploadRepo.GetByID(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrUploadNotFound(id.String())
+		}
+		return nil, err
+	}
+	return upload, nil
+}
+
+// GetMediaPath returns the stored file of an uploaded video
+func (s *Service) GetMediaPath(ctx context.Context, videoID uuid.UUID) (string, error) {
+	video, err := s.videoRepo.GetByID(ctx, videoID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return "", errors.ErrVideoNotFound(videoID.String())
+		}
+		return "", err
+	}
+	if video.SourceType != models.SourceTypeUpload || video.MediaPath == "" {
+		return "", errors.NewWithDetail(
+			errors.ErrorCodeNotFound,
+			errors.SubCodeVideoNotFound,
+			"Media file not found",
+			fmt.Sprintf("Video %s has no uploaded media file", videoID),
+		)
+	}
+	return video.MediaPath, nil
+}
+
+func (s *Service) createVideo(ctx context.Context, filename, title, path string) (*models.Video, error) {
+	if strings.TrimSpace(title) == "" {
+		title = strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	}
+
+	video := &models.Video{
+		Title:         strings.TrimSpace(title),
+		SourceType:    models.SourceTypeUpload,
+		MediaPath:     path,
+		ChannelName:   "Uploaded",
+		Duration:      s.probeDuration(ctx, path),
+		PublishedAt:   time.Now(),
+		Status:        "pending",
+		HasTranscript: false,
+		HasSummary:    false,
+	}
+
+	if err := s.videoRepo.Create(ctx, video); err != nil {
+		return nil, fmt.Errorf("failed to save video: %w", err)
+	}
+
+	s.logger.Info("Uploaded video created",
+		zap.String("video_id", video.ID.String()),
+		zap.String("path", path),
+		zap.Int("duration", video.Duration),
+	)
+
+	s.analysisQueue.Enqueue(ctx, video)
+	return video, nil
+}
+
+// MaxSize is the largest file accepted, in bytes
+func (s *Service) MaxSize() int64 {
+	return s.maxSize
+}
+
+// writeFile copies r into path starting at offset, failing once more than limit bytes are received
+func (s *Service) writeFile(path string, flag int, offset int64, r io.Reader, limit int64) (int64, error) {
+	file, err := os.OpenFile(path, flag, 0o644)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open upload file: %w", err)
+	}
+	defer file.Close()
+
+	// Read one byte past the limit to detect oversized bodies
+	written, err := io.Copy(io.NewOffsetWriter(file, offset), io.LimitReader(r, limit+1))
+	if err != nil {
+		return written, fmt.Errorf("failed to write upload file: %w", err)
+	}
+	if written > limit {
+		return written, s.tooLarge(written)
+	}
+	return written, nil
+}
+
+func (s *Service) newFilePath(filename string) (string, error) {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create upload directory: %w", err)
+	}
+	ext := strings.ToLower(filepath.Ext(filename))
+	return filepath.Join(s.dir, uuid.New().String()+ext), nil
+}
+
+func (s *Service) validateFilename(filename string) error {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if !allowedExtensions[ext] {
+		return errors.NewWithDetail(
+			errors.ErrorCodeBadRequest,
+			errors.SubCodeInvalidFormat,
+			"Unsupported file type",
+			fmt.Sprintf("'%s' is not a supported audio or video format", filepath.Base(filename)),
+		)
+	}
+	return nil
+}
+
+func (s *Service) tooLarge(size int64) error {
+	return errors.NewWithDetail(
+		errors.ErrorCodeTooLarge,
+		errors.SubCodeInvalidInput,
+		"Upload too large",
+		fmt.Sprintf("File size %d bytes exceeds maximum allowed size of %d bytes", size, s.maxSize),
+	)
+}
+
+// probeDuration reads the media duration in seconds with ffprobe; 0 if unavailable
+func (s *Service) probeDuration(ctx context.Context, path string) int {
+	cmd := exec.CommandContext(ctx, "ffprobe",
+		"-v", "error",
+		"-show_entries", "format=duration",
+		"-of", "default=noprint_wrappers=1:nokey=1",
+		path,
+	)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		s.logger.Debug("ffprobe failed, duration unknown", zap.String("path", path), zap.Error(err))
+		return 0
+	}
+
+	seconds, err := strconv.ParseFloat(strings.TrimSpace(stdout.String()), 64)
+	if err != nil {
+		return 0
+	}
+	return int(seconds + 0.5)
+}
@@ -0,0 +1,104 @@
+package upload
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/models"
+	"youtube-video-summarizer/backend/pkg/errors"
+)
+
+// memoryUploadRepository stores uploads by value, like the database does
+type memoryUploadRepository struct {
+	mu      sync.Mutex
+	uploads map[uuid.UUID]models.Upload
+}
+
+func (r *memoryUploadRepository) Create(ctx context.Context, upload *models.Upload) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	upload.ID = uuid.New()
+	r.uploads[upload.ID] = *upload
+	return nil
+}
+
+func (r *memoryUploadRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Upload, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	upload := r.uploads[id]
+	return &upload, nil
+}
+
+func (r *memoryUploadRepository) Update(ctx context.Context, upload *models.Upload) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.uploads[upload.ID] = *upload
+	return nil
+}
+
+func TestService_WriteChunk_ConcurrentSameOffset(t *testing.T) {
+	repo := &memoryUploadRepository{uploads: make(map[uuid.UUID]models.Upload)}
+	service := NewService(repo, nil, nil, t.TempDir(), 1, zap.NewNop())
+	ctx := context.Background()
+
+	upload, err := service.StartUpload(ctx, "talk.mp3", "Talk", 8)
+	require.NoError(t, err)
+
+	// A client retrying a chunk it believes was lost races the original request
+	chunks := [][]byte{[]byte("AAAA"), []byte("BBBB")}
+	errs := make([]error, len(chunks))
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = service.WriteChunk(ctx, upload.ID, 0, bytes.NewReader(chunk))
+		}()
+	}
+	wg.Wait()
+
+	var mismatches int
+	for _, err := range errs {
+		if err != nil {
+			appErr, ok := err.(*errors.AppError)
+			require.True(t, ok, err.Error())
+			assert.Equal(t, errors.SubCodeUploadOffsetMismatch, appErr.SubCode)
+			mismatches++
+		}
+	}
+	assert.Equal(t, 1, mismatches, "exactly one chunk is written at offset 0")
+
+	stored, err := service.GetUpload(ctx, upload.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), stored.Offset)
+	content, err := os.ReadFile(stored.Path)
+	require.NoError(t, err)
+	assert.Len(t, content, 4)
+	assert.Contains(t, []string{"AAAA", "BBBB"}, string(content))
+}
+
+func TestService_WriteChunk_OverwritesUnsavedBytes(t *testing.T) {
+	repo := &memoryUploadRepository{uploads: make(map[uuid.UUID]models.Upload)}
+	service := NewService(repo, nil, nil, t.TempDir(), 1, zap.NewNop())
+	ctx := context.Background()
+
+	upload, err := service.StartUpload(ctx, "talk.mp3", "Talk", 8)
+	require.NoError(t, err)
+
+	// Bytes of a chunk whose offset was never saved
+	require.NoError(t, os.WriteFile(upload.Path, []byte("stale"), 0o644))
+
+	stored, err := service.WriteChunk(ctx, upload.ID, 0, bytes.NewReader([]byte("FRES")))
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), stored.Offset)
+	content, err := os.ReadFile(upload.Path)
+	require.NoError(t, err)
+	assert.Equal(t, "FRESe", string(content), "the next chunk overwrites the rest")
+}
@@ -6,65 +6,97 @@ import (
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 	"youtube-video-summarizer/backend/internal/models"
 	"youtube-video-summarizer/backend/internal/repository"
+	"youtube-video-summarizer/backend/internal/services/source"
+	"youtube-video-summarizer/backend/pkg/errors"
 	"youtube-video-summarizer/backend/pkg/youtube"
 )
 
 type Service struct {
-	videoRepo     repository.VideoRepository
-	youtubeClient *youtube.Client
-	logger        *zap.Logger
+	videoRepo   repository.VideoRepository
+	chapterRepo repository.ChapterRepository
+	sources     *source.Registry
+	logger      *zap.Logger
 }
 
 func NewService(
 	videoRepo repository.VideoRepository,
-	youtubeClient *youtube.Client,
+	chapterRepo repository.ChapterRepository,
+	sources *source.Registry,
 	logger *zap.Logger,
 ) *Service {
 	return &Service{
-		videoRepo:     videoRepo,
-		youtubeClient: youtubeClient,
-		logger:        logger,
+		videoRepo:   videoRepo,
+		chapterRepo: chapterRepo,
+		sources:     sources,
+		logger:      logger,
 	}
 }
 
+// CreateFromURL creates a video from a YouTube URL or video ID
 func (s *Service) CreateFromURL(ctx context.Context, videoURL string) (*models.Video, error) {
-	// Extract video ID
-	videoID, err := s.youtubeClient.ExtractVideoID(videoURL)
+	return s.CreateFromSource(ctx, models.SourceTypeYouTube, source.Reference{URL: videoURL})
+}
+
+// CreateFromSource creates a video for the item ref points at in the given source, or returns
+// the existing video if that item was added before
+func (s *Service) CreateFromSource(ctx context.Context, sourceType string, ref source.Reference) (*models.Video, error) {
+	src, err := s.sources.Get(sourceType)
 	if err != nil {
-		return nil, fmt.Errorf("invalid YouTube URL: %w", err)
+		return nil, errors.NewWithError(errors.ErrorCodeBadRequest, errors.SubCodeInvalidInput, "Unsupported source", err)
 	}
 
-	// Check if video already exists
-	existing, err := s.videoRepo.GetByYouTubeID(ctx, videoID)
-	if err == nil && existing != nil {
-		return existing, nil
+	// Check if video already exists before fetching metadata
+	if sourceID, ok := src.Identify(ref); ok {
+		if existing, err := s.videoRepo.GetBySourceID(ctx, src.Type(), sourceID); err == nil && existing != nil {
+			return existing, nil
+		}
 	}
 
-	// Fetch video info from YouTube
-	info, err := s.youtubeClient.GetVideoInfo(ctx, videoID)
+	meta, err := src.Resolve(ctx, ref)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch video info: %w", err)
+		return nil, err
+	}
+
+	existing, err := s.videoRepo.GetBySourceID(ctx, src.Type(), meta.SourceID)
+	if err == nil && existing != nil {
+		return existing, nil
 	}
 
 	// Create video model
 	video := &models.Video{
-		YouTubeID:    info.ID,
-		Title:        info.Title,
-		Description:  info.Description,
-		ChannelID:     info.ChannelID,
-		ChannelName:  info.ChannelName,
-		Duration:     info.Duration,
-		ViewCount:    info.ViewCount,
-		LikeCount:    info.LikeCount,
-		PublishedAt:  info.PublishedAt,
-		ThumbnailURL: info.ThumbnailURL,
-		Tags:         info.Tags,
-		Category:     info.Category,
-		Status:       "pending",
+		SourceType:    src.Type(),
+		SourceID:      meta.SourceID,
+		SourceURL:     meta.SourceURL,
+		Title:         meta.Title,
+		Description:   meta.Description,
+		ChannelID:     meta.ChannelID,
+		ChannelName:   meta.ChannelName,
+		Duration:      meta.Duration,
+		StartTime:     meta.StartTime,
+		ViewCount:     meta.ViewCount,
+		LikeCount:     meta.LikeCount,
+		PublishedAt:   meta.PublishedAt,
+		ThumbnailURL:  meta.ThumbnailURL,
+		Tags:          meta.Tags,
+		Category:      meta.Category,
+		Status:        "pending",
 		HasTranscript: false,
-		HasSummary:   false,
+		HasSummary:    false,
+	}
+	if src.Type() == models.SourceTypeYouTube {
+		video.YouTubeID = meta.SourceID
+	}
+	if meta.LiveBroadcastContent != "" {
+		video.LiveBroadcastContent = meta.LiveBroadcastContent
+	}
+	// Premieres and running streams have no recording to download yet; the broadcast
+	// scheduler queues them once they have ended
+	if meta.LiveBroadcastContent == youtube.BroadcastUpcoming || meta.LiveBroadcastContent == youtube.BroadcastLive {
+		video.Status = models.VideoStatusScheduled
+		video.ScheduledStartAt = meta.ScheduledStartAt
 	}
 
 	// Save to database
@@ -72,11 +104,78 @@ func (s *Service) CreateFromURL(ctx context.Context, videoURL string) (*models.V
 		return nil, fmt.Errorf("failed to save video: %w", err)
 	}
 
-	s.logger.Info("Video created", zap.String("video_id", video.ID.String()), zap.String("youtube_id", videoID))
+	s.logger.Info("Video created",
+		zap.String("video_id", video.ID.String()),
+		zap.String("source_type", video.SourceType),
+		zap.String("source_id", video.SourceID),
+	)
+
+	if len(meta.Chapters) > 0 {
+		// Chapters are an extra; the video is usable without them
+		if err := s.saveChapters(ctx, video.ID, meta.Chapters, models.ChapterSourceDescription); err != nil {
+			s.logger.Warn("Failed to save chapters", zap.String("video_id", video.ID.String()), zap.Error(err))
+		}
+	}
 
 	return video, nil
 }
 
+// GetChapters returns the chapters of a video. When none were stored at ingestion the source
+// is asked for them (yt-dlp for YouTube) and the result is kept.
+func (s *Service) GetChapters(ctx context.Context, id uuid.UUID) ([]*models.Chapter, error) {
+	chapters, err := s.chapterRepo.ListByVideoID(ctx, id)
+	if err != nil {
+		return nil, errors.ErrDatabaseError("list chapters", err)
+	}
+	if len(chapters) > 0 {
+		return chapters, nil
+	}
+
+	video, err := s.videoRepo.GetByID(ctx, id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.ErrVideoNotFound(id.String())
+		}
+		return nil, err
+	}
+
+	src, err := s.sources.For(video)
+	if err != nil {
+		return chapters, nil
+	}
+	fetcher, ok := src.(source.ChapterFetcher)
+	if !ok {
+		return chapters, nil
+	}
+
+	fetched, err := fetcher.FetchChapters(ctx, video)
+	if err != nil {
+		s.logger.Warn("Failed to fetch chapters", zap.String("video_id", id.String()), zap.Error(err))
+		return chapters, nil
+	}
+	if len(fetched) == 0 {
+		return chapters, nil
+	}
+
+	if err := s.saveChapters(ctx, id, fetched, models.ChapterSourceYtDlp); err != nil {
+		return nil, errors.ErrDatabaseError("save chapters", err)
+	}
+	return s.chapterRepo.ListByVideoID(ctx, id)
+}
+
+func (s *Service) saveChapters(ctx context.Context, videoID uuid.UUID, chapters []youtube.Chapter, chapterSource string) error {
+	records := make([]*models.Chapter, 0, len(chapters))
+	for _, chapter := range chapters {
+		records = append(records, &models.Chapter{
+			Title:     chapter.Title,
+			StartTime: chapter.Start,
+			EndTime:   chapter.End,
+			Source:    chapterSource,
+		})
+	}
+	return s.chapterRepo.ReplaceForVideo(ctx, videoID, records)
+}
+
 func (s *Service) GetByID(ctx context.Context, id uuid.UUID) (*models.Video, error) {
 	return s.videoRepo.GetByID(ctx, id)
 }
@@ -92,4 +191,3 @@ func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
 func (s *Service) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
 	return s.videoRepo.UpdateStatus(ctx, id, status)
 }
-
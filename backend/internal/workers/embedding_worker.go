@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	kafkago "github.com/segmentio/kafka-go"
 	"go.uber.org/zap"
+	"youtube-video-summarizer/backend/internal/models"
 	"youtube-video-summarizer/backend/internal/repository"
 	"youtube-video-summarizer/backend/internal/services/cost"
 	"youtube-video-summarizer/backend/internal/services/embedding"
@@ -91,8 +92,12 @@ func (w *EmbeddingWorker) handleMessage(ctx context.Context, message kafkago.Mes
 		return err
 	}
 
-	// Generate embeddings
-	_, err = w.embeddingService.GenerateVideoEmbeddings(ctx, video, event.TranscriptContent)
+	// Generate embeddings from the transcript the event was published for
+	transcript := &models.Transcript{VideoID: videoID, Content: event.TranscriptContent}
+	if transcriptID, err := uuid.Parse(event.TranscriptID); err == nil {
+		transcript.ID = transcriptID
+	}
+	_, err = w.embeddingService.GenerateVideoEmbeddings(ctx, video, transcript)
 	if err != nil {
 		w.logger.Error("Failed to generate embeddings",
 			zap.String("video_id", event.VideoID),
@@ -10,9 +10,12 @@ import (
 	"youtube-video-summarizer/backend/internal/repository"
 	"youtube-video-summarizer/backend/internal/services/cost"
 	"youtube-video-summarizer/backend/internal/services/provider"
+	"youtube-video-summarizer/backend/internal/services/source"
 	"youtube-video-summarizer/backend/internal/services/transcript"
+	"youtube-video-summarizer/backend/pkg/artifact"
+	"youtube-video-summarizer/backend/pkg/downloader"
 	kafkapkg "youtube-video-summarizer/backend/pkg/kafka"
-	"youtube-video-summarizer/backend/pkg/youtube"
+	"youtube-video-summarizer/backend/pkg/whisper"
 )
 
 // TranscriptWorker processes transcript generation requests from Kafka
@@ -20,7 +23,7 @@ type TranscriptWorker struct {
 	consumer        *kafkapkg.Consumer
 	transcriptService *transcript.Service
 	videoEventService interface {
-		PublishEmbeddingRequested(ctx context.Context, videoID uuid.UUID, youtubeID, transcriptContent string, priority int) error
+		PublishEmbeddingRequested(ctx context.Context, videoID uuid.UUID, youtubeID string, transcriptID uuid.UUID, transcriptContent string, priority int) error
 		PublishAnalysisFailed(ctx context.Context, videoID uuid.UUID, youtubeID, stage, errorMsg string, retryable bool) error
 	}
 	logger *zap.Logger
@@ -31,7 +34,7 @@ func NewTranscriptWorker(
 	consumer *kafkapkg.Consumer,
 	transcriptService *transcript.Service,
 	videoEventService interface {
-		PublishEmbeddingRequested(ctx context.Context, videoID uuid.UUID, youtubeID, transcriptContent string, priority int) error
+		PublishEmbeddingRequested(ctx context.Context, videoID uuid.UUID, youtubeID string, transcriptID uuid.UUID, transcriptContent string, priority int) error
 		PublishAnalysisFailed(ctx context.Context, videoID uuid.UUID, youtubeID, stage, errorMsg string, retryable bool) error
 	},
 	logger *zap.Logger,
@@ -101,6 +104,7 @@ func (w *TranscriptWorker) handleMessage(ctx context.Context, message kafkago.Me
 		ctx,
 		videoID,
 		event.YouTubeID,
+		transcript.ID,
 		transcript.Content,
 		event.Priority,
 	); err != nil {
@@ -122,10 +126,16 @@ func StartTranscriptWorker(
 	transcriptRepo repository.TranscriptRepository,
 	videoRepo repository.VideoRepository,
 	providerFactory *provider.ProviderFactory,
-	youtubeClient *youtube.Client,
+	sources *source.Registry,
+	dl downloader.Downloader,
+	workspace *downloader.Workspace,
+	artifacts artifact.Store,
+	chunker *whisper.Chunker,
+	minCaptionQuality float64,
+	diarize bool,
 	costService *cost.Service,
 	videoEventService interface {
-		PublishEmbeddingRequested(ctx context.Context, videoID uuid.UUID, youtubeID, transcriptContent string, priority int) error
+		PublishEmbeddingRequested(ctx context.Context, videoID uuid.UUID, youtubeID string, transcriptID uuid.UUID, transcriptContent string, priority int) error
 		PublishAnalysisFailed(ctx context.Context, videoID uuid.UUID, youtubeID, stage, errorMsg string, retryable bool) error
 	},
 	logger *zap.Logger,
@@ -135,7 +145,13 @@ func StartTranscriptWorker(
 		transcriptRepo,
 		videoRepo,
 		providerFactory,
-		youtubeClient,
+		sources,
+		dl,
+		workspace,
+		artifacts,
+		chunker,
+		minCaptionQuality,
+		diarize,
 		costService,
 		logger,
 	)
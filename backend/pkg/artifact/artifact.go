@@ -0,0 +1,141 @@
+package artifact
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned by Store.Find when no artifact is stored for a video
+var ErrNotFound = errors.New("artifact not found")
+
+// audioPrefix is the key prefix of audio artifacts: "audio/<video ID>/<sha256><ext>"
+const audioPrefix = "audio/"
+
+// Artifact is a stored audio file
+type Artifact struct {
+	Key      string
+	VideoID  string
+	Hash     string // hex SHA-256 of the content
+	Size     int64
+	StoredAt time.Time // when it was stored or, for local artifacts, last reused
+}
+
+// Store keeps downloaded audio so later stages reuse it instead of downloading it again.
+// Artifacts are addressed by video ID and content hash, so storing the same file twice keeps
+// one copy.
+type Store interface {
+	// Put stores the file at localPath as audio of videoID
+	Put(ctx context.Context, videoID, localPath string) (*Artifact, error)
+	// Find returns the most recently stored audio of videoID, or ErrNotFound
+	Find(ctx context.Context, videoID string) (*Artifact, error)
+	// Fetch copies an artifact into dir and returns the local path
+	Fetch(ctx context.Context, artifact *Artifact, dir string) (string, error)
+	// List returns every stored artifact
+	List(ctx context.Context) ([]*Artifact, error)
+	// Delete removes an artifact
+	Delete(ctx context.Context, artifact *Artifact) error
+}
+
+// Retention limits how long and how much audio is kept
+type Retention struct {
+	MaxAge       time.Duration // 0 keeps artifacts regardless of age
+	MaxTotalSize int64         // bytes, 0 for no limit
+}
+
+// Prune deletes artifacts older than the retention's MaxAge, then the oldest remaining ones
+// until the rest fit in MaxTotalSize. It returns the number of deleted artifacts and bytes freed.
+func Prune(ctx context.Context, store Store, retention Retention, now time.Time) (int, int64, error) {
+	artifacts, err := store.List(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	sort.Slice(artifacts, func(i, j int) bool {
+		return artifacts[i].StoredAt.Before(artifacts[j].StoredAt)
+	})
+
+	var total int64
+	for _, a := range artifacts {
+		total += a.Size
+	}
+
+	deleted := 0
+	var freed int64
+	for _, a := range artifacts {
+		expired := retention.MaxAge > 0 && now.Sub(a.StoredAt) > retention.MaxAge
+		oversize := retention.MaxTotalSize > 0 && total > retention.MaxTotalSize
+		if !expired && !oversize {
+			// Sorted oldest first: nothing newer is expired either
+			break
+		}
+		if err := store.Delete(ctx, a); err != nil {
+			return deleted, freed, err
+		}
+		deleted++
+		freed += a.Size
+		total -= a.Size
+	}
+	return deleted, freed, nil
+}
+
+// audioKey returns the key of an audio file with the given content hash
+func audioKey(videoID, hash, ext string) string {
+	return audioPrefix + videoID + "/" + hash + ext
+}
+
+// videoPrefix returns the key prefix of all audio of a video
+func videoPrefix(videoID string) string {
+	return audioPrefix + videoID + "/"
+}
+
+// parseKey reads the video ID and hash back from an audio key
+func parseKey(key string) (videoID, hash string, ok bool) {
+	rest, found := strings.CutPrefix(key, audioPrefix)
+	if !found {
+		return "", "", false
+	}
+	videoID, name, found := strings.Cut(rest, "/")
+	if !found || videoID == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", false
+	}
+	hash = strings.TrimSuffix(name, path.Ext(name))
+	return videoID, hash, true
+}
+
+// hashFile returns the hex SHA-256 and size of a file
+func hashFile(localPath string) (string, int64, error) {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to hash %s: %w", localPath, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+// newest returns the most recently stored artifact, or ErrNotFound for none
+func newest(artifacts []*Artifact) (*Artifact, error) {
+	var latest *Artifact
+	for _, a := range artifacts {
+		if latest == nil || a.StoredAt.After(latest.StoredAt) {
+			latest = a
+		}
+	}
+	if latest == nil {
+		return nil, ErrNotFound
+	}
+	return latest, nil
+}
@@ -0,0 +1,177 @@
+package artifact
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LocalStore keeps artifacts in a directory on local disk
+type LocalStore struct {
+	root string
+}
+
+func NewLocalStore(root string) (*LocalStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create artifact directory: %w", err)
+	}
+	return &LocalStore{root: root}, nil
+}
+
+func (s *LocalStore) Put(ctx context.Context, videoID, localPath string) (*Artifact, error) {
+	hash, _, err := hashFile(localPath)
+	if err != nil {
+		return nil, err
+	}
+	key := audioKey(videoID, hash, filepath.Ext(localPath))
+	dst := s.path(key)
+
+	if _, err := os.Stat(dst); errors.Is(err, fs.ErrNotExist) {
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create artifact directory: %w", err)
+		}
+		if err := linkOrCopy(localPath, dst); err != nil {
+			return nil, fmt.Errorf("failed to store artifact: %w", err)
+		}
+	} else if err := touch(dst); err != nil {
+		return nil, fmt.Errorf("failed to store artifact: %w", err)
+	}
+	return s.stat(key)
+}
+
+func (s *LocalStore) Find(ctx context.Context, videoID string) (*Artifact, error) {
+	artifacts, err := s.list(videoPrefix(videoID))
+	if err != nil {
+		return nil, err
+	}
+	return newest(artifacts)
+}
+
+func (s *LocalStore) Fetch(ctx context.Context, artifact *Artifact, dir string) (string, error) {
+	dst := filepath.Join(dir, filepath.Base(artifact.Key))
+	if err := linkOrCopy(s.path(artifact.Key), dst); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("failed to fetch artifact: %w", err)
+	}
+	// Failing to record the use only makes the artifact a little more likely to be pruned
+	touch(s.path(artifact.Key))
+	return dst, nil
+}
+
+func (s *LocalStore) List(ctx context.Context) ([]*Artifact, error) {
+	return s.list(audioPrefix)
+}
+
+func (s *LocalStore) Delete(ctx context.Context, artifact *Artifact) error {
+	if err := os.Remove(s.path(artifact.Key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	// Drop the video's directory once it is empty; fails harmlessly otherwise
+	os.Remove(filepath.Dir(s.path(artifact.Key)))
+	return nil
+}
+
+func (s *LocalStore) list(prefix string) ([]*Artifact, error) {
+	var artifacts []*Artifact
+	err := filepath.WalkDir(s.path(prefix), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.root, p)
+		if err != nil {
+			return err
+		}
+		a, err := s.stat(filepath.ToSlash(rel))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if a != nil {
+			artifacts = append(artifacts, a)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+	return artifacts, nil
+}
+
+// stat describes the artifact stored under key, or returns nil for a file that is not one
+func (s *LocalStore) stat(key string) (*Artifact, error) {
+	videoID, hash, ok := parseKey(key)
+	if !ok {
+		return nil, nil
+	}
+	info, err := os.Stat(s.path(key))
+	if err != nil {
+		return nil, err
+	}
+	return &Artifact{
+		Key:      key,
+		VideoID:  videoID,
+		Hash:     hash,
+		Size:     info.Size(),
+		StoredAt: info.ModTime(),
+	}, nil
+}
+
+// touch sets the modification time of an artifact to now. Prune ages artifacts by it, so
+// artifacts still being reused are kept over those nobody asked for since they were stored.
+func touch(path string) error {
+	now := time.Now()
+	return os.Chtimes(path, now, now)
+}
+
+func (s *LocalStore) path(key string) string {
+	return filepath.Join(s.root, filepath.FromSlash(key))
+}
+
+// linkOrCopy hard-links src to dst, copying when both are not on the same filesystem. The copy
+// is written to a temporary name first so a partial file is never seen under dst.
+func linkOrCopy(src, dst string) error {
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), "."+filepath.Base(dst)+"-*")
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(tmp, in); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
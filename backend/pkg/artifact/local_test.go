@@ -0,0 +1,138 @@
+package artifact
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeAudio(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "download.mp3")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLocalStore_PutFindFetch(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = store.Find(ctx, "video-1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	src := writeAudio(t, "first take")
+	stored, err := store.Put(ctx, "video-1", src)
+	require.NoError(t, err)
+	assert.Equal(t, "video-1", stored.VideoID)
+	assert.Equal(t, int64(len("first take")), stored.Size)
+
+	// The download is gone once its job directory is cleaned up; the artifact is not
+	require.NoError(t, os.Remove(src))
+
+	again, err := store.Put(ctx, "video-1", writeAudio(t, "first take"))
+	require.NoError(t, err)
+	assert.Equal(t, stored.Key, again.Key, "the same content is stored once")
+
+	found, err := store.Find(ctx, "video-1")
+	require.NoError(t, err)
+	assert.Equal(t, stored.Key, found.Key)
+
+	path, err := store.Fetch(ctx, found, t.TempDir())
+	require.NoError(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "first take", string(data))
+
+	all, err := store.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+
+	require.NoError(t, store.Delete(ctx, found))
+	_, err = store.Find(ctx, "video-1")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestPrune(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+	now := time.Now()
+
+	put := func(videoID, content string, age time.Duration) *Artifact {
+		a, err := store.Put(ctx, videoID, writeAudio(t, content))
+		require.NoError(t, err)
+		stamp := now.Add(-age)
+		require.NoError(t, os.Chtimes(store.path(a.Key), stamp, stamp))
+		return a
+	}
+	put("expired", "0123456789", 10*24*time.Hour)
+	put("old", "0123456789", 3*24*time.Hour)
+	put("recent", "0123456789", 2*24*time.Hour)
+	put("new", "0123456789", time.Hour)
+
+	deleted, freed, err := Prune(ctx, store, Retention{MaxAge: 7 * 24 * time.Hour, MaxTotalSize: 25}, now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted, "the expired artifact, then the oldest until 25 bytes fit")
+	assert.Equal(t, int64(20), freed)
+
+	remaining, err := store.List(ctx)
+	require.NoError(t, err)
+	var ids []string
+	for _, a := range remaining {
+		ids = append(ids, a.VideoID)
+	}
+	assert.ElementsMatch(t, []string{"recent", "new"}, ids)
+}
+
+func TestParseKey(t *testing.T) {
+	videoID, hash, ok := parseKey("audio/5b0c/ab12.mp3")
+	assert.True(t, ok)
+	assert.Equal(t, "5b0c", videoID)
+	assert.Equal(t, "ab12", hash)
+
+	for _, key := range []string{"audio/5b0c", "video/5b0c/ab12.mp3", "audio/5b0c/x/ab12.mp3", "audio//ab12.mp3"} {
+		_, _, ok := parseKey(key)
+		assert.False(t, ok, key)
+	}
+}
+
+func TestLocalStore_ReuseKeepsArtifact(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+	now := time.Now()
+	weekAgo := now.Add(-7 * 24 * time.Hour)
+
+	fetched, err := store.Put(ctx, "fetched", writeAudio(t, "fetched"))
+	require.NoError(t, err)
+	restored, err := store.Put(ctx, "restored", writeAudio(t, "restored"))
+	require.NoError(t, err)
+	unused, err := store.Put(ctx, "unused", writeAudio(t, "unused"))
+	require.NoError(t, err)
+	for _, a := range []*Artifact{fetched, restored, unused} {
+		require.NoError(t, os.Chtimes(store.path(a.Key), weekAgo, weekAgo))
+	}
+
+	_, err = store.Fetch(ctx, fetched, t.TempDir())
+	require.NoError(t, err)
+	_, err = store.Put(ctx, "restored", writeAudio(t, "restored"))
+	require.NoError(t, err)
+
+	deleted, _, err := Prune(ctx, store, Retention{MaxAge: 24 * time.Hour}, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted, "only the artifact nobody used since it was stored expires")
+
+	remaining, err := store.List(ctx)
+	require.NoError(t, err)
+	var ids []string
+	for _, a := range remaining {
+		ids = append(ids, a.VideoID)
+	}
+	assert.ElementsMatch(t, []string{"fetched", "restored"}, ids)
+}
@@ -0,0 +1,389 @@
+package artifact
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// emptyPayloadHash is the SHA-256 of an empty request body
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// S3Config points at a bucket of an S3-compatible service (AWS S3, MinIO, ...)
+type S3Config struct {
+	Endpoint  string // e.g. "https://s3.eu-west-1.amazonaws.com" or "http://minio:9000"
+	Bucket    string
+	Region    string // "us-east-1" for MinIO unless configured otherwise
+	AccessKey string
+	SecretKey string
+	PathStyle bool // address the bucket as a path (MinIO) rather than a subdomain
+}
+
+// S3Store keeps artifacts in an S3-compatible bucket. Requests are signed with AWS Signature
+// Version 4.
+type S3Store struct {
+	cfg      S3Config
+	endpoint *url.URL
+	client   *http.Client
+	now      func() time.Time
+}
+
+func NewS3Store(cfg S3Config) (*S3Store, error) {
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("S3 bucket is not configured")
+	}
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", cfg.Endpoint)
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	return &S3Store{
+		cfg:      cfg,
+		endpoint: endpoint,
+		client: &http.Client{
+			Timeout: 30 * time.Minute, // large audio files on slow links
+		},
+		now: time.Now,
+	}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, videoID, localPath string) (*Artifact, error) {
+	hash, size, err := hashFile(localPath)
+	if err != nil {
+		return nil, err
+	}
+	key := audioKey(videoID, hash, filepath.Ext(localPath))
+
+	// Content-addressed: an object under this key already holds the same bytes
+	if existing, err := s.head(ctx, videoID, key); err == nil {
+		return existing, nil
+	} else if err != ErrNotFound {
+		return nil, err
+	}
+
+	f, err := os.Open(localPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	req, err := s.newRequest(ctx, http.MethodPut, key, nil, f, hash)
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = size
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	return &Artifact{
+		Key:      key,
+		VideoID:  videoID,
+		Hash:     hash,
+		Size:     size,
+		StoredAt: s.now(),
+	}, nil
+}
+
+func (s *S3Store) Find(ctx context.Context, videoID string) (*Artifact, error) {
+	artifacts, err := s.list(ctx, videoPrefix(videoID))
+	if err != nil {
+		return nil, err
+	}
+	return newest(artifacts)
+}
+
+func (s *S3Store) Fetch(ctx context.Context, artifact *Artifact, dir string) (string, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, artifact.Key, nil, nil, emptyPayloadHash)
+	if err != nil {
+		return "", err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	dst := filepath.Join(dir, path.Base(artifact.Key))
+	f, err := os.Create(dst)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		f.Close()
+		os.Remove(dst)
+		return "", fmt.Errorf("failed to download artifact: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(dst)
+		return "", err
+	}
+	return dst, nil
+}
+
+func (s *S3Store) List(ctx context.Context) ([]*Artifact, error) {
+	return s.list(ctx, audioPrefix)
+}
+
+func (s *S3Store) Delete(ctx context.Context, artifact *Artifact) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, artifact.Key, nil, nil, emptyPayloadHash)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req)
+	if err == ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (s *S3Store) head(ctx context.Context, videoID, key string) (*Artifact, error) {
+	req, err := s.newRequest(ctx, http.MethodHead, key, nil, nil, emptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	_, hash, _ := parseKey(key)
+	storedAt, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	return &Artifact{
+		Key:      key,
+		VideoID:  videoID,
+		Hash:     hash,
+		Size:     resp.ContentLength,
+		StoredAt: storedAt,
+	}, nil
+}
+
+// listBucketResult is the part of a ListObjectsV2 response used here
+type listBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (s *S3Store) list(ctx context.Context, prefix string) ([]*Artifact, error) {
+	var artifacts []*Artifact
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		req, err := s.newRequest(ctx, http.MethodGet, "", query, nil, emptyPayloadHash)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := s.do(req)
+		if err != nil {
+			return nil, err
+		}
+		var result listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("invalid S3 list response: %w", err)
+		}
+
+		for _, obj := range result.Contents {
+			videoID, hash, ok := parseKey(obj.Key)
+			if !ok {
+				continue
+			}
+			artifacts = append(artifacts, &Artifact{
+				Key:      obj.Key,
+				VideoID:  videoID,
+				Hash:     hash,
+				Size:     obj.Size,
+				StoredAt: obj.LastModified,
+			})
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return artifacts, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// newRequest builds a signed request for key (the bucket itself when empty). payloadHash is
+// the hex SHA-256 of body.
+func (s *S3Store) newRequest(ctx context.Context, method, key string, query url.Values, body io.Reader, payloadHash string) (*http.Request, error) {
+	u := *s.endpoint
+	if s.cfg.PathStyle {
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.cfg.Bucket + "/" + key
+	} else {
+		u.Host = s.cfg.Bucket + "." + u.Host
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + key
+	}
+	u.RawQuery = encodeQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	signV4(req, s.cfg.AccessKey, s.cfg.SecretKey, s.cfg.Region, payloadHash, s.now().UTC())
+	return req, nil
+}
+
+// do sends req, mapping 404 to ErrNotFound and other failures to an error with the S3 message
+func (s *S3Store) do(req *http.Request) (*http.Response, error) {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	var s3Err struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&s3Err); err == nil && s3Err.Code != "" {
+		return nil, fmt.Errorf("S3 %s %s: %s: %s", req.Method, req.URL.Path, s3Err.Code, s3Err.Message)
+	}
+	return nil, fmt.Errorf("S3 %s %s: unexpected status %d", req.Method, req.URL.Path, resp.StatusCode)
+}
+
+// signV4 adds the AWS Signature Version 4 Authorization header, signing the host and every
+// header already set on req
+func signV4(req *http.Request, accessKey, secretKey, region, payloadHash string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		encodePath(req.URL.Path),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signedHeaders, signature,
+	))
+}
+
+// encodeQuery encodes query parameters sorted by name, as the canonical request requires
+func encodeQuery(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var parts []string
+	for _, name := range names {
+		for _, value := range query[name] {
+			parts = append(parts, uriEncode(name, true)+"="+uriEncode(value, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// encodePath URI-encodes every segment of an object path
+func encodePath(p string) string {
+	if p == "" {
+		return "/"
+	}
+	return uriEncode(p, false)
+}
+
+// uriEncode percent-encodes everything but unreserved characters, and "/" unless encodeSlash
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
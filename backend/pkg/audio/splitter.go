@@ -0,0 +1,239 @@
+package audio
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultSilenceThreshold is the level (dB) below which audio counts as silence
+	DefaultSilenceThreshold = -35
+	// DefaultMinSilence is the shortest pause considered a cut point
+	DefaultMinSilence = 400 * time.Millisecond
+	// DefaultBitrate is the bitrate (kbps) chunks are encoded at; speech needs no more
+	DefaultBitrate = 64
+	// DefaultTimeout bounds a single ffmpeg run
+	DefaultTimeout = 30 * time.Minute
+)
+
+// sizeMargin leaves room for container overhead and bitrate overshoot when sizing chunks
+const sizeMargin = 0.95
+
+// Config configures the ffmpeg based Splitter
+type Config struct {
+	FFmpegPath       string        // defaults to "ffmpeg" on PATH
+	SilenceThreshold int           // dB, e.g. -35
+	MinSilence       time.Duration // shortest pause to cut at
+	Bitrate          int           // kbps of the mono 16 kHz MP3 chunks
+	Timeout          time.Duration
+}
+
+// Chunk is a piece of a longer recording
+type Chunk struct {
+	Path     string
+	Offset   float64 // start within the original recording, in seconds
+	Duration float64 // seconds
+}
+
+// Silence is a pause detected in a recording, in seconds
+type Silence struct {
+	Start float64
+	End   float64
+}
+
+// Splitter cuts recordings into chunks below a size limit, preferring to cut in pauses so no
+// word is split between two chunks
+type Splitter struct {
+	cfg Config
+}
+
+func NewSplitter(cfg Config) *Splitter {
+	if cfg.FFmpegPath == "" {
+		cfg.FFmpegPath = "ffmpeg"
+	}
+	if cfg.SilenceThreshold == 0 {
+		cfg.SilenceThreshold = DefaultSilenceThreshold
+	}
+	if cfg.MinSilence <= 0 {
+		cfg.MinSilence = DefaultMinSilence
+	}
+	if cfg.Bitrate <= 0 {
+		cfg.Bitrate = DefaultBitrate
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	return &Splitter{cfg: cfg}
+}
+
+// Split re-encodes the recording at path into chunks of at most maxBytes each, written to dir.
+// Cuts are placed in the last pause before a chunk would grow too large, or at the limit when
+// there is no pause.
+func (s *Splitter) Split(ctx context.Context, path, dir string, maxBytes int64) ([]Chunk, error) {
+	maxDuration := float64(maxBytes) * sizeMargin / (float64(s.cfg.Bitrate) * 1000 / 8)
+	if maxDuration < 1 {
+		return nil, fmt.Errorf("chunk size limit of %d bytes is too small", maxBytes)
+	}
+
+	duration, silences, err := s.DetectSilences(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	cuts := PlanCuts(duration, silences, maxDuration)
+
+	args := []string{"-hide_banner", "-loglevel", "error", "-y", "-i", path,
+		"-vn", "-ac", "1", "-ar", "16000", "-b:a", strconv.Itoa(s.cfg.Bitrate) + "k"}
+	if len(cuts) > 0 {
+		times := make([]string, len(cuts))
+		for i, cut := range cuts {
+			times[i] = strconv.FormatFloat(cut, 'f', 3, 64)
+		}
+		args = append(args, "-f", "segment", "-segment_times", strings.Join(times, ","),
+			"-reset_timestamps", "1", filepath.Join(dir, "chunk-%03d.mp3"))
+	} else {
+		args = append(args, filepath.Join(dir, "chunk-000.mp3"))
+	}
+	if _, err := s.run(ctx, args...); err != nil {
+		return nil, err
+	}
+
+	bounds := append(append([]float64{0}, cuts...), duration)
+	chunks := make([]Chunk, 0, len(bounds)-1)
+	for i := 0; i < len(bounds)-1; i++ {
+		chunkPath := filepath.Join(dir, fmt.Sprintf("chunk-%03d.mp3", i))
+		if _, err := os.Stat(chunkPath); err != nil {
+			return nil, fmt.Errorf("ffmpeg did not write chunk %d: %w", i, err)
+		}
+		chunks = append(chunks, Chunk{
+			Path:     chunkPath,
+			Offset:   bounds[i],
+			Duration: bounds[i+1] - bounds[i],
+		})
+	}
+	return chunks, nil
+}
+
+// DetectSilences returns the duration of the recording at path and the pauses in it
+func (s *Splitter) DetectSilences(ctx context.Context, path string) (float64, []Silence, error) {
+	filter := fmt.Sprintf("silencedetect=noise=%ddB:d=%s", s.cfg.SilenceThreshold,
+		strconv.FormatFloat(s.cfg.MinSilence.Seconds(), 'f', -1, 64))
+	// silencedetect reports on stderr, which is also where the input's duration is printed
+	stderr, err := s.run(ctx, "-hide_banner", "-nostats", "-i", path, "-vn", "-af", filter, "-f", "null", "-")
+	if err != nil {
+		return 0, nil, err
+	}
+	duration, silences := ParseSilenceDetect(stderr)
+	if duration <= 0 {
+		return 0, nil, fmt.Errorf("could not determine the duration of %s", filepath.Base(path))
+	}
+	return duration, silences, nil
+}
+
+var (
+	durationPattern     = regexp.MustCompile(`Duration: (\d+):(\d{2}):(\d{2}(?:\.\d+)?)`)
+	silenceStartPattern = regexp.MustCompile(`silence_start: (-?[\d.]+)`)
+	silenceEndPattern   = regexp.MustCompile(`silence_end: ([\d.]+)`)
+)
+
+// ParseSilenceDetect reads the input duration and the silencedetect filter's pauses from
+// ffmpeg's log output. A pause still open at the end of the input lasts until the end.
+func ParseSilenceDetect(output []byte) (float64, []Silence) {
+	var duration float64
+	var silences []Silence
+	open := -1.0
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := durationPattern.FindStringSubmatch(line); m != nil && duration == 0 {
+			hours, _ := strconv.Atoi(m[1])
+			minutes, _ := strconv.Atoi(m[2])
+			seconds, _ := strconv.ParseFloat(m[3], 64)
+			duration = float64(hours*3600+minutes*60) + seconds
+		}
+		if m := silenceStartPattern.FindStringSubmatch(line); m != nil {
+			open, _ = strconv.ParseFloat(m[1], 64)
+			if open < 0 {
+				open = 0
+			}
+		}
+		if m := silenceEndPattern.FindStringSubmatch(line); m != nil && open >= 0 {
+			end, _ := strconv.ParseFloat(m[1], 64)
+			silences = append(silences, Silence{Start: open, End: end})
+			open = -1
+		}
+	}
+	if open >= 0 && duration > open {
+		silences = append(silences, Silence{Start: open, End: duration})
+	}
+	return duration, silences
+}
+
+// PlanCuts returns the times to cut a recording at so no piece is longer than maxDuration.
+// Each cut is placed in the middle of the latest pause in the second half of the allowed
+// window, falling back to a hard cut at the limit.
+func PlanCuts(duration float64, silences []Silence, maxDuration float64) []float64 {
+	sorted := append([]Silence(nil), silences...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+
+	var cuts []float64
+	start := 0.0
+	for duration-start > maxDuration {
+		limit := start + maxDuration
+		cut := limit
+		for _, silence := range sorted {
+			mid := (silence.Start + silence.End) / 2
+			if mid > limit {
+				break
+			}
+			if mid > start+maxDuration/2 {
+				cut = mid
+			}
+		}
+		cuts = append(cuts, cut)
+		start = cut
+	}
+	return cuts
+}
+
+// run executes ffmpeg and returns its stderr, where it logs
+func (s *Splitter) run(ctx context.Context, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, s.cfg.FFmpegPath, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("ffmpeg timed out after %s", s.cfg.Timeout)
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("ffmpeg: %s", lastLine(msg))
+		}
+		return nil, fmt.Errorf("ffmpeg: %w", err)
+	}
+	return stderr.Bytes(), nil
+}
+
+// lastLine returns the final line of ffmpeg's log, which carries the actual error
+func lastLine(s string) string {
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
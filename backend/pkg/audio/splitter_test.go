@@ -0,0 +1,104 @@
+package audio
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const silenceDetectLog = `Input #0, mp3, from 'lecture.mp3':
+  Duration: 00:10:00.00, start: 0.025057, bitrate: 128 kb/s
+  Stream #0:0: Audio: mp3, 44100 Hz, stereo, fltp, 128 kb/s
+[silencedetect @ 0x5581] silence_start: 170
+[silencedetect @ 0x5581] silence_end: 172 | silence_duration: 2
+[silencedetect @ 0x5581] silence_start: 350
+[silencedetect @ 0x5581] silence_end: 351.5 | silence_duration: 1.5
+[silencedetect @ 0x5581] silence_start: 500
+[silencedetect @ 0x5581] silence_end: 501 | silence_duration: 1
+[silencedetect @ 0x5581] silence_start: 598.2
+`
+
+func TestParseSilenceDetect(t *testing.T) {
+	duration, silences := ParseSilenceDetect([]byte(silenceDetectLog))
+	assert.Equal(t, 600.0, duration)
+	assert.Equal(t, []Silence{
+		{Start: 170, End: 172},
+		{Start: 350, End: 351.5},
+		{Start: 500, End: 501},
+		{Start: 598.2, End: 600}, // still silent at the end of the input
+	}, silences)
+}
+
+func TestPlanCuts(t *testing.T) {
+	_, silences := ParseSilenceDetect([]byte(silenceDetectLog))
+
+	assert.Equal(t, []float64{171, 350.75, 500.5}, PlanCuts(600, silences, 200),
+		"each cut is in the last pause that keeps the chunk under the limit")
+	assert.Empty(t, PlanCuts(600, silences, 600), "short enough as is")
+	assert.Equal(t, []float64{100, 200}, PlanCuts(250, nil, 100), "hard cuts without pauses")
+	assert.Equal(t, []float64{100, 200}, PlanCuts(250, []Silence{{Start: 10, End: 12}}, 100),
+		"a pause early in the window would leave a needlessly short chunk")
+}
+
+// fakeFFmpeg writes a shell script standing in for ffmpeg: it prints silenceDetectLog for
+// the silencedetect run and writes chunkCount chunks for the split run, whose last argument
+// is the output pattern
+func fakeFFmpeg(t *testing.T, chunkCount int) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "silence.log")
+	require.NoError(t, os.WriteFile(logFile, []byte(silenceDetectLog), 0o644))
+	argsFile := filepath.Join(dir, "args.txt")
+
+	script := `#!/bin/sh
+printf '%s\n' "$*" >> ` + argsFile + `
+for last; do :; done
+if [ "$last" = "-" ]; then
+	cat ` + logFile + ` >&2
+	exit 0
+fi
+i=0
+while [ $i -lt ` + strconv.Itoa(chunkCount) + ` ]; do
+	printf 'chunk %d' $i > "$(printf "$last" $i)"
+	i=$((i+1))
+done
+`
+	path := filepath.Join(dir, "ffmpeg")
+	require.NoError(t, os.WriteFile(path, []byte(script), 0o755))
+	return path, argsFile
+}
+
+func TestSplitter_Split(t *testing.T) {
+	binary, argsFile := fakeFFmpeg(t, 4)
+	splitter := NewSplitter(Config{FFmpegPath: binary})
+	dir := t.TempDir()
+
+	// 64 kbps chunks: 200 seconds fit in 200*8000/0.95 bytes
+	chunks, err := splitter.Split(context.Background(), "lecture.mp3", dir, 1684211)
+	require.NoError(t, err)
+
+	require.Len(t, chunks, 4)
+	assert.Equal(t, Chunk{Path: filepath.Join(dir, "chunk-000.mp3"), Offset: 0, Duration: 171}, chunks[0])
+	assert.Equal(t, 350.75, chunks[2].Offset)
+	assert.Equal(t, 500.5, chunks[3].Offset)
+	assert.InDelta(t, 99.5, chunks[3].Duration, 1e-9)
+
+	data, err := os.ReadFile(argsFile)
+	require.NoError(t, err)
+	runs := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, runs, 2)
+	assert.Contains(t, runs[0], "-af silencedetect=noise=-35dB:d=0.4")
+	assert.Contains(t, runs[1], "-ac 1 -ar 16000 -b:a 64k -f segment -segment_times 171.000,350.750,500.500")
+}
+
+func TestSplitter_Split_LimitTooSmall(t *testing.T) {
+	splitter := NewSplitter(Config{FFmpegPath: "/nonexistent/ffmpeg"})
+	_, err := splitter.Split(context.Background(), "lecture.mp3", t.TempDir(), 100)
+	assert.ErrorContains(t, err, "too small")
+}
@@ -0,0 +1,49 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNoSubtitles is returned by DownloadSubtitles when none of the requested languages exist
+var ErrNoSubtitles = errors.New("no subtitles available")
+
+// Downloader fetches media and metadata of online videos
+type Downloader interface {
+	// DownloadAudio extracts the audio of url as mp3 into dir and returns the file path.
+	// name is the file name without extension. dir should belong to this download alone (see
+	// Workspace); files already in it may be mistaken for the output.
+	DownloadAudio(ctx context.Context, url, dir, name string) (string, error)
+	// DownloadSubtitles returns WebVTT subtitles, manual or auto-generated, in the first
+	// available language of languages, or ErrNoSubtitles
+	DownloadSubtitles(ctx context.Context, url string, languages []string) (*Subtitles, error)
+	// ListSubtitles returns the table of subtitle languages printed by yt-dlp --list-subs
+	ListSubtitles(ctx context.Context, url string) (string, error)
+	// Metadata returns the JSON metadata of url as printed by yt-dlp --dump-single-json
+	Metadata(ctx context.Context, url string) ([]byte, error)
+}
+
+// Subtitles is a downloaded subtitle file
+type Subtitles struct {
+	Language string
+	Content  []byte // WebVTT
+}
+
+// Config configures the yt-dlp downloader
+type Config struct {
+	BinaryPath      string        // yt-dlp executable, looked up in PATH when only a name
+	Proxy           string        // e.g. "socks5://127.0.0.1:1080"
+	CookiesFile     string        // Netscape-format cookies file, for age-restricted or members-only videos
+	Retries         int           // retries per download
+	RateLimit       string        // maximum download rate, e.g. "2M"
+	SleepRequests   time.Duration // pause between requests while extracting, against rate limiting
+	MetadataTimeout time.Duration // limit for listing subtitles, subtitle and metadata downloads
+	DownloadTimeout time.Duration // limit for audio downloads
+}
+
+// Default timeouts used when Config leaves them zero
+const (
+	DefaultMetadataTimeout = 2 * time.Minute
+	DefaultDownloadTimeout = 30 * time.Minute
+)
@@ -0,0 +1,121 @@
+package downloader
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Fake is a Downloader serving fixture files from a directory, for tests. Fixtures are named
+// after the video key of the URL (its "v" query parameter, otherwise the last path element):
+//
+//	<key>.mp3 (or any other extension)  audio for DownloadAudio
+//	<key>.<lang>.vtt                    subtitles for DownloadSubtitles
+//	<key>.subs.txt                      output for ListSubtitles
+//	<key>.info.json                     output for Metadata
+type Fake struct {
+	dir string
+
+	mu    sync.Mutex
+	calls []string
+}
+
+func NewFake(dir string) *Fake {
+	return &Fake{dir: dir}
+}
+
+// Calls lists the calls made so far as "<method> <url>"
+func (f *Fake) Calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+func (f *Fake) DownloadAudio(ctx context.Context, rawURL, dir, name string) (string, error) {
+	key := f.record("DownloadAudio", rawURL)
+	for _, fixture := range f.fixtures(key + ".*") {
+		ext := filepath.Ext(fixture)
+		if ext == ".vtt" || ext == ".txt" || ext == ".json" {
+			continue
+		}
+		dst := filepath.Join(dir, name+ext)
+		if err := copyFile(fixture, dst); err != nil {
+			return "", err
+		}
+		return dst, nil
+	}
+	return "", fmt.Errorf("yt-dlp: no audio fixture for %s", key)
+}
+
+func (f *Fake) DownloadSubtitles(ctx context.Context, rawURL string, languages []string) (*Subtitles, error) {
+	key := f.record("DownloadSubtitles", rawURL)
+	for _, lang := range languages {
+		content, err := os.ReadFile(filepath.Join(f.dir, key+"."+lang+".vtt"))
+		if err == nil {
+			return &Subtitles{Language: lang, Content: content}, nil
+		}
+	}
+	return nil, ErrNoSubtitles
+}
+
+func (f *Fake) ListSubtitles(ctx context.Context, rawURL string) (string, error) {
+	key := f.record("ListSubtitles", rawURL)
+	content, err := os.ReadFile(filepath.Join(f.dir, key+".subs.txt"))
+	if err != nil {
+		return "", fmt.Errorf("yt-dlp: %w", err)
+	}
+	return string(content), nil
+}
+
+func (f *Fake) Metadata(ctx context.Context, rawURL string) ([]byte, error) {
+	key := f.record("Metadata", rawURL)
+	content, err := os.ReadFile(filepath.Join(f.dir, key+".info.json"))
+	if err != nil {
+		return nil, fmt.Errorf("yt-dlp: %w", err)
+	}
+	return content, nil
+}
+
+func (f *Fake) record(method, rawURL string) string {
+	f.mu.Lock()
+	f.calls = append(f.calls, method+" "+rawURL)
+	f.mu.Unlock()
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	if v := u.Query().Get("v"); v != "" {
+		return v
+	}
+	return path.Base(strings.TrimSuffix(u.Path, "/"))
+}
+
+func (f *Fake) fixtures(pattern string) []string {
+	matches, _ := filepath.Glob(filepath.Join(f.dir, pattern))
+	return matches
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,227 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrDiskQuotaExceeded is returned by Workspace.Fetch while the downloads on disk use up the quota
+var ErrDiskQuotaExceeded = errors.New("download disk quota exceeded")
+
+// FetchFunc downloads a file into dir, an empty directory owned by the job. cleanup releases
+// anything the download created outside dir; it may be nil.
+type FetchFunc func(ctx context.Context, dir string) (path string, cleanup func(), err error)
+
+// Workspace runs downloads in isolated job directories under a root directory. Concurrent
+// fetches of the same key share one download, which is removed once every caller released it.
+// The number of simultaneous downloads, their duration and the disk space they use are capped.
+type Workspace struct {
+	root      string
+	quota     int64 // bytes, 0 for no limit
+	timeout   time.Duration
+	downloads chan struct{}
+
+	mu   sync.Mutex
+	jobs map[string]*job
+}
+
+type job struct {
+	done     chan struct{}
+	cancel   context.CancelFunc
+	finished bool // guarded by Workspace.mu
+	path     string
+	err      error
+	dir      string
+	cleanup  func()
+	refs     int
+}
+
+// NewWorkspace creates root if needed. maxConcurrent <= 0 allows one download at a time,
+// quotaBytes <= 0 disables the disk quota and timeout <= 0 uses DefaultDownloadTimeout.
+func NewWorkspace(root string, maxConcurrent int, quotaBytes int64, timeout time.Duration) (*Workspace, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create download directory: %w", err)
+	}
+	if maxConcurrent <= 0 {
+		maxConcurrent = 1
+	}
+	if quotaBytes < 0 {
+		quotaBytes = 0
+	}
+	if timeout <= 0 {
+		timeout = DefaultDownloadTimeout
+	}
+	return &Workspace{
+		root:      root,
+		quota:     quotaBytes,
+		timeout:   timeout,
+		downloads: make(chan struct{}, maxConcurrent),
+		jobs:      make(map[string]*job),
+	}, nil
+}
+
+// Fetch returns the file fetch downloads for key, starting the download unless one for the
+// same key is already running or held. Call release when done with the file. The download does
+// not depend on the caller that started it: it keeps running while any caller still waits for
+// it, is cancelled once all of them gave up and is limited by the workspace timeout.
+func (w *Workspace) Fetch(ctx context.Context, key string, fetch FetchFunc) (string, func(), error) {
+	w.mu.Lock()
+	j, shared := w.jobs[key]
+	if !shared {
+		jobCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), w.timeout)
+		j = &job{done: make(chan struct{}), cancel: cancel}
+		w.jobs[key] = j
+		go w.start(jobCtx, key, j, fetch)
+	}
+	j.refs++
+	w.mu.Unlock()
+
+	select {
+	case <-j.done:
+	case <-ctx.Done():
+		w.release(key, j)
+		return "", nil, ctx.Err()
+	}
+	if j.err != nil {
+		w.release(key, j)
+		return "", nil, j.err
+	}
+
+	var once sync.Once
+	return j.path, func() { once.Do(func() { w.release(key, j) }) }, nil
+}
+
+// start runs the download of j and publishes its result to the callers waiting for it
+func (w *Workspace) start(ctx context.Context, key string, j *job, fetch FetchFunc) {
+	path, dir, cleanup, err := w.run(ctx, key, fetch)
+	j.cancel()
+
+	w.mu.Lock()
+	j.path, j.dir, j.cleanup, j.err = path, dir, cleanup, err
+	j.finished = true
+	abandoned := j.refs == 0
+	// Let the next caller retry instead of reusing the failure
+	if (err != nil || abandoned) && w.jobs[key] == j {
+		delete(w.jobs, key)
+	}
+	w.mu.Unlock()
+	close(j.done)
+
+	if abandoned && err == nil {
+		j.remove()
+	}
+}
+
+func (w *Workspace) run(ctx context.Context, key string, fetch FetchFunc) (string, string, func(), error) {
+	select {
+	case w.downloads <- struct{}{}:
+		defer func() { <-w.downloads }()
+	case <-ctx.Done():
+		return "", "", nil, ctx.Err()
+	}
+
+	if err := w.checkQuota(); err != nil {
+		return "", "", nil, err
+	}
+
+	dir, err := os.MkdirTemp(w.root, "job-")
+	if err != nil {
+		return "", "", nil, fmt.Errorf("failed to create job directory: %w", err)
+	}
+
+	path, cleanup, err := fetch(ctx, dir)
+	if err == nil {
+		err = w.checkQuota()
+		if err != nil && cleanup != nil {
+			cleanup()
+		}
+	}
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", "", nil, err
+	}
+	return path, dir, cleanup, nil
+}
+
+// release drops one reference to j. The last one removes its files, or cancels the download
+// when it is still running, which then removes them itself.
+func (w *Workspace) release(key string, j *job) {
+	w.mu.Lock()
+	j.refs--
+	last := j.refs == 0
+	if last && w.jobs[key] == j {
+		delete(w.jobs, key)
+	}
+	finished := j.finished
+	w.mu.Unlock()
+
+	if !last {
+		return
+	}
+	if !finished {
+		j.cancel()
+		return
+	}
+	if j.err == nil {
+		j.remove()
+	}
+}
+
+// remove deletes the files of a successful download
+func (j *job) remove() {
+	if j.cleanup != nil {
+		j.cleanup()
+	}
+	os.RemoveAll(j.dir)
+}
+
+// checkQuota fails when the files under root use more than the quota
+func (w *Workspace) checkQuota() error {
+	if w.quota == 0 {
+		return nil
+	}
+	used, err := w.Usage()
+	if err != nil {
+		return err
+	}
+	if used > w.quota {
+		return ErrDiskQuotaExceeded
+	}
+	return nil
+}
+
+// Usage returns the bytes used by files under the workspace root
+func (w *Workspace) Usage() (int64, error) {
+	var total int64
+	err := filepath.WalkDir(w.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Job directories disappear while walking when their downloads are released
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		total += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to measure download directory: %w", err)
+	}
+	return total, nil
+}
@@ -0,0 +1,244 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFetch returns a FetchFunc writing size bytes to dir/audio.mp3 after delay
+func writeFetch(calls *int32, size int, delay time.Duration) FetchFunc {
+	return func(ctx context.Context, dir string) (string, func(), error) {
+		atomic.AddInt32(calls, 1)
+		time.Sleep(delay)
+		path := filepath.Join(dir, "audio.mp3")
+		if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+			return "", nil, err
+		}
+		return path, nil, nil
+	}
+}
+
+func TestWorkspace_SharesConcurrentDownloads(t *testing.T) {
+	ws, err := NewWorkspace(t.TempDir(), 2, 0, 0)
+	require.NoError(t, err)
+
+	var calls int32
+	fetch := writeFetch(&calls, 100, 50*time.Millisecond)
+
+	var wg sync.WaitGroup
+	paths := make([]string, 3)
+	releases := make([]func(), 3)
+	for i := range paths {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var err error
+			paths[i], releases[i], err = ws.Fetch(context.Background(), "video-1", fetch)
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls)
+	assert.Equal(t, paths[0], paths[1])
+	assert.Equal(t, paths[0], paths[2])
+
+	releases[0]()
+	releases[0]() // releasing twice counts once
+	releases[1]()
+	assert.FileExists(t, paths[0], "the file stays while a caller holds it")
+
+	releases[2]()
+	assert.NoDirExists(t, filepath.Dir(paths[0]))
+
+	// Released downloads are fetched again
+	_, release, err := ws.Fetch(context.Background(), "video-1", fetch)
+	require.NoError(t, err)
+	release()
+	assert.Equal(t, int32(2), calls)
+}
+
+func TestWorkspace_IsolatesJobs(t *testing.T) {
+	ws, err := NewWorkspace(t.TempDir(), 2, 0, 0)
+	require.NoError(t, err)
+
+	var calls int32
+	first, releaseFirst, err := ws.Fetch(context.Background(), "video-1", writeFetch(&calls, 10, 0))
+	require.NoError(t, err)
+	second, releaseSecond, err := ws.Fetch(context.Background(), "video-2", writeFetch(&calls, 10, 0))
+	require.NoError(t, err)
+
+	assert.NotEqual(t, filepath.Dir(first), filepath.Dir(second))
+	releaseFirst()
+	assert.FileExists(t, second)
+	releaseSecond()
+}
+
+func TestWorkspace_LimitsConcurrentDownloads(t *testing.T) {
+	ws, err := NewWorkspace(t.TempDir(), 2, 0, 0)
+	require.NoError(t, err)
+
+	var running, peak int32
+	fetch := func(ctx context.Context, dir string) (string, func(), error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(30 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return filepath.Join(dir, "audio.mp3"), nil, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, release, err := ws.Fetch(context.Background(), string(rune('a'+i)), fetch)
+			if assert.NoError(t, err) {
+				release()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), peak)
+}
+
+func TestWorkspace_DiskQuota(t *testing.T) {
+	ws, err := NewWorkspace(t.TempDir(), 2, 1000, 0)
+	require.NoError(t, err)
+
+	var calls int32
+	_, release, err := ws.Fetch(context.Background(), "large", writeFetch(&calls, 1500, 0))
+	assert.ErrorIs(t, err, ErrDiskQuotaExceeded, "a download ending above the quota is discarded")
+	assert.Nil(t, release)
+
+	used, err := ws.Usage()
+	require.NoError(t, err)
+	assert.Zero(t, used)
+
+	_, releaseSmall, err := ws.Fetch(context.Background(), "small", writeFetch(&calls, 800, 0))
+	require.NoError(t, err)
+	_, releaseSmall2, err := ws.Fetch(context.Background(), "small-2", writeFetch(&calls, 300, 0))
+	assert.ErrorIs(t, err, ErrDiskQuotaExceeded)
+	assert.Nil(t, releaseSmall2)
+
+	releaseSmall()
+	_, releaseSmall2, err = ws.Fetch(context.Background(), "small-2", writeFetch(&calls, 300, 0))
+	require.NoError(t, err)
+	releaseSmall2()
+}
+
+func TestWorkspace_FailureIsNotReused(t *testing.T) {
+	ws, err := NewWorkspace(t.TempDir(), 1, 0, 0)
+	require.NoError(t, err)
+
+	failing := func(ctx context.Context, dir string) (string, func(), error) {
+		return "", nil, errors.New("yt-dlp: HTTP Error 429")
+	}
+	_, _, err = ws.Fetch(context.Background(), "video-1", failing)
+	assert.EqualError(t, err, "yt-dlp: HTTP Error 429")
+
+	var calls int32
+	_, release, err := ws.Fetch(context.Background(), "video-1", writeFetch(&calls, 10, 0))
+	require.NoError(t, err)
+	release()
+	assert.Equal(t, int32(1), calls)
+}
+
+func TestWorkspace_StarterCancelsWaiterStillSucceeds(t *testing.T) {
+	ws, err := NewWorkspace(t.TempDir(), 1, 0, 0)
+	require.NoError(t, err)
+
+	started := make(chan struct{})
+	proceed := make(chan struct{})
+	fetch := func(ctx context.Context, dir string) (string, func(), error) {
+		close(started)
+		select {
+		case <-proceed:
+		case <-ctx.Done():
+			return "", nil, ctx.Err()
+		}
+		path := filepath.Join(dir, "audio.mp3")
+		return path, nil, os.WriteFile(path, []byte("audio"), 0o644)
+	}
+
+	starterCtx, cancelStarter := context.WithCancel(context.Background())
+	starterErr := make(chan error, 1)
+	go func() {
+		_, _, err := ws.Fetch(starterCtx, "video-1", fetch)
+		starterErr <- err
+	}()
+	<-started
+
+	waiterDone := make(chan struct{})
+	var path string
+	var release func()
+	go func() {
+		defer close(waiterDone)
+		var err error
+		path, release, err = ws.Fetch(context.Background(), "video-1", fetch)
+		assert.NoError(t, err)
+	}()
+	require.Eventually(t, func() bool {
+		ws.mu.Lock()
+		defer ws.mu.Unlock()
+		return ws.jobs["video-1"].refs == 2
+	}, time.Second, time.Millisecond)
+
+	cancelStarter()
+	assert.ErrorIs(t, <-starterErr, context.Canceled)
+
+	close(proceed)
+	<-waiterDone
+	require.NotNil(t, release)
+	assert.FileExists(t, path, "the download goes on for the remaining caller")
+	release()
+	assert.NoDirExists(t, filepath.Dir(path))
+}
+
+func TestWorkspace_CancelsDownloadWithoutWaiters(t *testing.T) {
+	root := t.TempDir()
+	ws, err := NewWorkspace(root, 1, 0, 0)
+	require.NoError(t, err)
+
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	fetch := func(ctx context.Context, dir string) (string, func(), error) {
+		close(started)
+		<-ctx.Done()
+		close(stopped)
+		return "", nil, ctx.Err()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+	_, _, err = ws.Fetch(ctx, "video-1", fetch)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("the download was not cancelled after its last caller left")
+	}
+	assert.Eventually(t, func() bool {
+		entries, err := os.ReadDir(root)
+		return err == nil && len(entries) == 0
+	}, time.Second, 10*time.Millisecond, "the job directory is removed")
+}
@@ -0,0 +1,170 @@
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// YtDlp runs the yt-dlp command line tool
+type YtDlp struct {
+	cfg Config
+}
+
+func NewYtDlp(cfg Config) *YtDlp {
+	if cfg.BinaryPath == "" {
+		cfg.BinaryPath = "yt-dlp"
+	}
+	if cfg.MetadataTimeout <= 0 {
+		cfg.MetadataTimeout = DefaultMetadataTimeout
+	}
+	if cfg.DownloadTimeout <= 0 {
+		cfg.DownloadTimeout = DefaultDownloadTimeout
+	}
+	return &YtDlp{cfg: cfg}
+}
+
+func (y *YtDlp) DownloadAudio(ctx context.Context, url, dir, name string) (string, error) {
+	_, err := y.run(ctx, y.cfg.DownloadTimeout,
+		"-x",                    // Extract audio
+		"--audio-format", "mp3", // Format
+		"--quiet",    // Less verbose output
+		"--no-mtime", // Don't use modification time (prevents some file issues)
+		"-o", filepath.Join(dir, name+".%(ext)s"),
+		url,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	matches, err := filepath.Glob(filepath.Join(dir, name+".*"))
+	if err != nil {
+		return "", fmt.Errorf("failed to find output file: %w", err)
+	}
+	for _, path := range matches {
+		if !strings.HasSuffix(path, ".part") {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("output file not found after download")
+}
+
+func (y *YtDlp) DownloadSubtitles(ctx context.Context, url string, languages []string) (*Subtitles, error) {
+	dir, err := os.MkdirTemp("", "yt-dlp-subs-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create subtitle directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	_, err = y.run(ctx, y.cfg.MetadataTimeout,
+		"--write-auto-subs",   // Include auto-generated subtitles
+		"--write-subs",        // Write subtitle file
+		"--sub-format", "vtt", // Use WebVTT format (easier to parse)
+		"--sub-lang", strings.Join(languages, ","),
+		"--skip-download", // Don't download video/audio
+		"--quiet",         // Less verbose output
+		"-o", filepath.Join(dir, "subs.%(ext)s"),
+		url,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	path, language := pickSubtitleFile(dir, languages)
+	if path == "" {
+		return nil, ErrNoSubtitles
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read subtitle file: %w", err)
+	}
+	return &Subtitles{Language: language, Content: content}, nil
+}
+
+func (y *YtDlp) ListSubtitles(ctx context.Context, url string) (string, error) {
+	stdout, err := y.run(ctx, y.cfg.MetadataTimeout,
+		"--list-subs", // List available subtitles
+		"--quiet",     // Less verbose output
+		url,
+	)
+	return string(stdout), err
+}
+
+func (y *YtDlp) Metadata(ctx context.Context, url string) ([]byte, error) {
+	return y.run(ctx, y.cfg.MetadataTimeout,
+		"--dump-single-json", // Print metadata as JSON
+		"--skip-download",    // Don't download video/audio
+		url,
+	)
+}
+
+// run executes yt-dlp with the configured network options followed by args
+func (y *YtDlp) run(ctx context.Context, timeout time.Duration, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, y.cfg.BinaryPath, append(y.baseArgs(), args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("yt-dlp timed out after %s", timeout)
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("yt-dlp: %s", msg)
+		}
+		return nil, fmt.Errorf("yt-dlp: %w", err)
+	}
+	return stdout.Bytes(), nil
+}
+
+func (y *YtDlp) baseArgs() []string {
+	args := []string{
+		"--no-playlist", // Don't download playlists
+		"--no-warnings", // Suppress warnings
+	}
+	if y.cfg.Proxy != "" {
+		args = append(args, "--proxy", y.cfg.Proxy)
+	}
+	if y.cfg.CookiesFile != "" {
+		args = append(args, "--cookies", y.cfg.CookiesFile)
+	}
+	if y.cfg.Retries > 0 {
+		args = append(args, "--retries", strconv.Itoa(y.cfg.Retries))
+	}
+	if y.cfg.RateLimit != "" {
+		args = append(args, "--limit-rate", y.cfg.RateLimit)
+	}
+	if y.cfg.SleepRequests > 0 {
+		args = append(args, "--sleep-requests", strconv.FormatFloat(y.cfg.SleepRequests.Seconds(), 'f', -1, 64))
+	}
+	return args
+}
+
+// pickSubtitleFile returns the subtitle file in dir for the earliest of languages, falling back
+// to any file when yt-dlp matched a language by pattern. Files are named "subs.<lang>.vtt".
+func pickSubtitleFile(dir string, languages []string) (string, string) {
+	for _, lang := range languages {
+		path := filepath.Join(dir, "subs."+lang+".vtt")
+		if _, err := os.Stat(path); err == nil {
+			return path, lang
+		}
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, "subs.*.vtt"))
+	if len(matches) == 0 {
+		return "", ""
+	}
+	lang := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(matches[0]), "subs."), ".vtt")
+	return matches[0], lang
+}
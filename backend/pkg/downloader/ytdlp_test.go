@@ -0,0 +1,130 @@
+package downloader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBinary writes a shell script standing in for yt-dlp. It records its arguments in
+// args.txt next to it and then runs body.
+func fakeBinary(t *testing.T, body string) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	argsFile := filepath.Join(dir, "args.txt")
+	script := "#!/bin/sh\nprintf '%s\\n' \"$@\" > " + argsFile + "\n" + body + "\n"
+	path := filepath.Join(dir, "yt-dlp")
+	require.NoError(t, os.WriteFile(path, []byte(script), 0o755))
+	return path, argsFile
+}
+
+func readArgs(t *testing.T, argsFile string) []string {
+	t.Helper()
+	data, err := os.ReadFile(argsFile)
+	require.NoError(t, err)
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func TestYtDlp_NetworkOptions(t *testing.T) {
+	binary, argsFile := fakeBinary(t, `echo '{"id": "abc"}'`)
+	dl := NewYtDlp(Config{
+		BinaryPath:    binary,
+		Proxy:         "socks5://127.0.0.1:1080",
+		CookiesFile:   "/etc/yt-dlp/cookies.txt",
+		Retries:       5,
+		RateLimit:     "2M",
+		SleepRequests: 1500 * time.Millisecond,
+	})
+
+	data, err := dl.Metadata(context.Background(), "https://www.youtube.com/watch?v=abc")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id": "abc"}`, string(data))
+
+	args := strings.Join(readArgs(t, argsFile), " ")
+	assert.Contains(t, args, "--proxy socks5://127.0.0.1:1080")
+	assert.Contains(t, args, "--cookies /etc/yt-dlp/cookies.txt")
+	assert.Contains(t, args, "--retries 5")
+	assert.Contains(t, args, "--limit-rate 2M")
+	assert.Contains(t, args, "--sleep-requests 1.5")
+	assert.True(t, strings.HasSuffix(args, "--dump-single-json --skip-download https://www.youtube.com/watch?v=abc"))
+}
+
+func TestYtDlp_DownloadSubtitles_PrefersRequestedOrder(t *testing.T) {
+	// Write a file for every language, as yt-dlp does for a comma-separated --sub-lang
+	binary, _ := fakeBinary(t, `
+while [ $# -gt 0 ]; do
+  case "$1" in
+    -o) out="$2"; shift ;;
+  esac
+  shift
+done
+for lang in de en; do
+  echo "WEBVTT $lang" > "$(echo "$out" | sed "s/%(ext)s/$lang.vtt/")"
+done`)
+	dl := NewYtDlp(Config{BinaryPath: binary})
+
+	subs, err := dl.DownloadSubtitles(context.Background(), "https://youtu.be/abc", []string{"en", "de"})
+	require.NoError(t, err)
+	assert.Equal(t, "en", subs.Language)
+	assert.Equal(t, "WEBVTT en\n", string(subs.Content))
+}
+
+func TestYtDlp_DownloadSubtitles_NoneWritten(t *testing.T) {
+	binary, _ := fakeBinary(t, "exit 0")
+	dl := NewYtDlp(Config{BinaryPath: binary})
+
+	_, err := dl.DownloadSubtitles(context.Background(), "https://youtu.be/abc", []string{"en"})
+	assert.ErrorIs(t, err, ErrNoSubtitles)
+}
+
+func TestYtDlp_ReportsStderr(t *testing.T) {
+	binary, _ := fakeBinary(t, "echo 'ERROR: Sign in to confirm your age' >&2; exit 1")
+	dl := NewYtDlp(Config{BinaryPath: binary})
+
+	_, err := dl.ListSubtitles(context.Background(), "https://youtu.be/abc")
+	require.Error(t, err)
+	assert.Equal(t, "yt-dlp: ERROR: Sign in to confirm your age", err.Error())
+}
+
+func TestYtDlp_Timeout(t *testing.T) {
+	binary, _ := fakeBinary(t, "exec sleep 5")
+	dl := NewYtDlp(Config{BinaryPath: binary, MetadataTimeout: 100 * time.Millisecond})
+
+	start := time.Now()
+	_, err := dl.Metadata(context.Background(), "https://youtu.be/abc")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out")
+	assert.Less(t, time.Since(start), 3*time.Second)
+}
+
+func TestFake(t *testing.T) {
+	fixtures := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(fixtures, "abc.mp3"), []byte("ID3"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(fixtures, "abc.fr.vtt"), []byte("WEBVTT"), 0o644))
+	fake := NewFake(fixtures)
+	ctx := context.Background()
+
+	out := t.TempDir()
+	path, err := fake.DownloadAudio(ctx, "https://www.youtube.com/watch?v=abc", out, "abc")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(out, "abc.mp3"), path)
+
+	subs, err := fake.DownloadSubtitles(ctx, "https://youtu.be/abc", []string{"en", "fr"})
+	require.NoError(t, err)
+	assert.Equal(t, "fr", subs.Language)
+
+	_, err = fake.DownloadSubtitles(ctx, "https://youtu.be/other", []string{"en"})
+	assert.ErrorIs(t, err, ErrNoSubtitles)
+
+	assert.Equal(t, []string{
+		"DownloadAudio https://www.youtube.com/watch?v=abc",
+		"DownloadSubtitles https://youtu.be/abc",
+		"DownloadSubtitles https://youtu.be/other",
+	}, fake.Calls())
+}
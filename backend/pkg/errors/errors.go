@@ -1,6 +1,7 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
 	"net/http"
 )
@@ -81,6 +82,28 @@ const (
 	SubCodeVideoNotFound      SubCode = "VIDEO_NOT_FOUND"
 	SubCodeVideoDuplicate     SubCode = "VIDEO_DUPLICATE"
 	SubCodeVideoStatusInvalid SubCode = "VIDEO_STATUS_INVALID"
+	SubCodeVideoScheduled     SubCode = "VIDEO_SCHEDULED"
+
+	// Playlist subcodes
+	SubCodePlaylistNotFound   SubCode = "PLAYLIST_NOT_FOUND"
+	SubCodePlaylistURLInvalid SubCode = "PLAYLIST_URL_INVALID"
+
+	// Channel subcodes
+	SubCodeChannelSubscriptionNotFound SubCode = "CHANNEL_SUBSCRIPTION_NOT_FOUND"
+	SubCodeChannelURLInvalid           SubCode = "CHANNEL_URL_INVALID"
+	SubCodeChannelAlreadySubscribed    SubCode = "CHANNEL_ALREADY_SUBSCRIBED"
+
+	// Import subcodes
+	SubCodeImportJobNotFound SubCode = "IMPORT_JOB_NOT_FOUND"
+
+	// Podcast subcodes
+	SubCodePodcastFeedInvalid     SubCode = "PODCAST_FEED_INVALID"
+	SubCodePodcastEpisodeNotFound SubCode = "PODCAST_EPISODE_NOT_FOUND"
+
+	// Upload subcodes
+	SubCodeUploadNotFound       SubCode = "UPLOAD_NOT_FOUND"
+	SubCodeUploadOffsetMismatch SubCode = "UPLOAD_OFFSET_MISMATCH"
+	SubCodeUploadNotInProgress  SubCode = "UPLOAD_NOT_IN_PROGRESS"
 
 	// Transcript subcodes
 	SubCodeTranscriptNotFound      SubCode = "TRANSCRIPT_NOT_FOUND"
@@ -89,12 +112,20 @@ const (
 	SubCodeTranscriptFileTooLarge   SubCode = "TRANSCRIPT_FILE_TOO_LARGE"
 	SubCodeTranscriptNoCaptions     SubCode = "TRANSCRIPT_NO_CAPTIONS"
 	SubCodeTranscriptWhisperFailed  SubCode = "TRANSCRIPT_WHISPER_FAILED"
+	SubCodeTranscriptTranslationFailed SubCode = "TRANSCRIPT_TRANSLATION_FAILED"
+	SubCodeTranscriptRevisionNotFound  SubCode = "TRANSCRIPT_REVISION_NOT_FOUND"
+	SubCodeTranscriptRevisionConflict  SubCode = "TRANSCRIPT_REVISION_CONFLICT"
 
 	// Summary subcodes
 	SubCodeSummaryNotFound      SubCode = "SUMMARY_NOT_FOUND"
 	SubCodeSummaryLLMFailed     SubCode = "SUMMARY_LLM_FAILED"
 	SubCodeSummaryNoTranscript  SubCode = "SUMMARY_NO_TRANSCRIPT"
 	SubCodeSummaryInvalidType    SubCode = "SUMMARY_INVALID_TYPE"
+	SubCodeSummaryNoChapters     SubCode = "SUMMARY_NO_CHAPTERS"
+
+	// Comment subcodes
+	SubCodeCommentsDisabled SubCode = "COMMENTS_DISABLED"
+	SubCodeCommentsNotFound SubCode = "COMMENTS_NOT_FOUND"
 
 	// Embedding subcodes
 	SubCodeEmbeddingNotFound      SubCode = "EMBEDDING_NOT_FOUND"
@@ -123,6 +154,8 @@ const (
 	// External service subcodes
 	SubCodeYouTubeAPIFailed      SubCode = "YOUTUBE_API_FAILED"
 	SubCodeYouTubeDownloadFailed SubCode = "YOUTUBE_DOWNLOAD_FAILED"
+	SubCodeYouTubeQuotaExceeded  SubCode = "YOUTUBE_QUOTA_EXCEEDED"
+	SubCodeDownloadDiskQuota     SubCode = "DOWNLOAD_DISK_QUOTA_EXCEEDED"
 	SubCodeLLMAPIFailed          SubCode = "LLM_API_FAILED"
 	SubCodeWhisperAPIFailed       SubCode = "WHISPER_API_FAILED"
 )
@@ -219,7 +252,7 @@ func getHTTPCode(code ErrorCode) int {
 		return http.StatusRequestEntityTooLarge
 	case ErrorCodeTimeout:
 		return http.StatusRequestTimeout
-	case ErrorCodeProviderRateLimit:
+	case ErrorCodeProviderRateLimit, ErrorCodeProviderQuotaExceeded:
 		return http.StatusTooManyRequests
 	default:
 		return http.StatusInternalServerError
@@ -238,6 +271,16 @@ func ErrVideoNotFound(videoID string) *AppError {
 	)
 }
 
+// ErrVideoScheduled returns an error for a premiere or live stream that has not ended yet
+func ErrVideoScheduled(videoID string) *AppError {
+	return NewWithDetail(
+		ErrorCodeConflict,
+		SubCodeVideoScheduled,
+		"Video is an upcoming or live broadcast",
+		fmt.Sprintf("Video %s cannot be processed until the broadcast has ended; it will be analyzed automatically", videoID),
+	)
+}
+
 // ErrVideoInvalidURL returns an invalid video URL error
 func ErrVideoInvalidURL(url string) *AppError {
 	return NewWithDetail(
@@ -248,6 +291,126 @@ func ErrVideoInvalidURL(url string) *AppError {
 	)
 }
 
+// ErrPlaylistNotFound returns a playlist not found error
+func ErrPlaylistNotFound(playlistID string) *AppError {
+	return NewWithDetail(
+		ErrorCodeNotFound,
+		SubCodePlaylistNotFound,
+		"Playlist not found",
+		fmt.Sprintf("Playlist with ID %s does not exist", playlistID),
+	)
+}
+
+// ErrPlaylistInvalidURL returns an invalid playlist URL error
+func ErrPlaylistInvalidURL(url string) *AppError {
+	return NewWithDetail(
+		ErrorCodeBadRequest,
+		SubCodePlaylistURLInvalid,
+		"Invalid playlist URL",
+		fmt.Sprintf("The provided URL '%s' is not a valid YouTube playlist URL or ID", url),
+	)
+}
+
+// ErrChannelSubscriptionNotFound returns a channel subscription not found error
+func ErrChannelSubscriptionNotFound(subscriptionID string) *AppError {
+	return NewWithDetail(
+		ErrorCodeNotFound,
+		SubCodeChannelSubscriptionNotFound,
+		"Channel subscription not found",
+		fmt.Sprintf("Channel subscription with ID %s does not exist", subscriptionID),
+	)
+}
+
+// ErrChannelInvalidURL returns an invalid channel URL error
+func ErrChannelInvalidURL(url string) *AppError {
+	return NewWithDetail(
+		ErrorCodeBadRequest,
+		SubCodeChannelURLInvalid,
+		"Invalid channel URL",
+		fmt.Sprintf("The provided URL '%s' is not a valid YouTube channel URL, ID or handle", url),
+	)
+}
+
+// ErrChannelAlreadySubscribed returns a duplicate channel subscription error
+func ErrChannelAlreadySubscribed(channelID string) *AppError {
+	return NewWithDetail(
+		ErrorCodeConflict,
+		SubCodeChannelAlreadySubscribed,
+		"Channel already subscribed",
+		fmt.Sprintf("A subscription for channel %s already exists", channelID),
+	)
+}
+
+// ErrImportJobNotFound returns an import job not found error
+func ErrImportJobNotFound(jobID string) *AppError {
+	return NewWithDetail(
+		ErrorCodeNotFound,
+		SubCodeImportJobNotFound,
+		"Import job not found",
+		fmt.Sprintf("Import job with ID %s does not exist", jobID),
+	)
+}
+
+// ErrPodcastFeedInvalid returns an error for a feed URL that could not be fetched or parsed
+func ErrPodcastFeedInvalid(url string, err error) *AppError {
+	return NewWithError(
+		ErrorCodeBadRequest,
+		SubCodePodcastFeedInvalid,
+		"Invalid podcast feed",
+		fmt.Errorf("'%s' could not be read as an RSS or Atom feed: %w", url, err),
+	)
+}
+
+// ErrPodcastEpisodeNotFound returns a podcast episode not found error
+func ErrPodcastEpisodeNotFound(episodeID string) *AppError {
+	return NewWithDetail(
+		ErrorCodeNotFound,
+		SubCodePodcastEpisodeNotFound,
+		"Podcast episode not found",
+		fmt.Sprintf("Episode %s is not in the feed", episodeID),
+	)
+}
+
+// ErrUploadNotFound returns an upload not found error
+func ErrUploadNotFound(uploadID string) *AppError {
+	return NewWithDetail(
+		ErrorCodeNotFound,
+		SubCodeUploadNotFound,
+		"Upload not found",
+		fmt.Sprintf("Upload with ID %s does not exist", uploadID),
+	)
+}
+
+// ErrUploadOffsetMismatch returns an error for a chunk that does not continue where the upload left off
+func ErrUploadOffsetMismatch(expected, got int64) *AppError {
+	return NewWithDetail(
+		ErrorCodeConflict,
+		SubCodeUploadOffsetMismatch,
+		"Upload offset mismatch",
+		fmt.Sprintf("Expected chunk at offset %d, got %d", expected, got),
+	)
+}
+
+// ErrYouTubeQuotaExceeded returns an error for a Data API call refused by the daily quota budget
+func ErrYouTubeQuotaExceeded(err error) *AppError {
+	return NewWithError(
+		ErrorCodeProviderQuotaExceeded,
+		SubCodeYouTubeQuotaExceeded,
+		"YouTube API daily quota exceeded",
+		err,
+	)
+}
+
+// ErrDownloadDiskQuota returns an error for a download refused while the download directory is full
+func ErrDownloadDiskQuota(err error) *AppError {
+	return NewWithError(
+		ErrorCodeProviderQuotaExceeded,
+		SubCodeDownloadDiskQuota,
+		"Download disk quota exceeded, try again once running analyses finish",
+		err,
+	)
+}
+
 // ErrTranscriptNotFound returns a transcript not found error
 func ErrTranscriptNotFound(videoID string) *AppError {
 	return NewWithDetail(
@@ -258,6 +421,26 @@ func ErrTranscriptNotFound(videoID string) *AppError {
 	)
 }
 
+// ErrTranscriptRevisionNotFound returns a transcript revision not found error
+func ErrTranscriptRevisionNotFound(transcriptID string, number int) *AppError {
+	return NewWithDetail(
+		ErrorCodeNotFound,
+		SubCodeTranscriptRevisionNotFound,
+		"Transcript revision not found",
+		fmt.Sprintf("Transcript %s has no revision %d", transcriptID, number),
+	)
+}
+
+// ErrTranscriptRevisionConflict returns an error for an edit made against an outdated transcript
+func ErrTranscriptRevisionConflict(transcriptID string) *AppError {
+	return NewWithDetail(
+		ErrorCodeConflict,
+		SubCodeTranscriptRevisionConflict,
+		"Transcript was edited in the meantime",
+		fmt.Sprintf("Transcript %s has a newer revision; reload it and apply the edit again", transcriptID),
+	)
+}
+
 // ErrTranscriptFileTooLarge returns a file too large error
 func ErrTranscriptFileTooLarge(fileSize, maxSize int64) *AppError {
 	return NewWithDetail(
@@ -288,6 +471,36 @@ func ErrSummaryNoTranscript(videoID string) *AppError {
 	)
 }
 
+// ErrSummaryNoChapters returns an error for a chaptered summary of a video without chapters
+func ErrSummaryNoChapters(videoID string) *AppError {
+	return NewWithDetail(
+		ErrorCodeBadRequest,
+		SubCodeSummaryNoChapters,
+		"Video has no chapters",
+		fmt.Sprintf("Video %s has no chapters in its description or metadata. Use another summary type", videoID),
+	)
+}
+
+// ErrCommentsDisabled returns an error for a video whose comments are turned off
+func ErrCommentsDisabled(videoID string) *AppError {
+	return NewWithDetail(
+		ErrorCodeBadRequest,
+		SubCodeCommentsDisabled,
+		"Comments are disabled",
+		fmt.Sprintf("Comments are disabled for video %s", videoID),
+	)
+}
+
+// ErrCommentsNotFound returns an error for a video without any comments
+func ErrCommentsNotFound(videoID string) *AppError {
+	return NewWithDetail(
+		ErrorCodeNotFound,
+		SubCodeCommentsNotFound,
+		"No comments found",
+		fmt.Sprintf("Video %s has no comments yet", videoID),
+	)
+}
+
 // ErrProviderFileTooLarge returns a provider file too large error
 func ErrProviderFileTooLarge(provider string, fileSizeMB, maxSizeMB float64) *AppError {
 	return NewWithDetail(
@@ -318,6 +531,16 @@ func ErrInternalError(message string, err error) *AppError {
 	)
 }
 
+// Is reports whether any error in err's chain matches target, like the standard errors.Is
+func Is(err, target error) bool {
+	return stderrors.Is(err, target)
+}
+
+// As finds the first error in err's chain that matches target, like the standard errors.As
+func As(err error, target any) bool {
+	return stderrors.As(err, target)
+}
+
 // IsNotFound checks if an error is a not found error
 func IsNotFound(err error) bool {
 	if err == nil {
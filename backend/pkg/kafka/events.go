@@ -68,12 +68,13 @@ func NewTranscriptRequestedEvent(videoID, youtubeID string, priority int) *Trans
 // EmbeddingRequestedEvent is published when embedding generation is requested
 type EmbeddingRequestedEvent struct {
 	Event
+	TranscriptID      string `json:"transcript_id,omitempty"`
 	TranscriptContent string `json:"transcript_content,omitempty"`
 	Priority          int    `json:"priority"`
 }
 
 // NewEmbeddingRequestedEvent creates a new EmbeddingRequestedEvent
-func NewEmbeddingRequestedEvent(videoID, youtubeID, transcriptContent string, priority int) *EmbeddingRequestedEvent {
+func NewEmbeddingRequestedEvent(videoID, youtubeID, transcriptID, transcriptContent string, priority int) *EmbeddingRequestedEvent {
 	return &EmbeddingRequestedEvent{
 		Event: Event{
 			EventID:   uuid.New().String(),
@@ -82,6 +83,7 @@ func NewEmbeddingRequestedEvent(videoID, youtubeID, transcriptContent string, pr
 			VideoID:   videoID,
 			YouTubeID: youtubeID,
 		},
+		TranscriptID:      transcriptID,
 		TranscriptContent: transcriptContent,
 		Priority:          priority,
 	}
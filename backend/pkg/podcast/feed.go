@@ -0,0 +1,415 @@
+package podcast
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const nsAtom = "http://www.w3.org/2005/Atom"
+
+// maxFeedSize limits how much of a feed document is read (large back catalogues run to a few MB)
+const maxFeedSize = 20 << 20
+
+type Client struct {
+	client *http.Client
+}
+
+// Feed is a parsed RSS 2.0 or Atom podcast feed
+type Feed struct {
+	URL      string
+	Title    string
+	Author   string
+	ImageURL string
+	Episodes []Episode
+}
+
+// Episode is a single feed item with an audio enclosure
+type Episode struct {
+	GUID        string
+	Title       string
+	Description string
+	PublishedAt time.Time
+	Duration    int // seconds, from itunes:duration when present
+	AudioURL    string
+	AudioType   string
+	AudioSize   int64
+	ImageURL    string
+	Transcripts []Transcript
+}
+
+// Transcript is a podcast:transcript link published alongside an episode
+type Transcript struct {
+	URL      string
+	Type     string // MIME type, e.g. text/vtt
+	Language string
+}
+
+func NewClient() *Client {
+	return &Client{
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// GetFeed downloads and parses the feed at feedURL
+func (c *Client) GetFeed(ctx context.Context, feedURL string) (*Feed, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", feedURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid feed URL: %w", err)
+	}
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("feed request failed: %s", resp.Status)
+	}
+
+	feed, err := ParseFeed(io.LimitReader(resp.Body, maxFeedSize))
+	if err != nil {
+		return nil, err
+	}
+	feed.URL = feedURL
+	return feed, nil
+}
+
+// Download streams url into w, e.g. an episode enclosure or a transcript file
+func (c *Client) Download(ctx context.Context, url string, w io.Writer) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// Enclosures can be large; rely on ctx rather than the client timeout
+	client := &http.Client{Transport: c.client.Transport}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("download failed: %s", resp.Status)
+	}
+
+	return io.Copy(w, resp.Body)
+}
+
+// FindEpisode returns the episode whose GUID or audio URL equals id
+func (f *Feed) FindEpisode(id string) (*Episode, bool) {
+	for i := range f.Episodes {
+		if f.Episodes[i].GUID == id || f.Episodes[i].AudioURL == id {
+			return &f.Episodes[i], true
+		}
+	}
+	return nil, false
+}
+
+// Latest returns the most recently published episode
+func (f *Feed) Latest() (*Episode, bool) {
+	var latest *Episode
+	for i := range f.Episodes {
+		if latest == nil || f.Episodes[i].PublishedAt.After(latest.PublishedAt) {
+			latest = &f.Episodes[i]
+		}
+	}
+	return latest, latest != nil
+}
+
+type rssDocument struct {
+	XMLName xml.Name `xml:"rss"`
+	Channel struct {
+		Title       string `xml:"title"`
+		Author      string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd author"`
+		ITunesImage struct {
+			Href string `xml:"href,attr"`
+		} `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+		Image struct {
+			URL string `xml:"url"`
+		} `xml:"image"`
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type rssItem struct {
+	Title       string `xml:"title"`
+	Description string `xml:"description"`
+	Summary     string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd summary"`
+	GUID        string `xml:"guid"`
+	Link        string `xml:"link"`
+	PubDate     string `xml:"pubDate"`
+	Duration    string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration"`
+	Enclosure   struct {
+		URL    string `xml:"url,attr"`
+		Type   string `xml:"type,attr"`
+		Length string `xml:"length,attr"`
+	} `xml:"enclosure"`
+	MediaContent []struct {
+		URL      string `xml:"url,attr"`
+		Type     string `xml:"type,attr"`
+		FileSize string `xml:"fileSize,attr"`
+	} `xml:"http://search.yahoo.com/mrss/ content"`
+	Image struct {
+		Href string `xml:"href,attr"`
+	} `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+	Transcripts []xmlTranscript `xml:"https://podcastindex.org/namespace/1.0 transcript"`
+}
+
+type atomDocument struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"http://www.w3.org/2005/Atom title"`
+	Author  atomAuthor  `xml:"http://www.w3.org/2005/Atom author"`
+	Logo    string      `xml:"http://www.w3.org/2005/Atom logo"`
+	Icon    string      `xml:"http://www.w3.org/2005/Atom icon"`
+	Entries []atomEntry `xml:"http://www.w3.org/2005/Atom entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"http://www.w3.org/2005/Atom name"`
+}
+
+type atomEntry struct {
+	ID        string `xml:"http://www.w3.org/2005/Atom id"`
+	Title     string `xml:"http://www.w3.org/2005/Atom title"`
+	Summary   string `xml:"http://www.w3.org/2005/Atom summary"`
+	Content   string `xml:"http://www.w3.org/2005/Atom content"`
+	Published string `xml:"http://www.w3.org/2005/Atom published"`
+	Updated   string `xml:"http://www.w3.org/2005/Atom updated"`
+	Duration  string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration"`
+	Links     []struct {
+		Rel    string `xml:"rel,attr"`
+		Href   string `xml:"href,attr"`
+		Type   string `xml:"type,attr"`
+		Length string `xml:"length,attr"`
+	} `xml:"http://www.w3.org/2005/Atom link"`
+	Transcripts []xmlTranscript `xml:"https://podcastindex.org/namespace/1.0 transcript"`
+}
+
+type xmlTranscript struct {
+	URL      string `xml:"url,attr"`
+	Type     string `xml:"type,attr"`
+	Language string `xml:"language,attr"`
+}
+
+// ParseFeed parses an RSS 2.0 (with iTunes and Podcasting 2.0 extensions) or Atom feed.
+// Items without an audio or video enclosure are skipped.
+func ParseFeed(r io.Reader) (*Feed, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feed: %w", err)
+	}
+
+	root, err := rootElement(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case root.Local == "rss":
+		return parseRSS(data)
+	case root.Local == "feed" && root.Space == nsAtom:
+		return parseAtom(data)
+	default:
+		return nil, fmt.Errorf("unsupported feed format: <%s>", root.Local)
+	}
+}
+
+func rootElement(data []byte) (xml.Name, error) {
+	decoder := newDecoder(data)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return xml.Name{}, fmt.Errorf("failed to parse feed: %w", err)
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name, nil
+		}
+	}
+}
+
+func parseRSS(data []byte) (*Feed, error) {
+	var doc rssDocument
+	if err := unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse RSS feed: %w", err)
+	}
+
+	feed := &Feed{
+		Title:    strings.TrimSpace(doc.Channel.Title),
+		Author:   strings.TrimSpace(doc.Channel.Author),
+		ImageURL: firstNonEmpty(doc.Channel.ITunesImage.Href, doc.Channel.Image.URL),
+	}
+
+	for _, item := range doc.Channel.Items {
+		episode := Episode{
+			GUID:        strings.TrimSpace(item.GUID),
+			Title:       strings.TrimSpace(item.Title),
+			Description: strings.TrimSpace(firstNonEmpty(item.Description, item.Summary)),
+			PublishedAt: parseDate(item.PubDate),
+			Duration:    parseDuration(item.Duration),
+			AudioURL:    strings.TrimSpace(item.Enclosure.URL),
+			AudioType:   item.Enclosure.Type,
+			AudioSize:   parseInt64(item.Enclosure.Length),
+			ImageURL:    firstNonEmpty(item.Image.Href, feed.ImageURL),
+			Transcripts: convertTranscripts(item.Transcripts),
+		}
+		if episode.AudioURL == "" {
+			for _, content := range item.MediaContent {
+				if isMediaType(content.Type) {
+					episode.AudioURL = content.URL
+					episode.AudioType = content.Type
+					episode.AudioSize = parseInt64(content.FileSize)
+					break
+				}
+			}
+		}
+		if episode.AudioURL == "" {
+			continue
+		}
+		if episode.GUID == "" {
+			episode.GUID = firstNonEmpty(strings.TrimSpace(item.Link), episode.AudioURL)
+		}
+		feed.Episodes = append(feed.Episodes, episode)
+	}
+
+	return feed, nil
+}
+
+func parseAtom(data []byte) (*Feed, error) {
+	var doc atomDocument
+	if err := unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse Atom feed: %w", err)
+	}
+
+	feed := &Feed{
+		Title:    strings.TrimSpace(doc.Title),
+		Author:   strings.TrimSpace(doc.Author.Name),
+		ImageURL: firstNonEmpty(doc.Logo, doc.Icon),
+	}
+
+	for _, entry := range doc.Entries {
+		episode := Episode{
+			GUID:        strings.TrimSpace(entry.ID),
+			Title:       strings.TrimSpace(entry.Title),
+			Description: strings.TrimSpace(firstNonEmpty(entry.Summary, entry.Content)),
+			PublishedAt: parseDate(firstNonEmpty(entry.Published, entry.Updated)),
+			Duration:    parseDuration(entry.Duration),
+			ImageURL:    feed.ImageURL,
+			Transcripts: convertTranscripts(entry.Transcripts),
+		}
+		for _, link := range entry.Links {
+			if link.Rel == "enclosure" {
+				episode.AudioURL = strings.TrimSpace(link.Href)
+				episode.AudioType = link.Type
+				episode.AudioSize = parseInt64(link.Length)
+				break
+			}
+		}
+		if episode.AudioURL == "" {
+			continue
+		}
+		if episode.GUID == "" {
+			episode.GUID = episode.AudioURL
+		}
+		feed.Episodes = append(feed.Episodes, episode)
+	}
+
+	return feed, nil
+}
+
+func unmarshal(data []byte, v interface{}) error {
+	return newDecoder(data).Decode(v)
+}
+
+func newDecoder(data []byte) *xml.Decoder {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	// Feeds in the wild often contain HTML entities and other non-strict XML
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+	// Non-UTF-8 feeds are decoded as-is; most are ASCII-compatible
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+	return decoder
+}
+
+func convertTranscripts(in []xmlTranscript) []Transcript {
+	var out []Transcript
+	for _, t := range in {
+		if t.URL == "" {
+			continue
+		}
+		out = append(out, Transcript{URL: t.URL, Type: strings.ToLower(t.Type), Language: t.Language})
+	}
+	return out
+}
+
+// dateLayouts covers RFC 822/1123 pubDate variants seen in podcast feeds and RFC 3339 Atom dates
+var dateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"Mon, 02 Jan 2006 15:04 -0700",
+	time.RFC3339,
+	"2006-01-02",
+}
+
+func parseDate(value string) time.Time {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// parseDuration converts itunes:duration ("3600", "59:30" or "1:02:03") to seconds
+func parseDuration(value string) int {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	var total int
+	for _, part := range strings.Split(value, ":") {
+		n, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return 0
+		}
+		total = total*60 + int(n)
+	}
+	return total
+}
+
+func parseInt64(value string) int64 {
+	n, _ := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	return n
+}
+
+func isMediaType(mimeType string) bool {
+	return strings.HasPrefix(mimeType, "audio/") || strings.HasPrefix(mimeType, "video/")
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
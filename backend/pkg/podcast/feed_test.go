@@ -0,0 +1,142 @@
+package podcast
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const rssFixture = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd" xmlns:podcast="https://podcastindex.org/namespace/1.0">
+  <channel>
+    <title>Go Time</title>
+    <itunes:author>Changelog Media</itunes:author>
+    <itunes:image href="https://example.com/cover.jpg"/>
+    <item>
+      <title>Episode 2: Generics</title>
+      <description>Talking about generics &amp; more</description>
+      <guid isPermaLink="false">ep-2</guid>
+      <pubDate>Tue, 14 May 2024 10:00:00 +0000</pubDate>
+      <itunes:duration>1:02:03</itunes:duration>
+      <enclosure url="https://cdn.example.com/ep2.mp3" type="audio/mpeg" length="123456"/>
+      <podcast:transcript url="https://cdn.example.com/ep2.vtt" type="text/vtt" language="en"/>
+    </item>
+    <item>
+      <title>Episode 1: Hello</title>
+      <guid>ep-1</guid>
+      <pubDate>Mon, 6 May 2024 10:00:00 GMT</pubDate>
+      <itunes:duration>3600</itunes:duration>
+      <itunes:image href="https://example.com/ep1.jpg"/>
+      <enclosure url="https://cdn.example.com/ep1.mp3" type="audio/mpeg" length="654321"/>
+    </item>
+    <item>
+      <title>Show notes only</title>
+      <guid>notes</guid>
+    </item>
+  </channel>
+</rss>`
+
+const atomFixture = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Atom Cast</title>
+  <author><name>Jane</name></author>
+  <logo>https://example.com/logo.png</logo>
+  <entry>
+    <id>urn:uuid:1225c695</id>
+    <title>First</title>
+    <summary>Intro episode</summary>
+    <published>2024-03-01T08:00:00Z</published>
+    <link rel="alternate" href="https://example.com/first"/>
+    <link rel="enclosure" href="https://example.com/first.m4a" type="audio/mp4" length="42"/>
+  </entry>
+</feed>`
+
+func TestParseFeed_RSS(t *testing.T) {
+	feed, err := ParseFeed(strings.NewReader(rssFixture))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Go Time", feed.Title)
+	assert.Equal(t, "Changelog Media", feed.Author)
+	assert.Equal(t, "https://example.com/cover.jpg", feed.ImageURL)
+	require.Len(t, feed.Episodes, 2, "items without an enclosure are skipped")
+
+	ep := feed.Episodes[0]
+	assert.Equal(t, "ep-2", ep.GUID)
+	assert.Equal(t, "Talking about generics & more", ep.Description)
+	assert.Equal(t, 3723, ep.Duration)
+	assert.Equal(t, "https://cdn.example.com/ep2.mp3", ep.AudioURL)
+	assert.Equal(t, int64(123456), ep.AudioSize)
+	assert.Equal(t, time.Date(2024, 5, 14, 10, 0, 0, 0, time.UTC), ep.PublishedAt.UTC())
+	assert.Equal(t, "https://example.com/cover.jpg", ep.ImageURL)
+	assert.Equal(t, []Transcript{{URL: "https://cdn.example.com/ep2.vtt", Type: "text/vtt", Language: "en"}}, ep.Transcripts)
+
+	assert.Equal(t, 3600, feed.Episodes[1].Duration)
+	assert.Equal(t, "https://example.com/ep1.jpg", feed.Episodes[1].ImageURL)
+
+	latest, ok := feed.Latest()
+	require.True(t, ok)
+	assert.Equal(t, "ep-2", latest.GUID)
+
+	found, ok := feed.FindEpisode("https://cdn.example.com/ep1.mp3")
+	require.True(t, ok)
+	assert.Equal(t, "ep-1", found.GUID)
+}
+
+func TestParseFeed_Atom(t *testing.T) {
+	feed, err := ParseFeed(strings.NewReader(atomFixture))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Atom Cast", feed.Title)
+	assert.Equal(t, "Jane", feed.Author)
+	require.Len(t, feed.Episodes, 1)
+	assert.Equal(t, "urn:uuid:1225c695", feed.Episodes[0].GUID)
+	assert.Equal(t, "https://example.com/first.m4a", feed.Episodes[0].AudioURL)
+	assert.Equal(t, "Intro episode", feed.Episodes[0].Description)
+	assert.Equal(t, 2024, feed.Episodes[0].PublishedAt.Year())
+}
+
+func TestParseFeed_Unsupported(t *testing.T) {
+	_, err := ParseFeed(strings.NewReader(`<html><body>not a feed</body></html>`))
+	assert.Error(t, err)
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]int{
+		"":        0,
+		"90":      90,
+		"59:30":   3570,
+		"1:02:03": 3723,
+		"1:xx":    0,
+	}
+	for input, expected := range tests {
+		assert.Equal(t, expected, parseDuration(input), input)
+	}
+}
+
+func TestClient_GetFeed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/feed.xml" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(rssFixture))
+	}))
+	defer server.Close()
+
+	client := NewClient()
+
+	feed, err := client.GetFeed(context.Background(), server.URL+"/feed.xml")
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/feed.xml", feed.URL)
+	assert.Len(t, feed.Episodes, 2)
+
+	_, err = client.GetFeed(context.Background(), server.URL+"/missing.xml")
+	assert.Error(t, err)
+}
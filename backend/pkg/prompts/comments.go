@@ -0,0 +1,35 @@
+package prompts
+
+// GetCommentSummaryPrompt returns the prompt for the audience-reaction summary of a video.
+// The model answers with JSON so the clusters can be stored and displayed separately.
+func GetCommentSummaryPrompt(language string) string {
+	languageInstruction := getLanguageInstruction(language)
+	return `You are an audience research analyst. Below are the top viewer comments of a video, most liked first, formatted as "[likes] comment".
+
+**Video:** {{.Title}}
+
+**Comments:**
+{{.Comments}}
+
+**Instructions:**
+- Group comments that make the same point into one theme and count how many comments belong to it
+- Sort themes in each category by comment count, largest first, and keep at most 5 per category
+- questions: what viewers ask or are confused about
+- corrections: factual errors or mistakes viewers point out in the video
+- praise: what viewers liked
+- complaints: what viewers disliked or criticized
+- overview: 2-3 sentences on the overall audience reaction
+- Ignore spam, self-promotion and comments unrelated to the video
+- Leave a category empty when no comment fits it
+
+**Required Format:** respond with only this JSON object, no Markdown code fences:
+{
+  "overview": "...",
+  "questions": [{"summary": "...", "comment_count": 3, "example": "a representative comment"}],
+  "corrections": [],
+  "praise": [],
+  "complaints": []
+}
+
+` + languageInstruction + ` Keep the JSON keys in English.`
+}
@@ -128,36 +128,39 @@ func getBulletPointsPrompt(language string) string {
 ` + languageInstruction
 }
 
+// GetChapterSummaryPrompt returns the prompt for summarizing one chapter of a chaptered summary.
+// The answer is placed under a heading for the chapter, so it must not add headings of its own.
+func GetChapterSummaryPrompt(language string) string {
+	languageInstruction := getLanguageInstruction(language)
+	return `You are a professional content analyst. Summarize one chapter of a longer video.
+
+**Chapter:** {{.Title}}
+
+**Chapter Transcript:**
+{{.Transcript}}
+
+**Instructions:**
+- Write one short paragraph (2-4 sentences) describing what this chapter covers
+- Follow it with 2-4 key points as bullet points
+- Only use information from this chapter's transcript
+- Do not add headings, a title, or an introduction; the chapter title is shown separately
+
+**Required Format (use Markdown):**
+[Paragraph]
+
+- [Key point]
+- [Key point]
+
+` + languageInstruction
+}
+
 // getLanguageInstruction returns the language instruction based on the language setting
 func getLanguageInstruction(language string) string {
 	if language == "" || language == "auto" {
 		return "Provide your response in the same language as the transcript."
 	}
 	
-	// Map common language codes to language names
-	languageMap := map[string]string{
-		"en": "English",
-		"tr": "Turkish",
-		"es": "Spanish",
-		"fr": "French",
-		"de": "German",
-		"it": "Italian",
-		"pt": "Portuguese",
-		"ru": "Russian",
-		"ja": "Japanese",
-		"ko": "Korean",
-		"zh": "Chinese",
-		"ar": "Arabic",
-		"hi": "Hindi",
-		"nl": "Dutch",
-		"pl": "Polish",
-		"sv": "Swedish",
-		"da": "Danish",
-		"no": "Norwegian",
-		"fi": "Finnish",
-	}
-	
-	langName, ok := languageMap[strings.ToLower(language)]
+	langName, ok := languageNames[strings.ToLower(language)]
 	if ok {
 		return fmt.Sprintf("Provide your response in %s.", langName)
 	}
@@ -166,6 +169,48 @@ func getLanguageInstruction(language string) string {
 	return fmt.Sprintf("Provide your response in %s.", language)
 }
 
+// languageNames maps common language codes to language names
+var languageNames = map[string]string{
+	"en": "English",
+	"tr": "Turkish",
+	"es": "Spanish",
+	"fr": "French",
+	"de": "German",
+	"it": "Italian",
+	"pt": "Portuguese",
+	"ru": "Russian",
+	"ja": "Japanese",
+	"ko": "Korean",
+	"zh": "Chinese",
+	"ar": "Arabic",
+	"hi": "Hindi",
+	"nl": "Dutch",
+	"pl": "Polish",
+	"sv": "Swedish",
+	"da": "Danish",
+	"no": "Norwegian",
+	"fi": "Finnish",
+}
+
+// LanguageName returns the name of a language code, or the code itself when unknown
+func LanguageName(code string) string {
+	if name, ok := languageNames[strings.ToLower(code)]; ok {
+		return name
+	}
+	return code
+}
+
+// speakerInstruction asks for attribution when the transcript is written as speaker turns
+const speakerInstruction = `**Speakers:** The transcript is divided into turns, each starting with the speaker's name followed by a colon. Several people are talking, so:
+- Attribute claims, opinions, and quotes to the speaker who made them (e.g., "Alice argues that...")
+- Note where speakers agree or disagree
+- Do not present one speaker's view as the consensus of the video`
+
+// WithSpeakerAttribution extends a summary prompt for a diarized transcript
+func WithSpeakerAttribution(prompt string) string {
+	return prompt + "\n\n" + speakerInstruction
+}
+
 func GetSummaryPrompt(summaryType string, language string) string {
 	switch summaryType {
 	case "detailed":
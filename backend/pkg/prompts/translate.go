@@ -0,0 +1,24 @@
+package prompts
+
+import "fmt"
+
+// GetSegmentTranslationPrompt returns the prompt translating a batch of transcript segments
+// into language. Segments are listed as "[id] text" and translated one by one, so every
+// translation keeps the timing of its segment.
+func GetSegmentTranslationPrompt(language string) string {
+	name := LanguageName(language)
+	return fmt.Sprintf(`You are translating the subtitles of a video into %[1]s. Below are consecutive transcript segments, formatted as "[id] text".
+
+**Segments:**
+{{.Segments}}
+
+**Instructions:**
+- Translate every segment into natural, fluent %[1]s, one translation per segment
+- Never merge, split, reorder or skip segments, even when a sentence continues in the next segment
+- Keep names, technical terms and numbers as they are
+- Keep each translation about as long as the original so it fits the same time on screen
+- A segment that is only music, noise or a sound description is translated as well
+
+**Required Format:** respond with only this JSON object, no Markdown code fences:
+{"segments": [{"id": 0, "text": "..."}]}`, name)
+}
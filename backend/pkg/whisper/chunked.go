@@ -0,0 +1,178 @@
+package whisper
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"youtube-video-summarizer/backend/pkg/audio"
+)
+
+// DefaultChunkParallelism is the number of chunks transcribed at once when not configured
+const DefaultChunkParallelism = 3
+
+// Splitter cuts a recording into chunks of at most maxBytes each, written to dir
+type Splitter interface {
+	Split(ctx context.Context, path, dir string, maxBytes int64) ([]audio.Chunk, error)
+}
+
+type ChunkerConfig struct {
+	// MaxChunkBytes splits recordings above this size for every provider. 0 only splits for
+	// providers with an upload limit.
+	MaxChunkBytes int64
+	Parallelism   int
+}
+
+// Chunker transcribes recordings too large for a provider by splitting them, transcribing
+// the chunks in parallel and merging the results back onto the original timeline
+type Chunker struct {
+	splitter Splitter
+	cfg      ChunkerConfig
+}
+
+func NewChunker(splitter Splitter, cfg ChunkerConfig) *Chunker {
+	if cfg.Parallelism <= 0 {
+		cfg.Parallelism = DefaultChunkParallelism
+	}
+	return &Chunker{splitter: splitter, cfg: cfg}
+}
+
+// Transcribe transcribes req with provider, in chunks when req.AudioPath exceeds the chunk
+// size for that provider
+func (c *Chunker) Transcribe(ctx context.Context, provider WhisperProvider, req TranscribeRequest) (*TranscribeResponse, error) {
+	limit := c.limit(provider)
+	if limit <= 0 || req.AudioPath == "" {
+		return provider.Transcribe(ctx, req)
+	}
+	info, err := os.Stat(req.AudioPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audio file info: %w", err)
+	}
+	if info.Size() <= limit {
+		return provider.Transcribe(ctx, req)
+	}
+
+	dir, err := os.MkdirTemp("", "whisper-chunks-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chunk directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	chunks, err := c.splitter.Split(ctx, req.AudioPath, dir, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to split audio: %w", err)
+	}
+
+	responses, err := c.transcribeChunks(ctx, provider, req, chunks)
+	if err != nil {
+		return nil, err
+	}
+	return MergeChunks(chunks, responses), nil
+}
+
+// limit returns the chunk size for provider: the smaller of its upload limit and the
+// configured size, or 0 when neither applies
+func (c *Chunker) limit(provider WhisperProvider) int64 {
+	limit := provider.GetModelInfo().MaxFileSize
+	if c.cfg.MaxChunkBytes > 0 && (limit <= 0 || c.cfg.MaxChunkBytes < limit) {
+		limit = c.cfg.MaxChunkBytes
+	}
+	return limit
+}
+
+// transcribeChunks transcribes up to cfg.Parallelism chunks at a time, stopping at the first
+// failure
+func (c *Chunker) transcribeChunks(ctx context.Context, provider WhisperProvider, req TranscribeRequest, chunks []audio.Chunk) ([]*TranscribeResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	responses := make([]*TranscribeResponse, len(chunks))
+	sem := make(chan struct{}, c.cfg.Parallelism)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+
+	for i, chunk := range chunks {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, chunk audio.Chunk) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			chunkReq := req
+			chunkReq.AudioPath = chunk.Path
+			chunkReq.AudioData = nil
+			resp, err := provider.Transcribe(ctx, chunkReq)
+			if err != nil {
+				once.Do(func() {
+					firstErr = fmt.Errorf("chunk %d of %d (at %.0fs): %w", i+1, len(chunks), chunk.Offset, err)
+					cancel()
+				})
+				return
+			}
+			responses[i] = resp
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return responses, nil
+}
+
+// MergeChunks joins the transcriptions of consecutive chunks, shifting every segment by its
+// chunk's offset and numbering segments across the whole recording
+func MergeChunks(chunks []audio.Chunk, responses []*TranscribeResponse) *TranscribeResponse {
+	merged := &TranscribeResponse{}
+	var texts []string
+	for i, resp := range responses {
+		offset := chunks[i].Offset
+		if merged.Language == "" {
+			merged.Language = resp.Language
+		}
+		if text := strings.TrimSpace(resp.Text); text != "" {
+			texts = append(texts, text)
+		}
+		for _, seg := range resp.Segments {
+			speaker := seg.Speaker
+			if speaker != "" && len(responses) > 1 {
+				// Each chunk is diarized on its own, so SPEAKER_00 of one chunk need not be
+				// SPEAKER_00 of the next
+				speaker = fmt.Sprintf("%s (part %d)", speaker, i+1)
+			}
+			var words []Word
+			for _, w := range seg.Words {
+				words = append(words, Word{Start: w.Start + offset, End: w.End + offset, Text: w.Text})
+			}
+			merged.Segments = append(merged.Segments, TranscriptSegment{
+				ID:      len(merged.Segments),
+				Start:   seg.Start + offset,
+				End:     seg.End + offset,
+				Text:    seg.Text,
+				Speaker: speaker,
+				Words:   words,
+			})
+		}
+
+		duration := resp.Duration
+		if duration <= 0 {
+			duration = chunks[i].Duration
+		}
+		merged.Duration = offset + duration
+	}
+	merged.Text = strings.Join(texts, " ")
+	return merged
+}
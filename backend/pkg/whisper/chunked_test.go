@@ -0,0 +1,186 @@
+package whisper
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"youtube-video-summarizer/backend/pkg/audio"
+)
+
+// fakeSplitter cuts every recording into chunks of the given durations
+type fakeSplitter struct {
+	durations []float64
+	maxBytes  int64
+}
+
+func (f *fakeSplitter) Split(ctx context.Context, path, dir string, maxBytes int64) ([]audio.Chunk, error) {
+	f.maxBytes = maxBytes
+	var chunks []audio.Chunk
+	offset := 0.0
+	for i, d := range f.durations {
+		chunkPath := filepath.Join(dir, filepath.Base(path)+"."+strconv.Itoa(i))
+		if err := os.WriteFile(chunkPath, []byte("chunk"), 0o644); err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, audio.Chunk{Path: chunkPath, Offset: offset, Duration: d})
+		offset += d
+	}
+	return chunks, nil
+}
+
+// fakeProvider returns two segments per file and records how many calls run at once
+type fakeProvider struct {
+	maxFileSize int64
+	failOn      string
+
+	mu      sync.Mutex
+	paths   []string
+	running atomic.Int32
+	peak    atomic.Int32
+}
+
+func (f *fakeProvider) Transcribe(ctx context.Context, req TranscribeRequest) (*TranscribeResponse, error) {
+	n := f.running.Add(1)
+	defer f.running.Add(-1)
+	for {
+		peak := f.peak.Load()
+		if n <= peak || f.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	f.mu.Lock()
+	f.paths = append(f.paths, req.AudioPath)
+	f.mu.Unlock()
+
+	name := filepath.Base(req.AudioPath)
+	if name == f.failOn {
+		return nil, errors.New("rate limited")
+	}
+	return &TranscribeResponse{
+		Text:     "text of " + name,
+		Language: "en",
+		Duration: 20,
+		Segments: []TranscriptSegment{
+			{ID: 0, Start: 0, End: 8, Text: "first"},
+			{ID: 1, Start: 8, End: 19.5, Text: "second"},
+		},
+	}, nil
+}
+
+func (f *fakeProvider) GetSupportedLanguages() []string { return []string{"en"} }
+
+func (f *fakeProvider) GetModelInfo() ModelInfo {
+	return ModelInfo{Name: "fake", Provider: "fake", MaxFileSize: f.maxFileSize}
+}
+
+func writeRecording(t *testing.T, size int) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "lecture.mp3")
+	require.NoError(t, os.WriteFile(path, make([]byte, size), 0o644))
+	return path
+}
+
+func TestChunker_SplitsAndMerges(t *testing.T) {
+	splitter := &fakeSplitter{durations: []float64{20, 20, 20, 15}}
+	provider := &fakeProvider{maxFileSize: 100}
+	chunker := NewChunker(splitter, ChunkerConfig{Parallelism: 2})
+
+	resp, err := chunker.Transcribe(context.Background(), provider, TranscribeRequest{
+		AudioPath: writeRecording(t, 250),
+		Task:      "transcribe",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(100), splitter.maxBytes)
+	assert.Len(t, provider.paths, 4)
+	assert.LessOrEqual(t, provider.peak.Load(), int32(2), "parallelism is bounded")
+
+	require.Len(t, resp.Segments, 8)
+	for i, seg := range resp.Segments {
+		assert.Equal(t, i, seg.ID)
+	}
+	assert.Equal(t, TranscriptSegment{ID: 5, Start: 48, End: 59.5, Text: "second"}, resp.Segments[5])
+	assert.Equal(t, 80.0, resp.Duration)
+	assert.Equal(t, "en", resp.Language)
+	assert.Equal(t, "text of lecture.mp3.0 text of lecture.mp3.1 text of lecture.mp3.2 text of lecture.mp3.3", resp.Text)
+}
+
+func TestChunker_SmallFilesPassThrough(t *testing.T) {
+	provider := &fakeProvider{maxFileSize: 100}
+	chunker := NewChunker(&fakeSplitter{}, ChunkerConfig{})
+
+	path := writeRecording(t, 100)
+	_, err := chunker.Transcribe(context.Background(), provider, TranscribeRequest{AudioPath: path})
+	require.NoError(t, err)
+	assert.Equal(t, []string{path}, provider.paths)
+
+	// No limit at all: never split
+	unlimited := &fakeProvider{}
+	path = writeRecording(t, 10000)
+	_, err = chunker.Transcribe(context.Background(), unlimited, TranscribeRequest{AudioPath: path})
+	require.NoError(t, err)
+	assert.Equal(t, []string{path}, unlimited.paths)
+}
+
+func TestChunker_ConfiguredLimitAppliesToEveryProvider(t *testing.T) {
+	splitter := &fakeSplitter{durations: []float64{30, 30}}
+	chunker := NewChunker(splitter, ChunkerConfig{MaxChunkBytes: 40})
+
+	_, err := chunker.Transcribe(context.Background(), &fakeProvider{}, TranscribeRequest{AudioPath: writeRecording(t, 60)})
+	require.NoError(t, err)
+	assert.Equal(t, int64(40), splitter.maxBytes)
+
+	_, err = chunker.Transcribe(context.Background(), &fakeProvider{maxFileSize: 30}, TranscribeRequest{AudioPath: writeRecording(t, 60)})
+	require.NoError(t, err)
+	assert.Equal(t, int64(30), splitter.maxBytes, "the provider's stricter limit wins")
+}
+
+func TestChunker_ChunkFailure(t *testing.T) {
+	splitter := &fakeSplitter{durations: []float64{20, 20, 20}}
+	provider := &fakeProvider{maxFileSize: 10, failOn: "lecture.mp3.1"}
+	chunker := NewChunker(splitter, ChunkerConfig{Parallelism: 1})
+
+	_, err := chunker.Transcribe(context.Background(), provider, TranscribeRequest{AudioPath: writeRecording(t, 50)})
+	assert.ErrorContains(t, err, "chunk 2 of 3 (at 20s): rate limited")
+	assert.Len(t, provider.paths, 2, "no further chunks are started after a failure")
+}
+
+func TestMergeChunks_QualifiesSpeakersPerChunk(t *testing.T) {
+	chunks := []audio.Chunk{{Offset: 0, Duration: 10}, {Offset: 10, Duration: 10}}
+	responses := []*TranscribeResponse{
+		{Text: "hi", Segments: []TranscriptSegment{{Start: 0, End: 4, Text: "hi", Speaker: "SPEAKER_00"}}},
+		{Text: "hello", Segments: []TranscriptSegment{{Start: 1, End: 3, Text: "hello", Speaker: "SPEAKER_00"}}},
+	}
+
+	merged := MergeChunks(chunks, responses)
+	require.Len(t, merged.Segments, 2)
+	assert.Equal(t, "SPEAKER_00 (part 1)", merged.Segments[0].Speaker)
+	assert.Equal(t, "SPEAKER_00 (part 2)", merged.Segments[1].Speaker)
+
+	single := MergeChunks(chunks[:1], responses[:1])
+	assert.Equal(t, "SPEAKER_00", single.Segments[0].Speaker, "one chunk keeps the provider's labels")
+}
+
+func TestMergeChunks_ShiftsWords(t *testing.T) {
+	chunks := []audio.Chunk{{Offset: 0, Duration: 10}, {Offset: 10, Duration: 10}}
+	responses := []*TranscribeResponse{
+		{Segments: []TranscriptSegment{{Start: 0, End: 1, Text: "one", Words: []Word{{Start: 0.2, End: 0.8, Text: "one"}}}}},
+		{Segments: []TranscriptSegment{{Start: 2, End: 3, Text: "two", Words: []Word{{Start: 2.1, End: 2.9, Text: "two"}}}}},
+	}
+
+	merged := MergeChunks(chunks, responses)
+	require.Len(t, merged.Segments, 2)
+	assert.Equal(t, []Word{{Start: 0.2, End: 0.8, Text: "one"}}, merged.Segments[0].Words)
+	assert.Equal(t, []Word{{Start: 12.1, End: 12.9, Text: "two"}}, merged.Segments[1].Words)
+}
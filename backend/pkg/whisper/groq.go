@@ -9,12 +9,17 @@ import (
 	"mime/multipart"
 	"net/http"
 	"os"
+	"strings"
 )
 
+// groqMaxFileSize is the largest file the Groq transcription API accepts
+const groqMaxFileSize = 25 * 1024 * 1024
+
 type GroqWhisperProvider struct {
 	apiKey     string
 	httpClient *http.Client
 	model      string
+	baseURL    string
 }
 
 func NewGroqWhisperProvider(apiKey string) (*GroqWhisperProvider, error) {
@@ -26,6 +31,7 @@ func NewGroqWhisperProvider(apiKey string) (*GroqWhisperProvider, error) {
 		apiKey: apiKey,
 		httpClient: &http.Client{},
 		model:      "whisper-large-v3",
+		baseURL:    "https://api.groq.com/openai/v1",
 	}, nil
 }
 
@@ -60,6 +66,9 @@ func (g *GroqWhisperProvider) Transcribe(ctx context.Context, req TranscribeRequ
 	writer.WriteField("model", g.model)
 	writer.WriteField("response_format", "verbose_json")
 	writer.WriteField("timestamp_granularities[]", "segment")
+	if req.WordTimings {
+		writer.WriteField("timestamp_granularities[]", "word")
+	}
 
 	if req.Language != "" {
 		writer.WriteField("language", req.Language)
@@ -67,7 +76,7 @@ func (g *GroqWhisperProvider) Transcribe(ctx context.Context, req TranscribeRequ
 
 	writer.Close()
 
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", "https://api.groq.com/openai/v1/audio/transcriptions", body)
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", g.baseURL+"/audio/transcriptions", body)
 	if err != nil {
 		return nil, err
 	}
@@ -96,6 +105,12 @@ func (g *GroqWhisperProvider) Transcribe(ctx context.Context, req TranscribeRequ
 			End   float64 `json:"end"`
 			Text  string  `json:"text"`
 		} `json:"segments"`
+		// Words of the whole recording, present when word granularity was requested
+		Words []struct {
+			Word  string  `json:"word"`
+			Start float64 `json:"start"`
+			End   float64 `json:"end"`
+		} `json:"words"`
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
@@ -111,6 +126,15 @@ func (g *GroqWhisperProvider) Transcribe(ctx context.Context, req TranscribeRequ
 			Text:  seg.Text,
 		}
 	}
+	if len(result.Words) > 0 {
+		words := make([]Word, 0, len(result.Words))
+		for _, w := range result.Words {
+			if text := strings.TrimSpace(w.Word); text != "" {
+				words = append(words, Word{Start: w.Start, End: w.End, Text: text})
+			}
+		}
+		assignWords(segments, words)
+	}
 
 	return &TranscribeResponse{
 		Text:     result.Text,
@@ -126,8 +150,9 @@ func (g *GroqWhisperProvider) GetSupportedLanguages() []string {
 
 func (g *GroqWhisperProvider) GetModelInfo() ModelInfo {
 	return ModelInfo{
-		Name:     g.model,
-		Provider: "groq",
+		Name:        g.model,
+		Provider:    "groq",
+		MaxFileSize: groqMaxFileSize,
 	}
 }
 
@@ -0,0 +1,61 @@
+package whisper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroqWhisperProvider_WordTimings(t *testing.T) {
+	var granularities []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/audio/transcriptions", r.URL.Path)
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		granularities = r.MultipartForm.Value["timestamp_granularities[]"]
+		w.Write([]byte(`{"text": " Hello there. General Kenobi.", "language": "en", "duration": 5,
+			"segments": [
+				{"id": 0, "start": 0, "end": 2.2, "text": " Hello there."},
+				{"id": 1, "start": 2.4, "end": 5, "text": " General Kenobi."}
+			],
+			"words": [
+				{"word": "Hello", "start": 0.1, "end": 0.9},
+				{"word": "there.", "start": 1.0, "end": 2.3},
+				{"word": "General", "start": 2.4, "end": 3.5},
+				{"word": "Kenobi.", "start": 3.6, "end": 4.8}
+			]}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewGroqWhisperProvider("key")
+	require.NoError(t, err)
+	provider.baseURL = server.URL
+
+	resp, err := provider.Transcribe(context.Background(), TranscribeRequest{AudioData: []byte("audio"), WordTimings: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"segment", "word"}, granularities)
+	require.Len(t, resp.Segments, 2)
+	assert.Equal(t, []Word{{Start: 0.1, End: 0.9, Text: "Hello"}, {Start: 1.0, End: 2.3, Text: "there."}}, resp.Segments[0].Words)
+	assert.Equal(t, []Word{{Start: 2.4, End: 3.5, Text: "General"}, {Start: 3.6, End: 4.8, Text: "Kenobi."}}, resp.Segments[1].Words)
+
+	_, err = provider.Transcribe(context.Background(), TranscribeRequest{AudioData: []byte("audio")})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"segment"}, granularities)
+}
+
+func TestAssignWords(t *testing.T) {
+	segments := []TranscriptSegment{{Start: 1, End: 2}, {Start: 5, End: 6}}
+	assignWords(segments, []Word{
+		{Start: 0, End: 0.5, Text: "early"},
+		{Start: 1.5, End: 1.8, Text: "in"},
+		{Start: 3, End: 3.5, Text: "gap"},
+		{Start: 5.2, End: 5.4, Text: "second"},
+	})
+
+	assert.Equal(t, []Word{{Start: 0, End: 0.5, Text: "early"}, {Start: 1.5, End: 1.8, Text: "in"}, {Start: 3, End: 3.5, Text: "gap"}}, segments[0].Words)
+	assert.Equal(t, []Word{{Start: 5.2, End: 5.4, Text: "second"}}, segments[1].Words)
+}
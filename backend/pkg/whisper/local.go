@@ -9,6 +9,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 )
 
@@ -61,6 +62,13 @@ func (l *LocalWhisperProvider) Transcribe(ctx context.Context, req TranscribeReq
 		writer.WriteField("language", req.Language)
 	}
 	writer.WriteField("task", req.Task)
+	if req.WordTimings {
+		writer.WriteField("word_timings", "true")
+	}
+	if req.Diarize {
+		// The service labels speakers only when it has a diarization pipeline configured
+		writer.WriteField("diarize", "true")
+	}
 
 	writer.Close()
 
@@ -87,9 +95,15 @@ func (l *LocalWhisperProvider) Transcribe(ctx context.Context, req TranscribeReq
 		Language string `json:"language"`
 		Duration float64 `json:"duration"`
 		Segments []struct {
-			Start float64 `json:"start"`
-			End   float64 `json:"end"`
-			Text  string  `json:"text"`
+			Start   float64 `json:"start"`
+			End     float64 `json:"end"`
+			Text    string  `json:"text"`
+			Speaker string  `json:"speaker"`
+			Words   []struct {
+				Start float64 `json:"start"`
+				End   float64 `json:"end"`
+				Word  string  `json:"word"`
+			} `json:"words"`
 		} `json:"segments"`
 	}
 
@@ -100,10 +114,16 @@ func (l *LocalWhisperProvider) Transcribe(ctx context.Context, req TranscribeReq
 	segments := make([]TranscriptSegment, len(result.Segments))
 	for i, seg := range result.Segments {
 		segments[i] = TranscriptSegment{
-			ID:    i,
-			Start: seg.Start,
-			End:   seg.End,
-			Text:  seg.Text,
+			ID:      i,
+			Start:   seg.Start,
+			End:     seg.End,
+			Text:    seg.Text,
+			Speaker: seg.Speaker,
+		}
+		for _, w := range seg.Words {
+			if text := strings.TrimSpace(w.Word); text != "" {
+				segments[i].Words = append(segments[i].Words, Word{Start: w.Start, End: w.End, Text: text})
+			}
 		}
 	}
 
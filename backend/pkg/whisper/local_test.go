@@ -0,0 +1,62 @@
+package whisper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalWhisperProvider_Diarize(t *testing.T) {
+	var diarize string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		diarize = r.FormValue("diarize")
+		w.Write([]byte(`{"text": "Hi. Hello.", "language": "en", "duration": 4,
+			"segments": [
+				{"start": 0, "end": 1.5, "text": "Hi.", "speaker": "SPEAKER_00"},
+				{"start": 1.5, "end": 4, "text": "Hello.", "speaker": "SPEAKER_01"}
+			]}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewLocalWhisperProvider(server.URL)
+	require.NoError(t, err)
+
+	resp, err := provider.Transcribe(context.Background(), TranscribeRequest{AudioData: []byte("audio"), Task: "transcribe", Diarize: true})
+	require.NoError(t, err)
+	assert.Equal(t, "true", diarize)
+	require.Len(t, resp.Segments, 2)
+	assert.Equal(t, "SPEAKER_00", resp.Segments[0].Speaker)
+	assert.Equal(t, "SPEAKER_01", resp.Segments[1].Speaker)
+
+	_, err = provider.Transcribe(context.Background(), TranscribeRequest{AudioData: []byte("audio"), Task: "transcribe"})
+	require.NoError(t, err)
+	assert.Empty(t, diarize, "diarization is only asked for when requested")
+}
+
+func TestLocalWhisperProvider_WordTimings(t *testing.T) {
+	var wordTimings string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		wordTimings = r.FormValue("word_timings")
+		w.Write([]byte(`{"text": "Hi there.", "language": "en", "duration": 2,
+			"segments": [{"start": 0, "end": 2, "text": " Hi there.", "words": [
+				{"start": 0, "end": 0.6, "word": " Hi"},
+				{"start": 0.7, "end": 1.9, "word": " there."}
+			]}]}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewLocalWhisperProvider(server.URL)
+	require.NoError(t, err)
+
+	resp, err := provider.Transcribe(context.Background(), TranscribeRequest{AudioData: []byte("audio"), WordTimings: true})
+	require.NoError(t, err)
+	assert.Equal(t, "true", wordTimings)
+	require.Len(t, resp.Segments, 1)
+	assert.Equal(t, []Word{{Start: 0, End: 0.6, Text: "Hi"}, {Start: 0.7, End: 1.9, Text: "there."}}, resp.Segments[0].Words)
+}
@@ -15,7 +15,8 @@ type TranscribeRequest struct {
 	AudioData   []byte
 	Language    string
 	Task        string // "transcribe" or "translate"
-	WordTimings bool
+	WordTimings bool   // time every word, where the provider supports it
+	Diarize     bool // label segments with speakers, where the provider supports it
 }
 
 type TranscribeResponse struct {
@@ -26,15 +27,40 @@ type TranscribeResponse struct {
 }
 
 type TranscriptSegment struct {
-	ID    int
+	ID      int
+	Start   float64
+	End     float64
+	Text    string
+	Speaker string // e.g. SPEAKER_00; empty without diarization
+	Words   []Word // empty unless word timings were requested and supported
+}
+
+// Word is one word of a segment with the time it is spoken
+type Word struct {
 	Start float64
 	End   float64
 	Text  string
 }
 
+// assignWords distributes words timed across a whole recording to the segments they are
+// spoken in: the segment containing their midpoint, else the closest one before them
+func assignWords(segments []TranscriptSegment, words []Word) {
+	i := 0
+	for _, word := range words {
+		mid := (word.Start + word.End) / 2
+		for i+1 < len(segments) && segments[i+1].Start <= mid {
+			i++
+		}
+		if len(segments) > 0 {
+			segments[i].Words = append(segments[i].Words, word)
+		}
+	}
+}
+
 type ModelInfo struct {
 	Name string
 	Provider string
+	MaxFileSize int64 // upload limit in bytes, 0 for none
 }
 
 type Config struct {
@@ -0,0 +1,47 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Video availability states
+const (
+	AvailabilityAvailable = "available"
+	AvailabilityPrivate   = "private"
+	AvailabilityDeleted   = "deleted"
+)
+
+// oEmbedURL is the public oEmbed endpoint; it needs no API key and costs no quota
+var oEmbedURL = "https://www.youtube.com/oembed"
+
+// CheckAvailability tells a private video from a deleted one. The Data API simply omits
+// both from videos.list, while oEmbed answers 401/403 for private and 404 for removed videos.
+func (c *Client) CheckAvailability(ctx context.Context, videoID string) (string, error) {
+	watchURL := "https://www.youtube.com/watch?v=" + url.QueryEscape(videoID)
+	apiURL := fmt.Sprintf("%s?url=%s&format=json", oEmbedURL, url.QueryEscape(watchURL))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return AvailabilityAvailable, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return AvailabilityPrivate, nil
+	case http.StatusNotFound, http.StatusBadRequest:
+		return AvailabilityDeleted, nil
+	default:
+		return "", fmt.Errorf("oEmbed returned status %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,53 @@
+package youtube
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CheckAvailability(t *testing.T) {
+	statuses := map[string]int{
+		"publicVideo": http.StatusOK,
+		"privateVide": http.StatusUnauthorized,
+		"removedVide": http.StatusNotFound,
+		"brokenVideo": http.StatusInternalServerError,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		watchURL, err := url.Parse(r.URL.Query().Get("url"))
+		require.NoError(t, err)
+		w.WriteHeader(statuses[watchURL.Query().Get("v")])
+	}))
+	defer server.Close()
+
+	original := oEmbedURL
+	oEmbedURL = server.URL
+	defer func() { oEmbedURL = original }()
+
+	client := NewClient("")
+	ctx := context.Background()
+
+	tests := []struct {
+		videoID  string
+		expected string
+	}{
+		{"publicVideo", AvailabilityAvailable},
+		{"privateVide", AvailabilityPrivate},
+		{"removedVide", AvailabilityDeleted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.videoID, func(t *testing.T) {
+			availability, err := client.CheckAvailability(ctx, tt.videoID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, availability)
+		})
+	}
+
+	_, err := client.CheckAvailability(ctx, "brokenVideo")
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+package youtube
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is how long API responses are reused when no TTL is configured
+const DefaultCacheTTL = time.Hour
+
+// maxCacheEntries bounds the in-memory response cache
+const maxCacheEntries = 10000
+
+type noCacheKey struct{}
+
+// WithoutCache makes lookups made with the returned context skip cached responses, for callers
+// that need current values such as view counts. Fresh responses are still cached for others.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+func cacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(noCacheKey{}).(bool)
+	return bypass
+}
+
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// responseCache is an in-memory TTL cache of decoded API responses. A zero TTL disables it.
+type responseCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+	now     func() time.Time
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+		now:     time.Now,
+	}
+}
+
+func (c *responseCache) get(key string) (interface{}, bool) {
+	if c.ttl <= 0 {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if c.now().After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (c *responseCache) set(key string, value interface{}) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if len(c.entries) >= maxCacheEntries {
+		for k, entry := range c.entries {
+			if now.After(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	// Still full of live entries: drop arbitrary ones rather than grow without bound
+	for k := range c.entries {
+		if len(c.entries) < maxCacheEntries {
+			break
+		}
+		delete(c.entries, k)
+	}
+
+	c.entries[key] = cacheEntry{value: value, expires: now.Add(c.ttl)}
+}
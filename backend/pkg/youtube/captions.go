@@ -0,0 +1,276 @@
+package youtube
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Caption formats returned by DownloadCaptions
+const (
+	CaptionFormatJSON3     = "json3"
+	CaptionFormatTimedText = "timedtext" // XML: srv1 <text start dur> or srv3 <p t d>
+)
+
+// maxCaptionSize limits how much of a watch page or caption file is read
+const maxCaptionSize = 10 << 20
+
+// xmlTagPattern matches the markup inside a srv3 paragraph
+var xmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// watchPageURL is where caption tracks are discovered; it is not a Data API call and costs no quota
+var watchPageURL = "https://www.youtube.com/watch"
+
+type CaptionTrack struct {
+	Language      string
+	Name          string
+	URL           string
+	AutoGenerated bool
+}
+
+// CaptionFile is a downloaded caption track in the format YouTube served it
+type CaptionFile struct {
+	Format   string
+	Language string
+	Data     []byte
+}
+
+// CaptionCue is one timed line of a caption track
+type CaptionCue struct {
+	Start float64 // seconds
+	End   float64 // seconds
+	Text  string
+}
+
+// GetCaptions lists the caption tracks of a video, manual and auto-generated, by reading the
+// player response embedded in the watch page. An empty list means the video has no captions.
+func (c *Client) GetCaptions(ctx context.Context, videoID string) ([]CaptionTrack, error) {
+	pageURL := fmt.Sprintf("%s?v=%s&hl=en", watchPageURL, url.QueryEscape(videoID))
+	page, err := c.fetch(ctx, pageURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch watch page: %w", err)
+	}
+	return parseCaptionTracks(page)
+}
+
+// DownloadCaptions downloads a track, asking for json3. Some tracks are still served as
+// timedtext XML, so the format is taken from the response itself.
+func (c *Client) DownloadCaptions(ctx context.Context, track CaptionTrack) (*CaptionFile, error) {
+	u, err := url.Parse(track.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid caption URL: %w", err)
+	}
+	query := u.Query()
+	query.Set("fmt", CaptionFormatJSON3)
+	u.RawQuery = query.Encode()
+
+	data, err := c.fetch(ctx, u.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to download captions: %w", err)
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("caption track is empty")
+	}
+
+	format := CaptionFormatJSON3
+	if data[0] == '<' {
+		format = CaptionFormatTimedText
+	}
+	return &CaptionFile{Format: format, Language: track.Language, Data: data}, nil
+}
+
+// SelectCaptionTrack picks the track for language (prefix match, so "en" matches "en-GB"),
+// preferring manual captions over auto-generated ones. With no language the first manual
+// track wins, then the auto-generated one, which is in the spoken language.
+func SelectCaptionTrack(tracks []CaptionTrack, language string) (CaptionTrack, bool) {
+	language = strings.ToLower(language)
+	for _, wantAuto := range []bool{false, true} {
+		for _, track := range tracks {
+			if track.AutoGenerated != wantAuto {
+				continue
+			}
+			trackLang := strings.ToLower(track.Language)
+			if language == "" || trackLang == language || strings.HasPrefix(trackLang, language+"-") {
+				return track, true
+			}
+		}
+	}
+	return CaptionTrack{}, false
+}
+
+// ParseCaptions turns a downloaded caption file into cues
+func ParseCaptions(file *CaptionFile) ([]CaptionCue, error) {
+	switch file.Format {
+	case CaptionFormatJSON3:
+		return ParseJSON3(file.Data)
+	case CaptionFormatTimedText:
+		return ParseTimedText(file.Data)
+	default:
+		return nil, fmt.Errorf("unsupported caption format: %s", file.Format)
+	}
+}
+
+// ParseJSON3 parses YouTube's json3 caption format. Events without text (window
+// definitions, line breaks of auto-generated captions) are skipped.
+func ParseJSON3(data []byte) ([]CaptionCue, error) {
+	var doc struct {
+		Events []struct {
+			StartMs    int64 `json:"tStartMs"`
+			DurationMs int64 `json:"dDurationMs"`
+			Segs       []struct {
+				UTF8 string `json:"utf8"`
+			} `json:"segs"`
+		} `json:"events"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid json3 captions: %w", err)
+	}
+
+	var cues []CaptionCue
+	for _, event := range doc.Events {
+		var text strings.Builder
+		for _, seg := range event.Segs {
+			text.WriteString(seg.UTF8)
+		}
+		content := cleanCaptionText(text.String())
+		if content == "" {
+			continue
+		}
+		cues = append(cues, CaptionCue{
+			Start: float64(event.StartMs) / 1000,
+			End:   float64(event.StartMs+event.DurationMs) / 1000,
+			Text:  content,
+		})
+	}
+	return cues, nil
+}
+
+// ParseTimedText parses timedtext XML in the srv1 (<text start="1.2" dur="3.4">) or
+// srv3 (<p t="1200" d="3400"> with <s> word spans) layout
+func ParseTimedText(data []byte) ([]CaptionCue, error) {
+	var doc struct {
+		Texts []struct {
+			Start string `xml:"start,attr"`
+			Dur   string `xml:"dur,attr"`
+			Text  string `xml:",chardata"`
+		} `xml:"text"`
+		Paragraphs []struct {
+			T     int64  `xml:"t,attr"`
+			D     int64  `xml:"d,attr"`
+			Inner string `xml:",innerxml"`
+		} `xml:"body>p"`
+	}
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid timedtext captions: %w", err)
+	}
+
+	var cues []CaptionCue
+	for _, text := range doc.Texts {
+		start, _ := strconv.ParseFloat(text.Start, 64)
+		dur, _ := strconv.ParseFloat(text.Dur, 64)
+		// srv1 text is HTML-escaped inside the XML escaping (&amp;#39;)
+		content := cleanCaptionText(html.UnescapeString(text.Text))
+		if content == "" {
+			continue
+		}
+		cues = append(cues, CaptionCue{Start: start, End: start + dur, Text: content})
+	}
+	for _, p := range doc.Paragraphs {
+		// Keep the <s> word spans of auto-generated captions in order by dropping the tags
+		content := cleanCaptionText(html.UnescapeString(xmlTagPattern.ReplaceAllString(p.Inner, "")))
+		if content == "" {
+			continue
+		}
+		cues = append(cues, CaptionCue{
+			Start: float64(p.T) / 1000,
+			End:   float64(p.T+p.D) / 1000,
+			Text:  content,
+		})
+	}
+	return cues, nil
+}
+
+// parseCaptionTracks extracts "captionTracks" from the ytInitialPlayerResponse of a watch page
+func parseCaptionTracks(page []byte) ([]CaptionTrack, error) {
+	if !bytes.Contains(page, []byte("ytInitialPlayerResponse")) {
+		return nil, fmt.Errorf("watch page has no player response")
+	}
+
+	idx := bytes.Index(page, []byte(`"captionTracks":`))
+	if idx < 0 {
+		return []CaptionTrack{}, nil
+	}
+
+	var raw []struct {
+		BaseURL      string `json:"baseUrl"`
+		LanguageCode string `json:"languageCode"`
+		Kind         string `json:"kind"`
+		Name         struct {
+			SimpleText string `json:"simpleText"`
+			Runs       []struct {
+				Text string `json:"text"`
+			} `json:"runs"`
+		} `json:"name"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(page[idx+len(`"captionTracks":`):]))
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("invalid caption track list: %w", err)
+	}
+
+	tracks := make([]CaptionTrack, 0, len(raw))
+	for _, t := range raw {
+		if t.BaseURL == "" {
+			continue
+		}
+		name := t.Name.SimpleText
+		if name == "" && len(t.Name.Runs) > 0 {
+			name = t.Name.Runs[0].Text
+		}
+		tracks = append(tracks, CaptionTrack{
+			Language:      t.LanguageCode,
+			Name:          name,
+			URL:           t.BaseURL,
+			AutoGenerated: t.Kind == "asr",
+		})
+	}
+	return tracks, nil
+}
+
+// fetch GETs a youtube.com page or caption file (not a Data API call)
+func (c *Client) fetch(ctx context.Context, pageURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	// Skip the EU cookie consent interstitial
+	req.AddCookie(&http.Cookie{Name: "CONSENT", Value: "YES+cb"})
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxCaptionSize))
+}
+
+// cleanCaptionText collapses line breaks and repeated whitespace into single spaces
+func cleanCaptionText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
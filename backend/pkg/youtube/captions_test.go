@@ -0,0 +1,149 @@
+package youtube
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return data
+}
+
+func TestParseCaptionTracks(t *testing.T) {
+	tracks, err := parseCaptionTracks(readFixture(t, "watch_page.html"))
+	require.NoError(t, err)
+	require.Len(t, tracks, 3)
+
+	assert.Equal(t, "en", tracks[0].Language)
+	assert.Equal(t, "English", tracks[0].Name)
+	assert.False(t, tracks[0].AutoGenerated)
+	assert.Contains(t, tracks[0].URL, "&lang=en", "\\u0026 escapes are decoded")
+
+	assert.Equal(t, "German (Germany)", tracks[1].Name, "names given as runs are read too")
+	assert.True(t, tracks[2].AutoGenerated)
+
+	tracks, err = parseCaptionTracks(readFixture(t, "watch_page_no_captions.html"))
+	require.NoError(t, err)
+	assert.Empty(t, tracks)
+
+	_, err = parseCaptionTracks([]byte("<html>Before you continue to YouTube</html>"))
+	assert.Error(t, err, "consent or error pages have no player response")
+}
+
+func TestSelectCaptionTrack(t *testing.T) {
+	tracks := []CaptionTrack{
+		{Language: "en", AutoGenerated: true, URL: "asr-en"},
+		{Language: "de-DE", URL: "de"},
+		{Language: "en", URL: "en"},
+	}
+
+	tests := []struct {
+		language string
+		expected string
+		found    bool
+	}{
+		{"", "de", true},
+		{"en", "en", true},
+		{"de", "de", true},
+		{"DE-de", "de", true},
+		{"fr", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.language, func(t *testing.T) {
+			track, ok := SelectCaptionTrack(tracks, tt.language)
+			assert.Equal(t, tt.found, ok)
+			assert.Equal(t, tt.expected, track.URL)
+		})
+	}
+
+	track, ok := SelectCaptionTrack(tracks[:1], "en")
+	require.True(t, ok)
+	assert.True(t, track.AutoGenerated, "auto-generated captions are used when there is nothing else")
+}
+
+func TestParseJSON3(t *testing.T) {
+	cues, err := ParseJSON3(readFixture(t, "captions.json3"))
+	require.NoError(t, err)
+	require.Len(t, cues, 3, "window definitions and line-break events are skipped")
+
+	assert.Equal(t, CaptionCue{Start: 18.8, End: 22.16, Text: "We're no strangers to love"}, cues[0])
+	assert.Equal(t, "You know the rules and so do I", cues[1].Text)
+	assert.Equal(t, "♪ A full commitment's what I'm thinking of ♪", cues[2].Text)
+
+	_, err = ParseJSON3([]byte("<transcript/>"))
+	assert.Error(t, err)
+}
+
+func TestParseTimedText(t *testing.T) {
+	cues, err := ParseTimedText(readFixture(t, "captions_srv1.xml"))
+	require.NoError(t, err)
+	require.Len(t, cues, 3, "empty lines are skipped")
+	assert.Equal(t, CaptionCue{Start: 18.8, End: 22.16, Text: "We're no strangers to love"}, cues[0])
+	assert.Equal(t, "You know the rules and so do I", cues[1].Text)
+	assert.Equal(t, "A full commitment's what I'm thinking of", cues[2].Text)
+
+	cues, err = ParseTimedText(readFixture(t, "captions_srv3.xml"))
+	require.NoError(t, err)
+	require.Len(t, cues, 2)
+	assert.Equal(t, CaptionCue{Start: 18.8, End: 22.16, Text: "we're no strangers to love"}, cues[0])
+	assert.Equal(t, "you know the rules & so do I", cues[1].Text)
+}
+
+func TestClient_GetAndDownloadCaptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/watch":
+			if r.URL.Query().Get("v") != "dQw4w9WgXcQ" {
+				w.Write(readFixture(t, "watch_page_no_captions.html"))
+				return
+			}
+			w.Write(readFixture(t, "watch_page.html"))
+		case "/api/timedtext":
+			if r.URL.Query().Get("fmt") == "json3" && r.URL.Query().Get("lang") == "en" {
+				w.Write(readFixture(t, "captions.json3"))
+				return
+			}
+			w.Write(readFixture(t, "captions_srv1.xml"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	original := watchPageURL
+	watchPageURL = server.URL + "/watch"
+	defer func() { watchPageURL = original }()
+
+	client := NewClient("")
+	ctx := context.Background()
+
+	tracks, err := client.GetCaptions(ctx, "dQw4w9WgXcQ")
+	require.NoError(t, err)
+	require.Len(t, tracks, 3)
+
+	tracks, err = client.GetCaptions(ctx, "noCaptions1")
+	require.NoError(t, err)
+	assert.Empty(t, tracks)
+
+	file, err := client.DownloadCaptions(ctx, CaptionTrack{Language: "en", URL: server.URL + "/api/timedtext?v=dQw4w9WgXcQ&lang=en&fmt=srv3"})
+	require.NoError(t, err)
+	assert.Equal(t, CaptionFormatJSON3, file.Format)
+	cues, err := ParseCaptions(file)
+	require.NoError(t, err)
+	assert.Len(t, cues, 3)
+
+	file, err = client.DownloadCaptions(ctx, CaptionTrack{Language: "de", URL: server.URL + "/api/timedtext?v=dQw4w9WgXcQ&lang=de"})
+	require.NoError(t, err)
+	assert.Equal(t, CaptionFormatTimedText, file.Format, "the format is sniffed from the response")
+	assert.Equal(t, "de", file.Language)
+}
@@ -0,0 +1,118 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	// channelIDPattern matches canonical channel IDs (UC + 22 characters)
+	channelIDPattern = regexp.MustCompile(`^UC[a-zA-Z0-9_-]{22}$`)
+	// channelHandlePattern matches @handles
+	channelHandlePattern = regexp.MustCompile(`^@[a-zA-Z0-9._-]{3,30}$`)
+)
+
+type ChannelInfo struct {
+	ID                string
+	Title             string
+	Description       string
+	ThumbnailURL      string
+	UploadsPlaylistID string
+}
+
+// ExtractChannelRef returns a channel ID (UC...) or @handle from a channel URL, bare ID or handle
+func (c *Client) ExtractChannelRef(channelURL string) (string, error) {
+	channelURL = strings.TrimSpace(channelURL)
+	if channelIDPattern.MatchString(channelURL) || channelHandlePattern.MatchString(channelURL) {
+		return channelURL, nil
+	}
+
+	u, err := url.Parse(channelURL)
+	if err != nil || !strings.Contains(u.Host, "youtube.com") {
+		return "", fmt.Errorf("invalid YouTube channel URL")
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	switch {
+	case len(parts) >= 2 && parts[0] == "channel" && channelIDPattern.MatchString(parts[1]):
+		return parts[1], nil
+	case len(parts) >= 1 && channelHandlePattern.MatchString(parts[0]):
+		return parts[0], nil
+	}
+
+	return "", fmt.Errorf("invalid YouTube channel URL")
+}
+
+// GetChannelInfo fetches channel metadata, including the uploads playlist, by channel ID or @handle
+func (c *Client) GetChannelInfo(ctx context.Context, channelRef string) (*ChannelInfo, error) {
+	lookup := "id=" + url.QueryEscape(channelRef)
+	if strings.HasPrefix(channelRef, "@") {
+		lookup = "forHandle=" + url.QueryEscape(channelRef)
+	}
+
+	apiURL := fmt.Sprintf(
+		"%s/channels?%s&key=%s&part=snippet,contentDetails",
+		apiBaseURL, lookup, c.apiKey,
+	)
+
+	var result struct {
+		Items []struct {
+			ID      string `json:"id"`
+			Snippet struct {
+				Title       string `json:"title"`
+				Description string `json:"description"`
+				Thumbnails  struct {
+					Default struct {
+						URL string `json:"url"`
+					} `json:"default"`
+					High struct {
+						URL string `json:"url"`
+					} `json:"high"`
+				} `json:"thumbnails"`
+			} `json:"snippet"`
+			ContentDetails struct {
+				RelatedPlaylists struct {
+					Uploads string `json:"uploads"`
+				} `json:"relatedPlaylists"`
+			} `json:"contentDetails"`
+		} `json:"items"`
+	}
+
+	if err := c.getJSON(ctx, "channels.list", apiURL, &result); err != nil {
+		return nil, err
+	}
+
+	if len(result.Items) == 0 {
+		return nil, fmt.Errorf("channel not found")
+	}
+
+	item := result.Items[0]
+	thumbnailURL := item.Snippet.Thumbnails.High.URL
+	if thumbnailURL == "" {
+		thumbnailURL = item.Snippet.Thumbnails.Default.URL
+	}
+
+	uploads := item.ContentDetails.RelatedPlaylists.Uploads
+	if uploads == "" {
+		uploads = UploadsPlaylistID(item.ID)
+	}
+
+	return &ChannelInfo{
+		ID:                item.ID,
+		Title:             item.Snippet.Title,
+		Description:       item.Snippet.Description,
+		ThumbnailURL:      thumbnailURL,
+		UploadsPlaylistID: uploads,
+	}, nil
+}
+
+// UploadsPlaylistID derives a channel's uploads playlist ID (UC... -> UU...)
+func UploadsPlaylistID(channelID string) string {
+	if strings.HasPrefix(channelID, "UC") {
+		return "UU" + channelID[2:]
+	}
+	return channelID
+}
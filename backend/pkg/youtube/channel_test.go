@@ -0,0 +1,52 @@
+package youtube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ExtractChannelRef(t *testing.T) {
+	client := NewClient("")
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"channel URL", "https://www.youtube.com/channel/UC_x5XG1OV2P6uZZ5FSM9Ttw", "UC_x5XG1OV2P6uZZ5FSM9Ttw"},
+		{"channel URL with tab", "https://www.youtube.com/channel/UC_x5XG1OV2P6uZZ5FSM9Ttw/videos", "UC_x5XG1OV2P6uZZ5FSM9Ttw"},
+		{"handle URL", "https://www.youtube.com/@GoogleDevelopers", "@GoogleDevelopers"},
+		{"bare channel ID", "UC_x5XG1OV2P6uZZ5FSM9Ttw", "UC_x5XG1OV2P6uZZ5FSM9Ttw"},
+		{"bare handle", "@GoogleDevelopers", "@GoogleDevelopers"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, err := client.ExtractChannelRef(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ref)
+		})
+	}
+}
+
+func TestClient_ExtractChannelRef_Invalid(t *testing.T) {
+	client := NewClient("")
+
+	inputs := []string{
+		"",
+		"https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+		"https://example.com/channel/UC_x5XG1OV2P6uZZ5FSM9Ttw",
+		"UCshort",
+	}
+
+	for _, input := range inputs {
+		_, err := client.ExtractChannelRef(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestUploadsPlaylistID(t *testing.T) {
+	assert.Equal(t, "UU_x5XG1OV2P6uZZ5FSM9Ttw", UploadsPlaylistID("UC_x5XG1OV2P6uZZ5FSM9Ttw"))
+}
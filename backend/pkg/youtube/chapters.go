@@ -0,0 +1,96 @@
+package youtube
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// minChapters is the number of timestamps YouTube requires before it shows chapters
+const minChapters = 3
+
+// chapterLinePattern matches a description line holding a timestamp (h:mm:ss or m:ss), either
+// before the title ("0:00 Intro", "(01:30) - Setup") or after it ("Intro - 0:00")
+var chapterLinePattern = regexp.MustCompile(`^(?:[-•*\s]*[(\[]?((?:\d{1,2}:)?\d{1,2}:\d{2})[)\]]?\s*[-–—:|.]?\s*(.+?)|(.+?)\s*[-–—:|]?\s*[(\[]?((?:\d{1,2}:)?\d{1,2}:\d{2})[)\]]?)\s*$`)
+
+// Chapter is a titled section of a video
+type Chapter struct {
+	Title string
+	Start float64 // seconds
+	End   float64 // seconds
+}
+
+// ParseDescriptionChapters reads chapters from the timestamp list in a video description.
+// Following YouTube's own rules the list must start at 0:00, have at least three entries and
+// be in ascending order; otherwise nil is returned. The last chapter ends at duration.
+func ParseDescriptionChapters(description string, duration int) []Chapter {
+	var chapters []Chapter
+	for _, line := range strings.Split(description, "\n") {
+		start, title, ok := parseChapterLine(strings.TrimSpace(line))
+		if !ok {
+			continue
+		}
+		if len(chapters) == 0 {
+			if start != 0 {
+				continue // timestamps before the list, e.g. "sponsor at 2:15"
+			}
+		} else if start <= chapters[len(chapters)-1].Start {
+			break
+		}
+		if duration > 0 && start >= float64(duration) {
+			break
+		}
+		chapters = append(chapters, Chapter{Title: title, Start: start})
+	}
+
+	if len(chapters) < minChapters {
+		return nil
+	}
+	for i := range chapters {
+		if i+1 < len(chapters) {
+			chapters[i].End = chapters[i+1].Start
+		} else {
+			chapters[i].End = float64(duration)
+		}
+	}
+	return chapters
+}
+
+func parseChapterLine(line string) (float64, string, bool) {
+	match := chapterLinePattern.FindStringSubmatch(line)
+	if match == nil {
+		return 0, "", false
+	}
+
+	timestamp, title := match[1], match[2]
+	if timestamp == "" {
+		timestamp, title = match[4], match[3]
+	}
+	title = strings.Trim(title, " -–—:|")
+	if title == "" {
+		return 0, "", false
+	}
+
+	seconds, ok := parseClockTime(timestamp)
+	if !ok {
+		return 0, "", false
+	}
+	return seconds, title, true
+}
+
+// parseClockTime converts "1:02:03" or "02:03" to seconds
+func parseClockTime(timestamp string) (float64, bool) {
+	parts := strings.Split(timestamp, ":")
+	total := 0
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return 0, false
+		}
+		if i > 0 && n >= 60 {
+			return 0, false
+		}
+		total = total*60 + n
+	}
+	return float64(total), true
+}
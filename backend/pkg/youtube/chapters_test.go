@@ -0,0 +1,60 @@
+package youtube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDescriptionChapters(t *testing.T) {
+	description := `In this video we build a compiler. Sponsor segment at 2:15.
+
+Chapters:
+0:00 Intro
+(01:30) - Lexer
+5:45 | Parser
+1:02:03 Code generation
+
+Follow me on social media!`
+
+	chapters := ParseDescriptionChapters(description, 4000)
+	require.Len(t, chapters, 4)
+
+	assert.Equal(t, Chapter{Title: "Intro", Start: 0, End: 90}, chapters[0])
+	assert.Equal(t, Chapter{Title: "Lexer", Start: 90, End: 345}, chapters[1])
+	assert.Equal(t, "Parser", chapters[2].Title)
+	assert.Equal(t, Chapter{Title: "Code generation", Start: 3723, End: 4000}, chapters[3])
+}
+
+func TestParseDescriptionChapters_TrailingTimestamps(t *testing.T) {
+	chapters := ParseDescriptionChapters("Welcome - 00:00\nSetup - 00:42\nDemo - 03:10", 600)
+	require.Len(t, chapters, 3)
+	assert.Equal(t, "Setup", chapters[1].Title)
+	assert.Equal(t, float64(42), chapters[1].Start)
+	assert.Equal(t, float64(600), chapters[2].End)
+}
+
+func TestParseDescriptionChapters_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+	}{
+		{"no timestamps", "Just a video about cats"},
+		{"too few", "0:00 Intro\n4:00 Outro"},
+		{"not starting at zero", "0:30 Intro\n1:00 Middle\n2:00 Outro"},
+		{"invalid seconds", "0:00 Intro\n1:75 Middle\n2:00 Outro"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Nil(t, ParseDescriptionChapters(tt.description, 600))
+		})
+	}
+}
+
+func TestParseDescriptionChapters_StopsAtOutOfOrder(t *testing.T) {
+	description := "0:00 Intro\n1:00 Part one\n2:00 Part two\n\nBloopers:\n0:30 Oops"
+	chapters := ParseDescriptionChapters(description, 300)
+	require.Len(t, chapters, 3)
+	assert.Equal(t, float64(300), chapters[2].End)
+}
@@ -2,9 +2,8 @@ package youtube
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -12,9 +11,18 @@ import (
 	"time"
 )
 
+// apiBaseURL is the YouTube Data API v3 root
+var apiBaseURL = "https://www.googleapis.com/youtube/v3"
+
+// ErrVideoNotFound is returned by GetVideoInfo when the API does not list the video,
+// which happens once it has been made private or deleted
+var ErrVideoNotFound = errors.New("video not found")
+
 type Client struct {
 	apiKey string
 	client *http.Client
+	quota  *QuotaLedger
+	cache  *responseCache
 }
 
 type VideoInfo struct {
@@ -30,96 +38,176 @@ type VideoInfo struct {
 	ThumbnailURL string
 	Tags        []string
 	Category    string
+	// LiveBroadcastContent is "upcoming" for scheduled premieres and streams, "live" while
+	// broadcasting and "none" for regular videos and finished broadcasts
+	LiveBroadcastContent string
+	ScheduledStartTime   *time.Time // set for premieres and streams
+	ActualEndTime        *time.Time // set once a broadcast has ended
 }
 
-func NewClient(apiKey string) *Client {
-	return &Client{
-		apiKey: apiKey,
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+// Live broadcast states of VideoInfo.LiveBroadcastContent
+const (
+	BroadcastNone     = "none"
+	BroadcastUpcoming = "upcoming"
+	BroadcastLive     = "live"
+)
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithDailyQuota sets the daily Data API budget in quota units
+func WithDailyQuota(units int) Option {
+	return func(c *Client) {
+		c.quota = NewQuotaLedger(units)
 	}
 }
 
-func (c *Client) ExtractVideoID(videoURL string) (string, error) {
-	patterns := []string{
-		`(?:youtube\.com\/watch\?v=|youtu\.be\/|youtube\.com\/embed\/)([a-zA-Z0-9_-]{11})`,
-		`youtube\.com\/watch\?.*v=([a-zA-Z0-9_-]{11})`,
+// WithCacheTTL sets how long video details and search results are reused; zero disables caching
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.cache = newResponseCache(ttl)
 	}
+}
 
-	for _, pattern := range patterns {
-		re := regexp.MustCompile(pattern)
-		matches := re.FindStringSubmatch(videoURL)
-		if len(matches) > 1 {
-			return matches[1], nil
-		}
+// WithHTTPClient replaces the HTTP client used for API and caption requests
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		c.client = client
 	}
+}
 
-	return "", fmt.Errorf("invalid YouTube URL")
+func NewClient(apiKey string, opts ...Option) *Client {
+	c := &Client{
+		apiKey: apiKey,
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		quota: NewQuotaLedger(DefaultDailyQuota),
+		cache: newResponseCache(DefaultCacheTTL),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
-func (c *Client) GetVideoInfo(ctx context.Context, videoID string) (*VideoInfo, error) {
-	apiURL := fmt.Sprintf(
-		"https://www.googleapis.com/youtube/v3/videos?id=%s&key=%s&part=snippet,statistics,contentDetails",
-		videoID, c.apiKey,
-	)
+// QuotaUsage reports the quota spent today
+func (c *Client) QuotaUsage() QuotaUsage {
+	return c.quota.Usage()
+}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+// ExtractVideoID returns the video ID of any supported YouTube URL form or a bare video ID.
+// Use ParseVideoURL to also get the start time, playlist ID and URL kind.
+func (c *Client) ExtractVideoID(videoURL string) (string, error) {
+	parsed, err := ParseVideoURL(videoURL)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
+	return parsed.VideoID, nil
+}
 
-	resp, err := c.client.Do(req)
+func (c *Client) GetVideoInfo(ctx context.Context, videoID string) (*VideoInfo, error) {
+	videos, err := c.GetVideosInfo(ctx, []string{videoID})
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("YouTube API error: %s", string(body))
+	info, ok := videos[videoID]
+	if !ok {
+		return nil, ErrVideoNotFound
 	}
+	return info, nil
+}
 
-	var result struct {
-		Items []struct {
-			ID      string `json:"id"`
-			Snippet struct {
-				Title       string    `json:"title"`
-				Description string    `json:"description"`
-				ChannelID   string    `json:"channelId"`
-				ChannelName string    `json:"channelTitle"`
-				PublishedAt time.Time `json:"publishedAt"`
-				Thumbnails  struct {
-					Default struct {
-						URL string `json:"url"`
-					} `json:"default"`
-					High struct {
-						URL string `json:"url"`
-					} `json:"high"`
-				} `json:"thumbnails"`
-				Tags     []string `json:"tags"`
-				Category string   `json:"categoryId"`
-			} `json:"snippet"`
-			Statistics struct {
-				ViewCount string `json:"viewCount"`
-				LikeCount string `json:"likeCount"`
-			} `json:"statistics"`
-			ContentDetails struct {
-				Duration string `json:"duration"`
-			} `json:"contentDetails"`
-		} `json:"items"`
-	}
+// GetVideosInfo looks up many videos with one videos.list call (1 quota unit) per 50 IDs.
+// Cached videos are not requested again unless ctx comes from WithoutCache. Videos the API does
+// not return are missing from the map.
+func (c *Client) GetVideosInfo(ctx context.Context, videoIDs []string) (map[string]*VideoInfo, error) {
+	videos := make(map[string]*VideoInfo, len(videoIDs))
+	var missing []string
+	seen := make(map[string]bool, len(videoIDs))
+	for _, id := range videoIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
+		if cached, ok := c.cache.get("video:" + id); ok && !cacheBypassed(ctx) {
+			info := cached.(VideoInfo)
+			videos[id] = &info
+			c.quota.RecordCacheHit("videos.list")
+			continue
+		}
+		missing = append(missing, id)
 	}
 
-	if len(result.Items) == 0 {
-		return nil, fmt.Errorf("video not found")
+	for start := 0; start < len(missing); start += maxVideosPerRequest {
+		end := start + maxVideosPerRequest
+		if end > len(missing) {
+			end = len(missing)
+		}
+
+		apiURL := fmt.Sprintf(
+			"%s/videos?id=%s&key=%s&part=snippet,statistics,contentDetails,liveStreamingDetails&maxResults=%d",
+			apiBaseURL, url.QueryEscape(strings.Join(missing[start:end], ",")), c.apiKey, maxVideosPerRequest,
+		)
+
+		var result videoListResponse
+		if err := c.getJSON(ctx, "videos.list", apiURL, &result); err != nil {
+			return nil, err
+		}
+
+		for _, item := range result.Items {
+			info := item.toVideoInfo()
+			c.cache.set("video:"+info.ID, *info)
+			videos[info.ID] = info
+		}
 	}
 
-	item := result.Items[0]
+	return videos, nil
+}
+
+// maxVideosPerRequest is the most IDs videos.list accepts in one call
+const maxVideosPerRequest = 50
 
+type videoListResponse struct {
+	Items []videoItem `json:"items"`
+}
+
+type videoItem struct {
+	ID      string `json:"id"`
+	Snippet struct {
+		Title       string    `json:"title"`
+		Description string    `json:"description"`
+		ChannelID   string    `json:"channelId"`
+		ChannelName string    `json:"channelTitle"`
+		PublishedAt time.Time `json:"publishedAt"`
+		Thumbnails  struct {
+			Default struct {
+				URL string `json:"url"`
+			} `json:"default"`
+			High struct {
+				URL string `json:"url"`
+			} `json:"high"`
+		} `json:"thumbnails"`
+		Tags                 []string `json:"tags"`
+		Category             string   `json:"categoryId"`
+		LiveBroadcastContent string   `json:"liveBroadcastContent"`
+	} `json:"snippet"`
+	Statistics struct {
+		ViewCount string `json:"viewCount"`
+		LikeCount string `json:"likeCount"`
+	} `json:"statistics"`
+	ContentDetails struct {
+		Duration string `json:"duration"`
+	} `json:"contentDetails"`
+	LiveStreamingDetails *struct {
+		ScheduledStartTime *time.Time `json:"scheduledStartTime"`
+		ActualEndTime      *time.Time `json:"actualEndTime"`
+	} `json:"liveStreamingDetails"`
+}
+
+func (item videoItem) toVideoInfo() *VideoInfo {
 	// Parse duration (ISO 8601 format: PT1H2M10S)
 	duration := parseDuration(item.ContentDetails.Duration)
 
@@ -133,15 +221,12 @@ func (c *Client) GetVideoInfo(ctx context.Context, videoID string) (*VideoInfo,
 		thumbnailURL = item.Snippet.Thumbnails.Default.URL
 	}
 
-	// Get channel name
-	channelName := item.Snippet.ChannelName
-
-	return &VideoInfo{
+	info := &VideoInfo{
 		ID:           item.ID,
 		Title:        item.Snippet.Title,
 		Description:  item.Snippet.Description,
 		ChannelID:    item.Snippet.ChannelID,
-		ChannelName:  channelName,
+		ChannelName:  item.Snippet.ChannelName,
 		Duration:     duration,
 		ViewCount:    viewCount,
 		LikeCount:    likeCount,
@@ -149,7 +234,17 @@ func (c *Client) GetVideoInfo(ctx context.Context, videoID string) (*VideoInfo,
 		ThumbnailURL: thumbnailURL,
 		Tags:         item.Snippet.Tags,
 		Category:     item.Snippet.Category,
-	}, nil
+	}
+
+	info.LiveBroadcastContent = item.Snippet.LiveBroadcastContent
+	if info.LiveBroadcastContent == "" {
+		info.LiveBroadcastContent = BroadcastNone
+	}
+	if live := item.LiveStreamingDetails; live != nil {
+		info.ScheduledStartTime = live.ScheduledStartTime
+		info.ActualEndTime = live.ActualEndTime
+	}
+	return info
 }
 
 func parseDuration(durationStr string) int {
@@ -171,17 +266,6 @@ func parseDuration(durationStr string) int {
 	return hours*3600 + minutes*60 + seconds
 }
 
-func (c *Client) GetCaptions(ctx context.Context, videoID string) ([]CaptionTrack, error) {
-	// This would require scraping or using YouTube Data API v3 captions endpoint
-	// For now, return empty - will be implemented with actual caption fetching
-	return nil, fmt.Errorf("caption fetching not yet implemented")
-}
-
-type CaptionTrack struct {
-	Language string
-	URL      string
-}
-
 // SearchRelatedVideos searches for videos related to the given video ID
 // First gets the video info, then searches for similar videos based on title, description, and tags
 func (c *Client) SearchRelatedVideos(ctx context.Context, videoID string, maxResults int) ([]VideoInfo, error) {
@@ -204,8 +288,8 @@ func (c *Client) SearchRelatedVideos(ctx context.Context, videoID string, maxRes
 	if videoInfo.ChannelID != "" {
 		// Search for videos from the same channel, excluding current video
 		apiURL = fmt.Sprintf(
-			"https://www.googleapis.com/youtube/v3/search?channelId=%s&type=video&part=snippet&maxResults=%d&key=%s&order=date&publishedAfter=2020-01-01T00:00:00Z",
-			videoInfo.ChannelID, maxResults+1, c.apiKey,
+			"%s/search?channelId=%s&type=video&part=snippet&maxResults=%d&key=%s&order=date&publishedAfter=2020-01-01T00:00:00Z",
+			apiBaseURL, videoInfo.ChannelID, maxResults+1, c.apiKey,
 		)
 	} else {
 		// Fallback: Search by title and tags
@@ -221,25 +305,16 @@ func (c *Client) SearchRelatedVideos(ctx context.Context, videoID string, maxRes
 		}
 		encodedQuery := url.QueryEscape(strings.TrimSpace(searchQuery))
 		apiURL = fmt.Sprintf(
-			"https://www.googleapis.com/youtube/v3/search?q=%s&type=video&part=snippet&maxResults=%d&key=%s&order=relevance",
-			encodedQuery, maxResults+1, c.apiKey,
+			"%s/search?q=%s&type=video&part=snippet&maxResults=%d&key=%s&order=relevance",
+			apiBaseURL, encodedQuery, maxResults+1, c.apiKey,
 		)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("YouTube API error: %s", string(body))
+	// search.list costs 100 units, so identical searches are answered from the cache
+	cacheKey := "search:" + strings.Replace(apiURL, c.apiKey, "", 1)
+	if cached, ok := c.cache.get(cacheKey); ok {
+		c.quota.RecordCacheHit("search.list")
+		return cached.([]VideoInfo), nil
 	}
 
 	var result struct {
@@ -265,11 +340,21 @@ func (c *Client) SearchRelatedVideos(ctx context.Context, videoID string, maxRes
 		} `json:"items"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := c.getJSON(ctx, "search.list", apiURL, &result); err != nil {
 		return nil, err
 	}
 
-	// Convert to VideoInfo and fetch full details for each video
+	// Get full details (statistics, duration, etc.) of all results with one batched lookup
+	resultIDs := make([]string, 0, len(result.Items))
+	for _, item := range result.Items {
+		resultIDs = append(resultIDs, item.ID.VideoID)
+	}
+	details, err := c.GetVideosInfo(ctx, resultIDs)
+	if err != nil {
+		details = nil
+	}
+
+	// Convert to VideoInfo
 	var videos []VideoInfo
 	for _, item := range result.Items {
 		// Skip the original video
@@ -277,9 +362,8 @@ func (c *Client) SearchRelatedVideos(ctx context.Context, videoID string, maxRes
 			continue
 		}
 
-		// Get full video details (for statistics, duration, etc.)
-		videoInfo, err := c.GetVideoInfo(ctx, item.ID.VideoID)
-		if err != nil {
+		videoInfo, ok := details[item.ID.VideoID]
+		if !ok {
 			// If we can't get full details, create minimal info from search result
 			thumbnailURL := item.Snippet.Thumbnails.High.URL
 			if thumbnailURL == "" {
@@ -296,13 +380,13 @@ func (c *Client) SearchRelatedVideos(ctx context.Context, videoID string, maxRes
 			}
 		}
 		videos = append(videos, *videoInfo)
-		
+
 		// Stop if we have enough results
 		if len(videos) >= maxResults {
 			break
 		}
 	}
 
+	c.cache.set(cacheKey, videos)
 	return videos, nil
 }
-
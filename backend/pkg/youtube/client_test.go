@@ -0,0 +1,42 @@
+package youtube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ExtractVideoID(t *testing.T) {
+	client := NewClient("")
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"watch URL", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", "dQw4w9WgXcQ"},
+		{"short URL", "https://youtu.be/dQw4w9WgXcQ", "dQw4w9WgXcQ"},
+		{"embed URL", "https://www.youtube.com/embed/dQw4w9WgXcQ", "dQw4w9WgXcQ"},
+		{"bare video ID", "dQw4w9WgXcQ", "dQw4w9WgXcQ"},
+		{"shorts URL", "https://www.youtube.com/shorts/dQw4w9WgXcQ", "dQw4w9WgXcQ"},
+		{"mobile URL", "https://m.youtube.com/watch?v=dQw4w9WgXcQ", "dQw4w9WgXcQ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := client.ExtractVideoID(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, id)
+		})
+	}
+}
+
+func TestClient_ExtractVideoID_Invalid(t *testing.T) {
+	client := NewClient("")
+
+	for _, input := range []string{"", "not a video", "https://example.com/watch?v=123"} {
+		_, err := client.ExtractVideoID(input)
+		assert.Error(t, err, input)
+	}
+}
@@ -0,0 +1,83 @@
+package youtube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ErrCommentsDisabled is returned when the uploader turned comments off for a video
+var ErrCommentsDisabled = errors.New("comments are disabled for this video")
+
+// Comment is a top-level comment of a video
+type Comment struct {
+	ID          string
+	AuthorName  string
+	Text        string
+	LikeCount   int64
+	ReplyCount  int
+	PublishedAt time.Time
+}
+
+// GetTopComments pages through commentThreads ordered by relevance, which is how YouTube ranks
+// "Top comments". Replies are not fetched. maxComments <= 0 means no limit.
+func (c *Client) GetTopComments(ctx context.Context, videoID string, maxComments int) ([]Comment, error) {
+	var comments []Comment
+	pageToken := ""
+
+	for {
+		apiURL := fmt.Sprintf(
+			"%s/commentThreads?videoId=%s&key=%s&part=snippet&order=relevance&textFormat=plainText&maxResults=100",
+			apiBaseURL, url.QueryEscape(videoID), c.apiKey,
+		)
+		if pageToken != "" {
+			apiURL += "&pageToken=" + url.QueryEscape(pageToken)
+		}
+
+		var result struct {
+			NextPageToken string `json:"nextPageToken"`
+			Items         []struct {
+				Snippet struct {
+					TotalReplyCount int `json:"totalReplyCount"`
+					TopLevelComment struct {
+						ID      string `json:"id"`
+						Snippet struct {
+							AuthorDisplayName string    `json:"authorDisplayName"`
+							TextDisplay       string    `json:"textDisplay"`
+							LikeCount         int64     `json:"likeCount"`
+							PublishedAt       time.Time `json:"publishedAt"`
+						} `json:"snippet"`
+					} `json:"topLevelComment"`
+				} `json:"snippet"`
+			} `json:"items"`
+		}
+
+		if err := c.getJSON(ctx, "commentThreads.list", apiURL, &result); err != nil {
+			return nil, err
+		}
+
+		for _, item := range result.Items {
+			top := item.Snippet.TopLevelComment
+			comments = append(comments, Comment{
+				ID:          top.ID,
+				AuthorName:  top.Snippet.AuthorDisplayName,
+				Text:        top.Snippet.TextDisplay,
+				LikeCount:   top.Snippet.LikeCount,
+				ReplyCount:  item.Snippet.TotalReplyCount,
+				PublishedAt: top.Snippet.PublishedAt,
+			})
+			if maxComments > 0 && len(comments) >= maxComments {
+				return comments, nil
+			}
+		}
+
+		if result.NextPageToken == "" {
+			break
+		}
+		pageToken = result.NextPageToken
+	}
+
+	return comments, nil
+}
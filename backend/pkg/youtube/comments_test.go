@@ -0,0 +1,59 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetTopComments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("videoId") == "disabled000" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"error":{"code":403,"errors":[{"reason":"commentsDisabled"}]}}`)
+			return
+		}
+		assert.Equal(t, "relevance", query.Get("order"))
+
+		if query.Get("pageToken") == "" {
+			fmt.Fprint(w, `{"nextPageToken":"page2","items":[
+				{"snippet":{"totalReplyCount":4,"topLevelComment":{"id":"c1","snippet":{"authorDisplayName":"@ada","textDisplay":"How did you wire the relay?","likeCount":120,"publishedAt":"2024-05-01T10:00:00Z"}}}},
+				{"snippet":{"totalReplyCount":0,"topLevelComment":{"id":"c2","snippet":{"authorDisplayName":"@bob","textDisplay":"Great video","likeCount":30,"publishedAt":"2024-05-01T11:00:00Z"}}}}
+			]}`)
+			return
+		}
+		fmt.Fprint(w, `{"items":[
+			{"snippet":{"totalReplyCount":1,"topLevelComment":{"id":"c3","snippet":{"authorDisplayName":"@cy","textDisplay":"At 3:10 it should be 5V, not 12V","likeCount":8,"publishedAt":"2024-05-02T09:00:00Z"}}}}
+		]}`)
+	}))
+	defer server.Close()
+
+	original := apiBaseURL
+	apiBaseURL = server.URL
+	defer func() { apiBaseURL = original }()
+
+	client := NewClient("key")
+	ctx := context.Background()
+
+	comments, err := client.GetTopComments(ctx, "dQw4w9WgXcQ", 0)
+	require.NoError(t, err)
+	require.Len(t, comments, 3)
+	assert.Equal(t, "c1", comments[0].ID)
+	assert.Equal(t, "@ada", comments[0].AuthorName)
+	assert.Equal(t, int64(120), comments[0].LikeCount)
+	assert.Equal(t, 4, comments[0].ReplyCount)
+	assert.Equal(t, "c3", comments[2].ID)
+
+	comments, err = client.GetTopComments(ctx, "dQw4w9WgXcQ", 2)
+	require.NoError(t, err)
+	assert.Len(t, comments, 2, "paging stops at the limit")
+
+	_, err = client.GetTopComments(ctx, "disabled000", 0)
+	assert.ErrorIs(t, err, ErrCommentsDisabled)
+}
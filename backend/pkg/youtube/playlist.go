@@ -0,0 +1,198 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// playlistIDPattern matches bare playlist IDs (PL..., UU..., OL..., etc.)
+var playlistIDPattern = regexp.MustCompile(`^(?:PL|UU|LL|FL|OL|RD|UL|PU)[a-zA-Z0-9_-]{10,}$`)
+
+type PlaylistInfo struct {
+	ID           string
+	Title        string
+	Description  string
+	ChannelID    string
+	ChannelName  string
+	ItemCount    int
+	ThumbnailURL string
+}
+
+type PlaylistItem struct {
+	VideoID     string
+	Title       string
+	Position    int
+	PublishedAt time.Time
+}
+
+// ExtractPlaylistID returns the playlist ID from a playlist URL (list= parameter) or a bare playlist ID
+func (c *Client) ExtractPlaylistID(playlistURL string) (string, error) {
+	playlistURL = strings.TrimSpace(playlistURL)
+	if playlistIDPattern.MatchString(playlistURL) {
+		return playlistURL, nil
+	}
+
+	u, err := url.Parse(playlistURL)
+	if err != nil || !strings.Contains(u.Host, "youtube.com") && !strings.Contains(u.Host, "youtu.be") {
+		return "", fmt.Errorf("invalid YouTube playlist URL")
+	}
+
+	listID := u.Query().Get("list")
+	if listID == "" || !playlistIDPattern.MatchString(listID) {
+		return "", fmt.Errorf("invalid YouTube playlist URL")
+	}
+
+	return listID, nil
+}
+
+// GetPlaylistInfo fetches playlist metadata from the YouTube Data API
+func (c *Client) GetPlaylistInfo(ctx context.Context, playlistID string) (*PlaylistInfo, error) {
+	apiURL := fmt.Sprintf(
+		"%s/playlists?id=%s&key=%s&part=snippet,contentDetails",
+		apiBaseURL, url.QueryEscape(playlistID), c.apiKey,
+	)
+
+	var result struct {
+		Items []struct {
+			ID      string `json:"id"`
+			Snippet struct {
+				Title        string `json:"title"`
+				Description  string `json:"description"`
+				ChannelID    string `json:"channelId"`
+				ChannelTitle string `json:"channelTitle"`
+				Thumbnails   struct {
+					Default struct {
+						URL string `json:"url"`
+					} `json:"default"`
+					High struct {
+						URL string `json:"url"`
+					} `json:"high"`
+				} `json:"thumbnails"`
+			} `json:"snippet"`
+			ContentDetails struct {
+				ItemCount int `json:"itemCount"`
+			} `json:"contentDetails"`
+		} `json:"items"`
+	}
+
+	if err := c.getJSON(ctx, "playlists.list", apiURL, &result); err != nil {
+		return nil, err
+	}
+
+	if len(result.Items) == 0 {
+		return nil, fmt.Errorf("playlist not found")
+	}
+
+	item := result.Items[0]
+	thumbnailURL := item.Snippet.Thumbnails.High.URL
+	if thumbnailURL == "" {
+		thumbnailURL = item.Snippet.Thumbnails.Default.URL
+	}
+
+	return &PlaylistInfo{
+		ID:           item.ID,
+		Title:        item.Snippet.Title,
+		Description:  item.Snippet.Description,
+		ChannelID:    item.Snippet.ChannelID,
+		ChannelName:  item.Snippet.ChannelTitle,
+		ItemCount:    item.ContentDetails.ItemCount,
+		ThumbnailURL: thumbnailURL,
+	}, nil
+}
+
+// GetPlaylistItems pages through the playlistItems endpoint and returns the videos in playlist order.
+// maxItems <= 0 means no limit.
+func (c *Client) GetPlaylistItems(ctx context.Context, playlistID string, maxItems int) ([]PlaylistItem, error) {
+	var items []PlaylistItem
+	pageToken := ""
+
+	for {
+		apiURL := fmt.Sprintf(
+			"%s/playlistItems?playlistId=%s&key=%s&part=snippet,contentDetails&maxResults=50",
+			apiBaseURL, url.QueryEscape(playlistID), c.apiKey,
+		)
+		if pageToken != "" {
+			apiURL += "&pageToken=" + url.QueryEscape(pageToken)
+		}
+
+		var result struct {
+			NextPageToken string `json:"nextPageToken"`
+			Items         []struct {
+				Snippet struct {
+					Title    string `json:"title"`
+					Position int    `json:"position"`
+				} `json:"snippet"`
+				ContentDetails struct {
+					VideoID          string    `json:"videoId"`
+					VideoPublishedAt time.Time `json:"videoPublishedAt"`
+				} `json:"contentDetails"`
+			} `json:"items"`
+		}
+
+		if err := c.getJSON(ctx, "playlistItems.list", apiURL, &result); err != nil {
+			return nil, err
+		}
+
+		for _, item := range result.Items {
+			if item.ContentDetails.VideoID == "" {
+				continue
+			}
+			items = append(items, PlaylistItem{
+				VideoID:     item.ContentDetails.VideoID,
+				Title:       item.Snippet.Title,
+				Position:    item.Snippet.Position,
+				PublishedAt: item.ContentDetails.VideoPublishedAt,
+			})
+			if maxItems > 0 && len(items) >= maxItems {
+				return items, nil
+			}
+		}
+
+		if result.NextPageToken == "" {
+			break
+		}
+		pageToken = result.NextPageToken
+	}
+
+	return items, nil
+}
+
+// getJSON performs a GET request against the YouTube Data API and decodes the JSON response.
+// The call is booked on the quota ledger under endpoint first.
+func (c *Client) getJSON(ctx context.Context, endpoint, apiURL string, out interface{}) error {
+	if err := c.quota.Reserve(endpoint); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode == http.StatusForbidden && strings.Contains(string(body), "quotaExceeded") {
+			c.quota.MarkExhausted()
+			return fmt.Errorf("%w: %s", ErrQuotaExceeded, string(body))
+		}
+		if resp.StatusCode == http.StatusForbidden && strings.Contains(string(body), "commentsDisabled") {
+			return ErrCommentsDisabled
+		}
+		return fmt.Errorf("YouTube API error: %s", string(body))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
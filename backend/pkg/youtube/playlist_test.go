@@ -0,0 +1,47 @@
+package youtube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ExtractPlaylistID(t *testing.T) {
+	client := NewClient("")
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"playlist URL", "https://www.youtube.com/playlist?list=PLrAXtmErZgOeiKm4sgNOknGvNjby9efdf", "PLrAXtmErZgOeiKm4sgNOknGvNjby9efdf"},
+		{"watch URL with list", "https://www.youtube.com/watch?v=dQw4w9WgXcQ&list=PLrAXtmErZgOeiKm4sgNOknGvNjby9efdf&index=2", "PLrAXtmErZgOeiKm4sgNOknGvNjby9efdf"},
+		{"bare playlist ID", "PLrAXtmErZgOeiKm4sgNOknGvNjby9efdf", "PLrAXtmErZgOeiKm4sgNOknGvNjby9efdf"},
+		{"uploads playlist ID", "UU_x5XG1OV2P6uZZ5FSM9Ttw", "UU_x5XG1OV2P6uZZ5FSM9Ttw"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := client.ExtractPlaylistID(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, id)
+		})
+	}
+}
+
+func TestClient_ExtractPlaylistID_Invalid(t *testing.T) {
+	client := NewClient("")
+
+	inputs := []string{
+		"",
+		"https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+		"https://example.com/playlist?list=PLrAXtmErZgOeiKm4sgNOknGvNjby9efdf",
+		"not a playlist",
+	}
+
+	for _, input := range inputs {
+		_, err := client.ExtractPlaylistID(input)
+		assert.Error(t, err, input)
+	}
+}
@@ -0,0 +1,170 @@
+package youtube
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultDailyQuota is the quota Google grants a new Data API project per day
+const DefaultDailyQuota = 10000
+
+// quotaCosts are the unit costs of the Data API endpoints used by this client
+// (https://developers.google.com/youtube/v3/determine_quota_cost)
+var quotaCosts = map[string]int{
+	"videos.list":         1,
+	"channels.list":       1,
+	"playlists.list":      1,
+	"playlistItems.list":  1,
+	"commentThreads.list": 1,
+	"search.list":         100,
+}
+
+// ErrQuotaExceeded is returned instead of calling the API when the daily budget would be exceeded
+var ErrQuotaExceeded = errors.New("YouTube API daily quota exceeded")
+
+// quotaLocation is the time zone in which the Data API quota resets (midnight Pacific Time)
+var quotaLocation = loadQuotaLocation()
+
+func loadQuotaLocation() *time.Location {
+	if loc, err := time.LoadLocation("America/Los_Angeles"); err == nil {
+		return loc
+	}
+	return time.FixedZone("PST", -8*60*60)
+}
+
+// EndpointUsage is the quota spent on one API endpoint today
+type EndpointUsage struct {
+	Endpoint  string `json:"endpoint"`
+	Calls     int    `json:"calls"`
+	Units     int    `json:"units"`
+	CacheHits int    `json:"cache_hits"`
+}
+
+// QuotaUsage is a snapshot of the ledger for the current quota day
+type QuotaUsage struct {
+	Date      string          `json:"date"`
+	Limit     int             `json:"limit"`
+	Used      int             `json:"used"`
+	Remaining int             `json:"remaining"`
+	Exhausted bool            `json:"exhausted"`
+	ResetAt   time.Time       `json:"reset_at"`
+	Endpoints []EndpointUsage `json:"endpoints"`
+}
+
+// QuotaLedger records the unit cost of every API call made today and refuses calls once the
+// daily budget would be exceeded. It is kept in memory, so a restart starts the day from zero;
+// a quotaExceeded answer from the API marks the day as exhausted regardless.
+type QuotaLedger struct {
+	mu        sync.Mutex
+	limit     int
+	day       string
+	used      int
+	exhausted bool
+	endpoints map[string]*EndpointUsage
+	now       func() time.Time
+}
+
+func NewQuotaLedger(limit int) *QuotaLedger {
+	if limit <= 0 {
+		limit = DefaultDailyQuota
+	}
+	return &QuotaLedger{
+		limit:     limit,
+		endpoints: make(map[string]*EndpointUsage),
+		now:       time.Now,
+	}
+}
+
+// Reserve books the cost of one call to endpoint, or returns ErrQuotaExceeded
+func (l *QuotaLedger) Reserve(endpoint string) error {
+	cost, ok := quotaCosts[endpoint]
+	if !ok {
+		cost = 1
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rollover()
+
+	if l.exhausted || l.used+cost > l.limit {
+		return fmt.Errorf("%w: %s needs %d units, %d of %d left", ErrQuotaExceeded, endpoint, cost, l.limit-l.used, l.limit)
+	}
+
+	l.used += cost
+	usage := l.endpoint(endpoint)
+	usage.Calls++
+	usage.Units += cost
+	return nil
+}
+
+// RecordCacheHit counts a call that was answered from the response cache
+func (l *QuotaLedger) RecordCacheHit(endpoint string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rollover()
+	l.endpoint(endpoint).CacheHits++
+}
+
+// MarkExhausted blocks further calls until the quota resets
+func (l *QuotaLedger) MarkExhausted() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rollover()
+	l.exhausted = true
+}
+
+// Usage returns today's totals
+func (l *QuotaLedger) Usage() QuotaUsage {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rollover()
+
+	endpoints := make([]EndpointUsage, 0, len(l.endpoints))
+	for _, usage := range l.endpoints {
+		endpoints = append(endpoints, *usage)
+	}
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].Endpoint < endpoints[j].Endpoint })
+
+	remaining := l.limit - l.used
+	if l.exhausted || remaining < 0 {
+		remaining = 0
+	}
+
+	now := l.now().In(quotaLocation)
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, quotaLocation)
+
+	return QuotaUsage{
+		Date:      l.day,
+		Limit:     l.limit,
+		Used:      l.used,
+		Remaining: remaining,
+		Exhausted: l.exhausted,
+		ResetAt:   midnight.AddDate(0, 0, 1),
+		Endpoints: endpoints,
+	}
+}
+
+// rollover starts a new ledger day at midnight Pacific Time; callers hold mu
+func (l *QuotaLedger) rollover() {
+	day := l.now().In(quotaLocation).Format("2006-01-02")
+	if day == l.day {
+		return
+	}
+	l.day = day
+	l.used = 0
+	l.exhausted = false
+	l.endpoints = make(map[string]*EndpointUsage)
+}
+
+// endpoint returns the usage entry for endpoint, creating it; callers hold mu
+func (l *QuotaLedger) endpoint(endpoint string) *EndpointUsage {
+	usage, ok := l.endpoints[endpoint]
+	if !ok {
+		usage = &EndpointUsage{Endpoint: endpoint}
+		l.endpoints[endpoint] = usage
+	}
+	return usage
+}
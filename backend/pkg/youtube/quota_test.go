@@ -0,0 +1,105 @@
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaLedger_Reserve(t *testing.T) {
+	ledger := NewQuotaLedger(150)
+
+	require.NoError(t, ledger.Reserve("search.list"))
+	require.NoError(t, ledger.Reserve("videos.list"))
+
+	err := ledger.Reserve("search.list")
+	assert.ErrorIs(t, err, ErrQuotaExceeded, "a second search would exceed the budget")
+
+	require.NoError(t, ledger.Reserve("videos.list"), "cheap calls still fit")
+
+	usage := ledger.Usage()
+	assert.Equal(t, 102, usage.Used)
+	assert.Equal(t, 48, usage.Remaining)
+	require.Len(t, usage.Endpoints, 2)
+	assert.Equal(t, EndpointUsage{Endpoint: "search.list", Calls: 1, Units: 100}, usage.Endpoints[0])
+	assert.Equal(t, EndpointUsage{Endpoint: "videos.list", Calls: 2, Units: 2}, usage.Endpoints[1])
+}
+
+func TestQuotaLedger_ResetsAtPacificMidnight(t *testing.T) {
+	ledger := NewQuotaLedger(100)
+	now := time.Date(2024, 5, 14, 23, 0, 0, 0, quotaLocation)
+	ledger.now = func() time.Time { return now }
+
+	ledger.MarkExhausted()
+	assert.ErrorIs(t, ledger.Reserve("videos.list"), ErrQuotaExceeded)
+	assert.Equal(t, time.Date(2024, 5, 15, 0, 0, 0, 0, quotaLocation), ledger.Usage().ResetAt)
+
+	now = now.Add(2 * time.Hour)
+	require.NoError(t, ledger.Reserve("videos.list"))
+	usage := ledger.Usage()
+	assert.Equal(t, "2024-05-15", usage.Date)
+	assert.Equal(t, 1, usage.Used)
+	assert.False(t, usage.Exhausted)
+}
+
+func TestClient_GetVideosInfo_BatchesAndCaches(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids := strings.Split(r.URL.Query().Get("id"), ",")
+		requests = append(requests, r.URL.Query().Get("id"))
+
+		items := make([]string, 0, len(ids))
+		for _, id := range ids {
+			if id == "missing0000" {
+				continue
+			}
+			items = append(items, fmt.Sprintf(`{"id":%q,"snippet":{"title":"Video %s"},"statistics":{"viewCount":"7"},"contentDetails":{"duration":"PT1M5S"}}`, id, id))
+		}
+		fmt.Fprintf(w, `{"items":[%s]}`, strings.Join(items, ","))
+	}))
+	defer server.Close()
+
+	original := apiBaseURL
+	apiBaseURL = server.URL
+	defer func() { apiBaseURL = original }()
+
+	client := NewClient("key")
+	ctx := context.Background()
+
+	ids := make([]string, 0, 61)
+	for i := 0; i < 60; i++ {
+		ids = append(ids, fmt.Sprintf("video%06d", i))
+	}
+	ids = append(ids, "missing0000", "video000000")
+
+	videos, err := client.GetVideosInfo(ctx, ids)
+	require.NoError(t, err)
+	assert.Len(t, videos, 60)
+	assert.Len(t, requests, 2, "61 unique IDs need two requests of at most 50")
+	assert.Equal(t, 65, videos["video000001"].Duration)
+	assert.Equal(t, int64(7), videos["video000001"].ViewCount)
+
+	info, err := client.GetVideoInfo(ctx, "video000002")
+	require.NoError(t, err)
+	assert.Equal(t, "Video video000002", info.Title)
+	assert.Len(t, requests, 2, "served from the cache")
+
+	_, err = client.GetVideoInfo(WithoutCache(ctx), "video000003")
+	require.NoError(t, err)
+	assert.Len(t, requests, 3, "WithoutCache asks the API again")
+
+	_, err = client.GetVideoInfo(ctx, "missing0000")
+	assert.ErrorIs(t, err, ErrVideoNotFound)
+
+	usage := client.QuotaUsage()
+	assert.Equal(t, 4, usage.Used)
+	require.Len(t, usage.Endpoints, 1)
+	assert.Equal(t, 1, usage.Endpoints[0].CacheHits)
+}
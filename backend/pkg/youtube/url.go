@@ -0,0 +1,133 @@
+package youtube
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// URLKind describes which form of YouTube URL a video reference was given in
+type URLKind string
+
+const (
+	URLKindID        URLKind = "id"         // bare video ID
+	URLKindWatch     URLKind = "watch"      // /watch?v=ID (www, m., music.)
+	URLKindShortLink URLKind = "short_link" // youtu.be/ID
+	URLKindShorts    URLKind = "shorts"     // /shorts/ID
+	URLKindLive      URLKind = "live"       // /live/ID
+	URLKindEmbed     URLKind = "embed"      // /embed/ID, including youtube-nocookie.com
+	URLKindLegacy    URLKind = "legacy"     // /v/ID and /e/ID
+)
+
+var (
+	// videoIDPattern matches a bare 11-character video ID
+	videoIDPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{11}$`)
+	// timestampPattern matches start times like 90, 90s, 1m30s, 1h2m3s
+	timestampPattern = regexp.MustCompile(`^(?:(\d+)h)?(?:(\d+)m)?(?:(\d+)s?)?$`)
+)
+
+// ParsedURL is the structured result of parsing a YouTube video reference
+type ParsedURL struct {
+	VideoID    string  `json:"video_id"`
+	StartTime  int     `json:"start_time,omitempty"` // seconds
+	PlaylistID string  `json:"playlist_id,omitempty"`
+	Kind       URLKind `json:"kind"`
+}
+
+// ParseVideoURL parses a YouTube video URL or bare video ID. It understands watch, youtu.be,
+// Shorts, /live/, /embed/ and /v/ URLs on youtube.com, m.youtube.com, music.youtube.com and
+// youtube-nocookie.com, and picks up the t=/start= timestamp and list= playlist parameters.
+func ParseVideoURL(rawURL string) (*ParsedURL, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if videoIDPattern.MatchString(rawURL) {
+		return &ParsedURL{VideoID: rawURL, Kind: URLKindID}, nil
+	}
+
+	// Allow scheme-less input such as "youtu.be/ID" or "www.youtube.com/watch?v=ID"
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid YouTube URL")
+	}
+
+	host := strings.ToLower(u.Hostname())
+	for _, prefix := range []string{"www.", "m.", "music."} {
+		host = strings.TrimPrefix(host, prefix)
+	}
+
+	query := u.Query()
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	result := &ParsedURL{PlaylistID: query.Get("list")}
+
+	switch host {
+	case "youtu.be":
+		result.VideoID = segments[0]
+		result.Kind = URLKindShortLink
+	case "youtube.com", "youtube-nocookie.com":
+		switch {
+		case segments[0] == "watch":
+			result.VideoID = query.Get("v")
+			result.Kind = URLKindWatch
+		case len(segments) >= 2 && segments[0] == "shorts":
+			result.VideoID = segments[1]
+			result.Kind = URLKindShorts
+		case len(segments) >= 2 && segments[0] == "live":
+			result.VideoID = segments[1]
+			result.Kind = URLKindLive
+		case len(segments) >= 2 && segments[0] == "embed":
+			result.VideoID = segments[1]
+			result.Kind = URLKindEmbed
+		case len(segments) >= 2 && (segments[0] == "v" || segments[0] == "e"):
+			result.VideoID = segments[1]
+			result.Kind = URLKindLegacy
+		}
+	default:
+		return nil, fmt.Errorf("invalid YouTube URL")
+	}
+
+	if !videoIDPattern.MatchString(result.VideoID) {
+		return nil, fmt.Errorf("invalid YouTube URL")
+	}
+
+	// Embeds use start=, everything else t= (sometimes in the fragment: #t=1m30s)
+	timestamp := query.Get("t")
+	if timestamp == "" {
+		timestamp = query.Get("start")
+	}
+	if timestamp == "" && strings.HasPrefix(u.Fragment, "t=") {
+		timestamp = strings.TrimPrefix(u.Fragment, "t=")
+	}
+	result.StartTime = parseTimestamp(timestamp)
+
+	return result, nil
+}
+
+// parseTimestamp converts a YouTube t= value (90, 90s, 1m30s, 1h2m3s) to seconds; invalid values give 0
+func parseTimestamp(value string) int {
+	if value == "" {
+		return 0
+	}
+	matches := timestampPattern.FindStringSubmatch(strings.ToLower(value))
+	if matches == nil {
+		return 0
+	}
+
+	var total int
+	for i, multiplier := range []int{3600, 60, 1} {
+		if matches[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(matches[i+1])
+		if err != nil {
+			return 0
+		}
+		total += n * multiplier
+	}
+	return total
+}
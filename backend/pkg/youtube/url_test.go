@@ -0,0 +1,65 @@
+package youtube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVideoURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected ParsedURL
+	}{
+		{"bare ID", "dQw4w9WgXcQ", ParsedURL{VideoID: "dQw4w9WgXcQ", Kind: URLKindID}},
+		{"watch", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", ParsedURL{VideoID: "dQw4w9WgXcQ", Kind: URLKindWatch}},
+		{"watch with v not first", "https://www.youtube.com/watch?feature=share&v=dQw4w9WgXcQ", ParsedURL{VideoID: "dQw4w9WgXcQ", Kind: URLKindWatch}},
+		{"watch with t and list", "https://www.youtube.com/watch?v=dQw4w9WgXcQ&list=PLrAXtmErZgOeiKm4sgNOknGvNjby9efdf&t=1m30s", ParsedURL{VideoID: "dQw4w9WgXcQ", StartTime: 90, PlaylistID: "PLrAXtmErZgOeiKm4sgNOknGvNjby9efdf", Kind: URLKindWatch}},
+		{"mobile", "https://m.youtube.com/watch?v=dQw4w9WgXcQ", ParsedURL{VideoID: "dQw4w9WgXcQ", Kind: URLKindWatch}},
+		{"music", "https://music.youtube.com/watch?v=dQw4w9WgXcQ&list=RDAMVMdQw4w9WgXcQ", ParsedURL{VideoID: "dQw4w9WgXcQ", PlaylistID: "RDAMVMdQw4w9WgXcQ", Kind: URLKindWatch}},
+		{"no scheme", "youtube.com/watch?v=dQw4w9WgXcQ", ParsedURL{VideoID: "dQw4w9WgXcQ", Kind: URLKindWatch}},
+		{"short link with t", "https://youtu.be/dQw4w9WgXcQ?t=42", ParsedURL{VideoID: "dQw4w9WgXcQ", StartTime: 42, Kind: URLKindShortLink}},
+		{"short link with si", "https://youtu.be/dQw4w9WgXcQ?si=abcDEF123", ParsedURL{VideoID: "dQw4w9WgXcQ", Kind: URLKindShortLink}},
+		{"shorts", "https://www.youtube.com/shorts/dQw4w9WgXcQ", ParsedURL{VideoID: "dQw4w9WgXcQ", Kind: URLKindShorts}},
+		{"live", "https://www.youtube.com/live/dQw4w9WgXcQ?feature=share", ParsedURL{VideoID: "dQw4w9WgXcQ", Kind: URLKindLive}},
+		{"embed with start", "https://www.youtube.com/embed/dQw4w9WgXcQ?start=75", ParsedURL{VideoID: "dQw4w9WgXcQ", StartTime: 75, Kind: URLKindEmbed}},
+		{"nocookie embed", "https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ", ParsedURL{VideoID: "dQw4w9WgXcQ", Kind: URLKindEmbed}},
+		{"legacy v", "https://www.youtube.com/v/dQw4w9WgXcQ", ParsedURL{VideoID: "dQw4w9WgXcQ", Kind: URLKindLegacy}},
+		{"fragment timestamp", "https://www.youtube.com/watch?v=dQw4w9WgXcQ#t=1h2m3s", ParsedURL{VideoID: "dQw4w9WgXcQ", StartTime: 3723, Kind: URLKindWatch}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := ParseVideoURL(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, *parsed)
+		})
+	}
+}
+
+func TestParseVideoURL_Invalid(t *testing.T) {
+	inputs := []string{
+		"",
+		"not a video",
+		"https://example.com/watch?v=dQw4w9WgXcQ",
+		"https://www.youtube.com/watch?v=short",
+		"https://www.youtube.com/playlist?list=PLrAXtmErZgOeiKm4sgNOknGvNjby9efdf",
+		"https://www.youtube.com/@GoogleDevelopers",
+	}
+
+	for _, input := range inputs {
+		_, err := ParseVideoURL(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	assert.Equal(t, 0, parseTimestamp(""))
+	assert.Equal(t, 90, parseTimestamp("90"))
+	assert.Equal(t, 90, parseTimestamp("90s"))
+	assert.Equal(t, 90, parseTimestamp("1m30s"))
+	assert.Equal(t, 3600, parseTimestamp("1h"))
+	assert.Equal(t, 0, parseTimestamp("abc"))
+}
@@ -18,7 +18,7 @@ export function SummaryDisplay({ videoId }: SummaryDisplayProps) {
   const { data: summary, isLoading, refetch } = useSummary(videoId, summaryLanguage)
   const summarizeMutation = useSummarizeVideo()
   const { showToast } = useToast()
-  const [summaryType, setSummaryType] = useState<'short' | 'detailed' | 'bullet_points'>('short')
+  const [summaryType, setSummaryType] = useState<'short' | 'detailed' | 'bullet_points' | 'chapters'>('short')
   const [fromAudio, setFromAudio] = useState(false)
   const [isRegenerating, setIsRegenerating] = useState(false)
   const [isTranslating, setIsTranslating] = useState(false)
@@ -95,6 +95,7 @@ export function SummaryDisplay({ videoId }: SummaryDisplayProps) {
                 <option value="short">Short Summary</option>
                 <option value="detailed">Detailed Summary</option>
                 <option value="bullet_points">Bullet Points</option>
+                <option value="chapters">By Chapter</option>
               </select>
               <Select
                 value={summaryLanguage}
@@ -291,6 +292,7 @@ export function SummaryDisplay({ videoId }: SummaryDisplayProps) {
               <option value="short">Short Summary</option>
               <option value="detailed">Detailed Summary</option>
               <option value="bullet_points">Bullet Points</option>
+              <option value="chapters">By Chapter</option>
             </select>
             <Select
               value={summaryLanguage}
@@ -14,7 +14,7 @@ export function useSummarizeVideo() {
   const queryClient = useQueryClient()
 
   return useMutation({
-    mutationFn: ({ videoId, type, fromAudio, language }: { videoId: string; type?: 'short' | 'detailed' | 'bullet_points'; fromAudio?: boolean; language?: string }) =>
+    mutationFn: ({ videoId, type, fromAudio, language }: { videoId: string; type?: 'short' | 'detailed' | 'bullet_points' | 'chapters'; fromAudio?: boolean; language?: string }) =>
       videoService.summarize(videoId, { type, from_audio: fromAudio, language }),
     onSuccess: (_, variables) => {
       queryClient.invalidateQueries({ queryKey: ['summary', variables.videoId] })
@@ -100,6 +100,7 @@ export function VideoDetail() {
     completed: { icon: CheckCircle, color: 'bg-green-500/10 text-green-600 dark:text-green-400 border-green-500/20', label: 'Completed' },
     processing: { icon: Loader2, color: 'bg-yellow-500/10 text-yellow-600 dark:text-yellow-400 border-yellow-500/20', label: 'Processing' },
     pending: { icon: Clock, color: 'bg-blue-500/10 text-blue-600 dark:text-blue-400 border-blue-500/20', label: 'Pending' },
+    scheduled: { icon: Clock, color: 'bg-purple-500/10 text-purple-600 dark:text-purple-400 border-purple-500/20', label: 'Scheduled' },
     error: { icon: AlertCircle, color: 'bg-red-500/10 text-red-600 dark:text-red-400 border-red-500/20', label: 'Error' },
   }
   const statusInfo = statusConfig[video.status] || statusConfig.pending
@@ -132,7 +133,11 @@ export function VideoDetail() {
               <div className="aspect-video bg-black">
                 <ReactPlayer
                   ref={setPlayerRef}
-                  url={`https://www.youtube.com/watch?v=${video.youtubeId}`}
+                  url={video.sourceType === 'upload'
+                    ? videoService.getMediaUrl(video.id)
+                    : video.sourceType === 'podcast'
+                      ? video.sourceUrl
+                      : `https://www.youtube.com/watch?v=${video.youtubeId}${video.startTime ? `&t=${video.startTime}s` : ''}`}
                   width="100%"
                   height="100%"
                   controls
@@ -33,6 +33,7 @@ export function VideoList() {
     { value: 'completed', label: 'Completed' },
     { value: 'processing', label: 'Processing' },
     { value: 'pending', label: 'Pending' },
+    { value: 'scheduled', label: 'Scheduled' },
     { value: 'error', label: 'Error' },
   ]
 
@@ -1,18 +1,25 @@
 import api, { apiWithExtendedTimeout } from './api'
-import type { Video, Transcript, Summary } from '@/types/video'
+import type { Video, Transcript, Summary, Chapter, CommentSummary, CommentTheme, TranscriptSearchResult, TranscriptRevision } from '@/types/video'
 
 // Transform backend snake_case to frontend camelCase
 function transformVideo(data: any): Video {
   return {
     id: data.id,
     youtubeId: data.youtube_id || data.youtubeId,
+    sourceType: data.source_type || data.sourceType || 'youtube',
+    sourceUrl: data.source_url || data.sourceUrl,
     title: data.title || '',
     description: data.description || '',
     channelId: data.channel_id || data.channelId,
     channelName: data.channel_name || data.channelName,
     duration: data.duration || 0,
+    startTime: data.start_time || data.startTime || 0,
     viewCount: data.view_count || data.viewCount || 0,
     likeCount: data.like_count || data.likeCount || 0,
+    availability: data.availability || 'available',
+    statsUpdatedAt: data.stats_updated_at || data.statsUpdatedAt,
+    liveBroadcastContent: data.live_broadcast_content || data.liveBroadcastContent || 'none',
+    scheduledStartAt: data.scheduled_start_at || data.scheduledStartAt,
     publishedAt: data.published_at || data.publishedAt,
     thumbnailUrl: data.thumbnail_url || data.thumbnailUrl || '',
     tags: data.tags || [],
@@ -24,6 +31,18 @@ function transformVideo(data: any): Video {
   }
 }
 
+function transformRevision(data: any): TranscriptRevision {
+  return {
+    id: data.id,
+    transcriptId: data.transcript_id,
+    number: data.number,
+    author: data.author,
+    comment: data.comment,
+    changes: data.changes || [],
+    createdAt: data.created_at,
+  }
+}
+
 export const videoService = {
   getAll: (params?: { page?: number; limit?: number; offset?: number }) => {
     // Convert page to offset if page is provided (backend uses offset, not page)
@@ -43,11 +62,48 @@ export const videoService = {
     }))
   },
 
+  searchTranscripts: (params: {
+    q: string
+    channel_id?: string
+    language?: string
+    published_after?: string
+    published_before?: string
+    limit?: number
+    offset?: number
+  }) =>
+    api.get<{ results: any[]; total: number; limit: number; offset: number }>('/search/transcripts', { params }).then(res => ({
+      results: res.data.results.map((r: any) => ({
+        video: transformVideo(r.video),
+        hitCount: r.hit_count,
+        hits: (r.hits || []).map((h: any) => ({
+          transcriptId: h.transcript_id,
+          language: h.language,
+          start: h.start,
+          end: h.end,
+          snippet: h.snippet,
+        })),
+      } as TranscriptSearchResult)),
+      total: res.data.total,
+      limit: res.data.limit,
+      offset: res.data.offset,
+    })),
+
   getById: (id: string) =>
     api.get<any>(`/videos/${id}`).then(res => transformVideo(res.data)),
 
-  create: (url: string) =>
-    api.post<any>('/videos', { url }).then(res => transformVideo(res.data)),
+  create: (url: string, opts?: { source?: 'youtube' | 'podcast'; episode_id?: string }) =>
+    api.post<any>('/videos', { url, ...opts }).then(res => transformVideo(res.data)),
+
+  upload: (file: File, title?: string) => {
+    const form = new FormData()
+    form.append('file', file)
+    if (title) form.append('title', title)
+    return apiWithExtendedTimeout
+      .post<any>('/videos/upload', form, { headers: { 'Content-Type': 'multipart/form-data' } })
+      .then(res => transformVideo(res.data))
+  },
+
+  getMediaUrl: (id: string) => `${api.defaults.baseURL}/videos/${id}/media`,
 
   delete: (id: string) =>
     api.delete(`/videos/${id}`).then(res => res.data),
@@ -60,10 +116,57 @@ export const videoService = {
     return api.get<any>(`/videos/${id}/transcript`, { params }).then(res => ({
       ...res.data,
       videoId: res.data.video_id || res.data.videoId,
+      isPrimary: res.data.is_primary ?? res.data.isPrimary,
+      qualityScore: res.data.quality_score ?? res.data.qualityScore,
+      translatedFrom: res.data.translated_from ?? res.data.translatedFrom,
       createdAt: res.data.created_at || res.data.createdAt,
     } as Transcript))
   },
 
+  getTranscriptExportUrl: (id: string, format: 'srt' | 'vtt' | 'txt' | 'json', language?: string, transcriptId?: string) => {
+    const params = new URLSearchParams({ format })
+    if (language) params.set('language', language)
+    if (transcriptId) params.set('transcript_id', transcriptId)
+    return `${api.defaults.baseURL}/videos/${id}/transcript/export?${params}`
+  },
+
+  // Translation sends the transcript to the LLM in batches, so it can take a while
+  translateTranscript: (id: string, language: string, transcriptId?: string) =>
+    apiWithExtendedTimeout
+      .post<any>(`/videos/${id}/transcript/translate`, { language, transcript_id: transcriptId })
+      .then(res => ({
+        ...res.data,
+        videoId: res.data.video_id || res.data.videoId,
+        isPrimary: res.data.is_primary ?? res.data.isPrimary,
+        translatedFrom: res.data.translated_from ?? res.data.translatedFrom,
+        createdAt: res.data.created_at || res.data.createdAt,
+      } as Transcript)),
+
+  editTranscriptSegments: (id: string, transcriptId: string, edit: {
+    author: string
+    comment?: string
+    base_revision?: number
+    segments: Array<{ position: number; text: string }>
+  }) =>
+    api.patch<any>(`/videos/${id}/transcripts/${transcriptId}/segments`, edit).then(res => ({
+      transcript: {
+        ...res.data.transcript,
+        videoId: res.data.transcript.video_id,
+        isPrimary: res.data.transcript.is_primary,
+        qualityScore: res.data.transcript.quality_score,
+        createdAt: res.data.transcript.created_at,
+      } as Transcript,
+      revision: transformRevision(res.data.revision),
+    })),
+
+  listTranscriptRevisions: (id: string, transcriptId: string) =>
+    api.get<{ revisions: any[] }>(`/videos/${id}/transcripts/${transcriptId}/revisions`).then(res =>
+      (res.data.revisions || []).map(transformRevision)
+    ),
+
+  getTranscriptRevision: (id: string, transcriptId: string, number: number) =>
+    api.get<any>(`/videos/${id}/transcripts/${transcriptId}/revisions/${number}`).then(res => transformRevision(res.data)),
+
   getAvailableLanguages: (id: string) =>
     api.get<{ languages: Array<{ code: string; name: string; is_auto_generated: boolean }> }>(`/videos/${id}/transcript/languages`).then(res => res.data.languages),
 
@@ -76,13 +179,44 @@ export const videoService = {
       modelUsed: res.data.model_used || res.data.modelUsed,
       summaryType: res.data.summary_type || res.data.summaryType,
       keyPoints: res.data.key_points || res.data.keyPoints || [],
+      transcriptId: res.data.transcript_id || res.data.transcriptId,
+      stale: res.data.stale ?? false,
       createdAt: res.data.created_at || res.data.createdAt || new Date().toISOString(),
     } as Summary))
   },
 
-  summarize: (id: string, opts?: { type?: 'short' | 'detailed' | 'bullet_points'; from_audio?: boolean; language?: string }) =>
+  summarize: (id: string, opts?: { type?: 'short' | 'detailed' | 'bullet_points' | 'chapters'; from_audio?: boolean; language?: string }) =>
     apiWithExtendedTimeout.post(`/videos/${id}/summarize`, opts).then(res => res.data),
 
+  getChapters: (id: string) =>
+    api.get<{ chapters: any[] }>(`/videos/${id}/chapters`).then(res =>
+      res.data.chapters.map((c: any) => ({
+        id: c.id,
+        title: c.title,
+        startTime: c.start_time,
+        endTime: c.end_time,
+        source: c.source,
+      } as Chapter))
+    ),
+
+  getCommentSummary: (id: string, opts?: { language?: string; refresh?: boolean }) => {
+    const transformThemes = (themes: any[] | null | undefined): CommentTheme[] =>
+      (themes || []).map((t: any) => ({ summary: t.summary, commentCount: t.comment_count, example: t.example }))
+    return apiWithExtendedTimeout.get<any>(`/videos/${id}/comments/summary`, { params: opts }).then(res => ({
+      id: res.data.id,
+      videoId: res.data.video_id,
+      overview: res.data.overview,
+      questions: transformThemes(res.data.questions),
+      corrections: transformThemes(res.data.corrections),
+      praise: transformThemes(res.data.praise),
+      complaints: transformThemes(res.data.complaints),
+      commentCount: res.data.comment_count,
+      language: res.data.language,
+      modelUsed: res.data.model_used,
+      createdAt: res.data.created_at,
+    } as CommentSummary))
+  },
+
   translateSummary: (id: string, language: string) =>
     api.post(`/videos/${id}/summary/translate`, { language }).then(res => res.data),
 
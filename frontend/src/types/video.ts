@@ -1,17 +1,24 @@
 export interface Video {
   id: string
   youtubeId: string
+  sourceType: 'youtube' | 'upload' | 'podcast'
+  sourceUrl?: string
   title: string
   description: string
   channelId: string
   channelName: string
   duration: number
+  startTime: number
   viewCount: number
   likeCount: number
+  availability: 'available' | 'private' | 'deleted'
+  statsUpdatedAt?: string
+  liveBroadcastContent: 'none' | 'upcoming' | 'live'
+  scheduledStartAt?: string
   publishedAt: string
   thumbnailUrl: string
   tags: string[]
-  status: 'pending' | 'processing' | 'completed' | 'error'
+  status: 'scheduled' | 'pending' | 'processing' | 'completed' | 'error'
   hasTranscript: boolean
   hasSummary: boolean
   createdAt: string
@@ -22,27 +29,108 @@ export interface Transcript {
   id: string
   videoId: string
   language: string
-  source: 'youtube' | 'whisper'
+  source: 'youtube' | 'podcast' | 'upload' | 'whisper' | 'translated'
   content: string
   segments: TranscriptSegment[]
+  speakers?: Record<string, string>
+  isPrimary?: boolean
+  qualityScore?: number // 0-1, for published captions
+  translatedFrom?: string // transcript a translation was made from
+  stale?: boolean // a translation whose original was edited after translating
 }
 
 export interface TranscriptSegment {
   start: number
   end: number
   text: string
+  speaker?: string
+  words?: TranscriptWord[]
+}
+
+export interface TranscriptWord {
+  start: number
+  end: number
+  text: string
+}
+
+export interface TranscriptRevision {
+  id: string
+  transcriptId: string
+  number: number
+  author: string
+  comment?: string
+  changes: SegmentChange[]
+  createdAt: string
+}
+
+export interface SegmentChange {
+  position: number
+  start: number
+  end: number
+  before: string
+  after: string
+  diff: DiffPart[]
+}
+
+export interface DiffPart {
+  op: 'equal' | 'insert' | 'delete'
+  text: string
+}
+
+export interface TranscriptSearchResult {
+  video: Video
+  hitCount: number
+  hits: TranscriptSearchHit[]
+}
+
+export interface TranscriptSearchHit {
+  transcriptId: string
+  language: string
+  start: number
+  end: number
+  snippet: string // HTML-escaped text, matched words wrapped in <mark>
+}
+
+export interface Chapter {
+  id: string
+  title: string
+  startTime: number
+  endTime: number
+  source: 'description' | 'yt-dlp'
 }
 
 export interface Summary {
   id: string
   videoId: string
   modelUsed: string
-  summaryType: 'short' | 'detailed' | 'bullet_points'
+  summaryType: 'short' | 'detailed' | 'bullet_points' | 'chapters'
   content: string
   keyPoints: string[]
+  transcriptId?: string
+  stale?: boolean // the transcript was corrected after this summary was generated
   createdAt?: string
 }
 
+export interface CommentTheme {
+  summary: string
+  commentCount: number
+  example?: string
+}
+
+export interface CommentSummary {
+  id: string
+  videoId: string
+  overview: string
+  questions: CommentTheme[]
+  corrections: CommentTheme[]
+  praise: CommentTheme[]
+  complaints: CommentTheme[]
+  commentCount: number
+  language: string
+  modelUsed: string
+  createdAt: string
+}
+
 export interface SimilarVideo {
   video: Video
   similarityScore: number
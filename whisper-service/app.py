@@ -1,4 +1,4 @@
-from fastapi import FastAPI, UploadFile, File, HTTPException, Request
+from fastapi import FastAPI, UploadFile, File, Form, HTTPException, Request
 from faster_whisper import WhisperModel
 import os
 import tempfile
@@ -20,6 +20,12 @@ MAX_TRANSCRIPTION_TIME = 600
 transcription_lock = threading.Lock()
 transcription_in_progress = False
 
+# Speaker diarization (pyannote.audio). Needs the image built with WITH_DIARIZATION=true and a
+# Hugging Face token that has accepted the model's terms.
+diarization_pipeline = None
+diarization_enabled = os.getenv("DIARIZATION_ENABLED", "false").lower() == "true"
+diarization_model = os.getenv("DIARIZATION_MODEL", "pyannote/speaker-diarization-3.1")
+
 @app.on_event("startup")
 async def load_model():
     global model
@@ -34,17 +40,56 @@ async def load_model():
         num_workers=1   # Single worker for transcription
     )
     print("Model loaded successfully")
+    load_diarization_pipeline()
+
+def load_diarization_pipeline():
+    global diarization_pipeline
+    if not diarization_enabled:
+        return
+    try:
+        from pyannote.audio import Pipeline
+    except ImportError:
+        print("Diarization disabled: pyannote.audio is not installed (build with WITH_DIARIZATION=true)")
+        return
+    token = os.getenv("HF_TOKEN")
+    if not token:
+        print("Diarization disabled: HF_TOKEN is not set")
+        return
+    print(f"Loading diarization pipeline: {diarization_model}")
+    diarization_pipeline = Pipeline.from_pretrained(diarization_model, use_auth_token=token)
+    print("Diarization pipeline loaded successfully")
+
+def diarize_file(path):
+    """Returns the speaker turns of a recording as (start, end, label) tuples"""
+    diarization = diarization_pipeline(path)
+    return [
+        (turn.start, turn.end, label)
+        for turn, _, label in diarization.itertracks(yield_label=True)
+    ]
+
+def assign_speaker(start, end, turns):
+    """Returns the label of the speaker who talks the most between start and end"""
+    overlaps = {}
+    for turn_start, turn_end, label in turns:
+        overlap = min(end, turn_end) - max(start, turn_start)
+        if overlap > 0:
+            overlaps[label] = overlaps.get(label, 0) + overlap
+    if not overlaps:
+        return None
+    return max(overlaps, key=overlaps.get)
 
 @app.get("/health")
 async def health():
-    return {"status": "healthy", "model": model_size}
+    return {"status": "healthy", "model": model_size, "diarization": diarization_pipeline is not None}
 
 @app.post("/transcribe")
 async def transcribe(
     request: Request,
     file: UploadFile = File(...),
     language: Optional[str] = None,
-    task: str = "transcribe"
+    task: str = "transcribe",
+    word_timings: bool = Form(False),
+    diarize: bool = Form(False),
 ):
     global transcription_in_progress
     
@@ -110,8 +155,22 @@ async def transcribe(
                 "end": segment.end,
                 "text": segment.text
             }
+            if word_timings and segment.words:
+                segment_data["words"] = [
+                    {"start": word.start, "end": word.end, "word": word.word}
+                    for word in segment.words
+                ]
             transcript_segments.append(segment_data)
             full_text.append(segment.text)
+
+        # Label segments with speakers when asked and a pipeline is loaded; without one the
+        # transcript is returned unlabelled rather than failing
+        if diarize and diarization_pipeline is not None:
+            turns = await loop.run_in_executor(executor, diarize_file, tmp_path)
+            for segment_data in transcript_segments:
+                speaker = assign_speaker(segment_data["start"], segment_data["end"], turns)
+                if speaker:
+                    segment_data["speaker"] = speaker
         
         return {
             "text": " ".join(full_text),